@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"sarc/core/domain"
+	serviceinterfaces "sarc/core/services/interfaces"
+)
+
+// runAttendanceAlerts implements
+//
+//	sarc attendance-alerts [-class id]
+//
+// and is meant to run periodically, e.g. from cron.
+func runAttendanceAlerts(args []string, service serviceinterfaces.AttendanceService, out io.Writer) error {
+	fs := flag.NewFlagSet("attendance-alerts", flag.ContinueOnError)
+	fs.SetOutput(out)
+	classID := fs.Uint("class", 0, "only check this class")
+	fs.Usage = func() {
+		fmt.Fprintln(out, "usage: sarc attendance-alerts [-class id]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var alerts []domain.AttendanceAlert
+	var err error
+	if *classID != 0 {
+		alerts, err = service.NotifyClassAlerts(*classID)
+	} else {
+		alerts, err = service.NotifyAllAlerts()
+	}
+	for _, alert := range alerts {
+		fmt.Fprintf(out, "class %d\tuser %d\t%s\t%s\n", alert.ClassID, alert.UserID, alert.Status, alert.Message)
+	}
+	fmt.Fprintf(out, "%d alerts\n", len(alerts))
+	return err
+}
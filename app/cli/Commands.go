@@ -0,0 +1,28 @@
+// Package cli implements the administrative subcommands run as
+// "sarc [flags] <command> ...", which use the same configuration and
+// database as the HTTP server.
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	serviceinterfaces "sarc/core/services/interfaces"
+)
+
+// Services holds the services the subcommands need.
+type Services struct {
+	Import     serviceinterfaces.ImportService
+	Attendance serviceinterfaces.AttendanceService
+}
+
+// Run executes the subcommand named by args[0].
+func Run(args []string, services Services, out io.Writer) error {
+	switch args[0] {
+	case "import":
+		return runImport(args[1:], services.Import, out)
+	case "attendance-alerts":
+		return runAttendanceAlerts(args[1:], services.Attendance, out)
+	}
+	return fmt.Errorf("unknown command %q, available commands: import, attendance-alerts", args[0])
+}
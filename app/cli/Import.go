@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"sarc/core/domain"
+	serviceinterfaces "sarc/core/services/interfaces"
+	"sarc/pkg/tabular"
+)
+
+// errRejected is returned when the file had invalid rows; the report has
+// already been printed.
+var errRejected = errors.New("import rejected, nothing was written")
+
+// runImport implements
+//
+//	sarc import [-map field=Header,...] [-dry-run] <entity> <file>
+func runImport(args []string, service serviceinterfaces.ImportService, out io.Writer) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(out)
+	mappingFlag := fs.String("map", "", "column mapping, e.g. buildingName=Prédio,roomNumber=Sala")
+	dryRun := fs.Bool("dry-run", false, "validate the file without writing anything")
+	fs.Usage = func() {
+		fmt.Fprintf(out, "usage: sarc import [-map field=Header,...] [-dry-run] <%s> <file.csv|file.xlsx>\n",
+			strings.Join(service.ImportEntities(), "|"))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return errors.New("import needs an entity and a file")
+	}
+	entity, path := fs.Arg(0), fs.Arg(1)
+
+	mapping, err := tabular.ParseColumnMapping(*mappingFlag)
+	if err != nil {
+		return err
+	}
+	format, err := tabular.FormatFromFilename(path)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	table, err := tabular.Read(file, format)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	report, err := service.Import(entity, table, domain.ImportOptions{Mapping: mapping, DryRun: *dryRun})
+	if err != nil {
+		return err
+	}
+	for _, e := range report.Errors {
+		if e.Column != "" {
+			fmt.Fprintf(out, "%s:%d: %s: %s\n", path, e.Row, e.Column, e.Message)
+		} else {
+			fmt.Fprintf(out, "%s:%d: %s\n", path, e.Row, e.Message)
+		}
+	}
+	if len(report.Errors) > 0 {
+		return errRejected
+	}
+	verb := "imported"
+	if report.DryRun {
+		verb = "validated, would import"
+	}
+	fmt.Fprintf(out, "%s %s: %d rows %s (%d inserted, %d updated)\n",
+		path, report.Entity, report.Rows, verb, report.Inserted, report.Updated)
+	return nil
+}
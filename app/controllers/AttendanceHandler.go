@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"sarc/core/domain"
+	serviceinterfaces "sarc/core/services/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AttendanceHandler struct {
+	Service serviceinterfaces.AttendanceService
+}
+
+func NewAttendanceHandler(service serviceinterfaces.AttendanceService) *AttendanceHandler {
+	return &AttendanceHandler{Service: service}
+}
+
+// Get Class Attendance
+// @Summary      Attendance report of a class
+// @Description  Lectures held, present and absent counts, percentage and status of every student enrolled in the class, and of anyone else who attended it; dropped students and teachers are left out. Only lectures that have started count as held. The student list can be exported as CSV, XLSX or NDJSON.
+// @Tags         attendance
+// @Produce      json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/x-ndjson
+// @Param        id      path   int     true   "Class ID"
+// @Param        format  query  string  false  "json (default), csv, xlsx or ndjson; the Accept header is used when omitted"
+// @Success      200  {object}  domain.ClassAttendanceReport
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID or format"
+// @Failure      404  {object}  domain.ErrorResponse "Class not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /classes/{id}/attendance [get]
+func (h *AttendanceHandler) GetClassAttendance(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	format, err := exportFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	report, err := h.Service.GetClassAttendance(uint(id))
+	if err != nil {
+		attendanceFailed(c, err)
+		return
+	}
+	if format != formatJSON {
+		writeExport(c, format, "attendance-class-"+c.Param("id"), attendanceColumns, streamSlice(report.Students))
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// Get Student Attendance
+// @Summary      Attendance report of a student
+// @Description  The user's attendance in each class they are a student of or attended, as JSON or exported as CSV, XLSX or NDJSON
+// @Tags         attendance
+// @Produce      json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/x-ndjson
+// @Param        id      path   int     true   "User ID"
+// @Param        format  query  string  false  "json (default), csv, xlsx or ndjson; the Accept header is used when omitted"
+// @Success      200  {array}   domain.StudentAttendance
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID or format"
+// @Failure      404  {object}  domain.ErrorResponse "User not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /users/{id}/attendance [get]
+func (h *AttendanceHandler) GetStudentAttendance(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	format, err := exportFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	attendance, err := h.Service.GetStudentAttendance(uint(id))
+	if err != nil {
+		attendanceFailed(c, err)
+		return
+	}
+	if format != formatJSON {
+		writeExport(c, format, "attendance-user-"+c.Param("id"), attendanceColumns, streamSlice(attendance))
+		return
+	}
+	c.JSON(http.StatusOK, attendance)
+}
+
+// Notify Class Attendance Alerts
+// @Summary      Send attendance alerts for a class
+// @Description  Flags the students of a class who are at risk of, below or past the minimum attendance, sends a notification to each whose status changed since they were last notified and returns those sent
+// @Tags         attendance
+// @Produce      json
+// @Param        id   path      int  true  "Class ID"
+// @Success      200  {array}   domain.AttendanceAlert
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "Class not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error or failed notification"
+// @Router       /classes/{id}/attendance/alerts [post]
+func (h *AttendanceHandler) NotifyClassAlerts(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	alerts, err := h.Service.NotifyClassAlerts(uint(id))
+	if err != nil {
+		attendanceFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, alerts)
+}
+
+func attendanceFailed(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrClassNotFound), errors.Is(err, domain.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+var attendanceColumns = exportColumns[domain.StudentAttendance]{
+	header: []string{"classId", "userId", "name", "lecturesHeld", "present", "absent", "percentage", "absencesLeft", "status"},
+	record: func(a domain.StudentAttendance) []string {
+		var name string
+		if a.User != nil {
+			name = a.User.Nome
+		}
+		return []string{
+			exportID(a.ClassID), exportID(a.UserID), name,
+			strconv.Itoa(a.LecturesHeld), strconv.Itoa(a.Present), strconv.Itoa(a.Absent),
+			strconv.FormatFloat(a.Percentage, 'f', 1, 64), strconv.Itoa(a.AbsencesLeft), string(a.Status),
+		}
+	},
+}
@@ -11,11 +11,12 @@ import (
 )
 
 type BuildingHandler struct {
-	Service serviceinterfaces.BuildingService
+	Service  serviceinterfaces.BuildingService
+	Includes serviceinterfaces.IncludeService
 }
 
-func NewBuildingHandler(service serviceinterfaces.BuildingService) *BuildingHandler {
-	return &BuildingHandler{Service: service}
+func NewBuildingHandler(service serviceinterfaces.BuildingService, includes serviceinterfaces.IncludeService) *BuildingHandler {
+	return &BuildingHandler{Service: service, Includes: includes}
 }
 
 // Create Building
@@ -45,14 +46,22 @@ func (h *BuildingHandler) CreateBuilding(c *gin.Context) {
 
 // Get All Buildings
 // @Summary      Get all buildings
-// @Description  Retrieves all buildings
+// @Description  Retrieves all buildings, ordered by ID. Use limit and offset to page through them.
 // @Tags         buildings
 // @Produce      json
+// @Param        limit   query     int  false  "Maximum number of buildings"
+// @Param        offset  query     int  false  "Number of buildings to skip"
 // @Success      200   {array}   domain.Building
+// @Failure      400   {object}  domain.ErrorResponse "Invalid page"
 // @Failure      500   {object}  domain.ErrorResponse "Internal server error"
 // @Router       /buildings [get]
 func (h *BuildingHandler) GetBuildings(c *gin.Context) {
-	buildings, err := h.Service.GetBuildings()
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	buildings, err := h.Service.GetBuildings(page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -136,3 +145,39 @@ func (h *BuildingHandler) DeleteBuilding(c *gin.Context) {
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// GetBuildingRooms
+// @Summary      List the rooms of a building
+// @Description  Retrieves the rooms of the given building, ordered by ID. Use limit and offset to page through them.
+// @Tags         buildings
+// @Produce      json
+// @Param        id      path      int  true   "Building ID"
+// @Param        limit   query     int  false  "Maximum number of rooms"
+// @Param        offset  query     int  false  "Number of rooms to skip"
+// @Param        include  query     string  false  "Relations to embed: building"
+// @Success      200  {array}   domain.Room
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID or page"
+// @Failure      404  {object}  domain.ErrorResponse "Building not found"
+// @Router       /buildings/{id}/rooms [get]
+func (h *BuildingHandler) GetBuildingRooms(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	rooms, err := h.Service.GetBuildingRooms(uint(id), page)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.Includes.IncludeRooms(rooms, includeQuery(c)); err != nil {
+		includeFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rooms)
+}
@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"sarc/app/middleware"
+	"sarc/core/domain"
+	serviceinterfaces "sarc/core/services/interfaces"
+
+	"github.com/gin-gonic/gin"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+type CheckInHandler struct {
+	Service serviceinterfaces.CheckInService
+}
+
+func NewCheckInHandler(service serviceinterfaces.CheckInService) *CheckInHandler {
+	return &CheckInHandler{Service: service}
+}
+
+// Get Check-in Token
+// @Summary      Get a lecture's check-in token
+// @Description  Returns the lecture's current check-in token to an active teacher or assistant of the class. It rotates every token period and is accepted until expiresAt.
+// @Tags         presence
+// @Produce      json
+// @Param        id         path      int  true  "Lecture ID"
+// @Param        X-User-ID  header    int  true  "Authenticated user ID"
+// @Success      200  {object}  domain.CheckInToken
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      401  {object}  domain.ErrorResponse "Unknown or missing user"
+// @Failure      403  {object}  domain.ErrorResponse "User not a teacher or assistant of the class"
+// @Failure      404  {object}  domain.ErrorResponse "Lecture not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /lectures/{id}/check-in/token [get]
+func (h *CheckInHandler) GetCheckInToken(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	token, err := h.Service.IssueToken(uint(id), middleware.UserID(c))
+	if err != nil {
+		checkInFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, token)
+}
+
+// Get Check-in QR Code
+// @Summary      Get a lecture's check-in QR code
+// @Description  Renders the lecture's current check-in token as a PNG QR code for a teacher or assistant of the class to show in the room. Fetch it again when the token expires.
+// @Tags         presence
+// @Produce      png
+// @Param        id         path    int  true   "Lecture ID"
+// @Param        X-User-ID  header  int  true   "Authenticated user ID"
+// @Param        size       query   int  false  "Image width and height in pixels, 128 to 1024 (default 256)"
+// @Success      200  {file}    binary
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID or size"
+// @Failure      401  {object}  domain.ErrorResponse "Unknown or missing user"
+// @Failure      403  {object}  domain.ErrorResponse "User not a teacher or assistant of the class"
+// @Failure      404  {object}  domain.ErrorResponse "Lecture not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /lectures/{id}/check-in/qr [get]
+func (h *CheckInHandler) GetCheckInQR(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	size, err := strconv.Atoi(c.DefaultQuery("size", "256"))
+	if err != nil || size < 128 || size > 1024 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid size"})
+		return
+	}
+	token, err := h.Service.IssueToken(uint(id), middleware.UserID(c))
+	if err != nil {
+		checkInFailed(c, err)
+		return
+	}
+	png, err := qrcode.Encode(token.Token, qrcode.Medium, size)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Header("Expires", token.ExpiresAt.UTC().Format(http.TimeFormat))
+	c.Data(http.StatusOK, "image/png", png)
+}
+
+// Check In
+// @Summary      Check in to a lecture
+// @Description  Marks the calling user present with a token scanned from the lecture's QR code. The user must be an active student of the class, the lecture must be in progress and each token can be used once per user. Checking in again while present returns 200 instead of 201.
+// @Tags         presence
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int     true  "Lecture ID"
+// @Param        X-User-ID  header    int     true  "Authenticated user ID"
+// @Param        token      body      object  true  "Scanned token"  Schema({"token":"1.58312345.abc"})
+// @Success      201  {object}  domain.Presence
+// @Success      200  {object}  domain.Presence
+// @Failure      400  {object}  domain.ErrorResponse "Invalid request or token"
+// @Failure      401  {object}  domain.ErrorResponse "Unknown or missing user"
+// @Failure      403  {object}  domain.ErrorResponse "Lecture not in progress or user not enrolled as a student"
+// @Failure      404  {object}  domain.ErrorResponse "Lecture not found"
+// @Failure      409  {object}  domain.ErrorResponse "Token already used"
+// @Failure      410  {object}  domain.ErrorResponse "Token expired"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /lectures/{id}/check-in [post]
+func (h *CheckInHandler) CheckIn(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var req struct {
+		Token string `json:"token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	presence, created, err := h.Service.CheckIn(uint(id), middleware.UserID(c), req.Token)
+	if err != nil {
+		checkInFailed(c, err)
+		return
+	}
+	if created {
+		c.JSON(http.StatusCreated, presence)
+		return
+	}
+	c.JSON(http.StatusOK, presence)
+}
+
+func checkInFailed(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrLectureNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrUserNotFound):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrCheckInTokenInvalid), errors.Is(err, domain.ErrInvalidLectureTime):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrLectureNotInProgress), errors.Is(err, domain.ErrNotEnrolled), errors.Is(err, domain.ErrNotLectureStaff):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrCheckInTokenReused):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrCheckInTokenExpired):
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -11,11 +11,12 @@ import (
 )
 
 type ClassHandler struct {
-	Service serviceinterfaces.ClassService
+	Service  serviceinterfaces.ClassService
+	Includes serviceinterfaces.IncludeService
 }
 
-func NewClassHandler(service serviceinterfaces.ClassService) *ClassHandler {
-	return &ClassHandler{Service: service}
+func NewClassHandler(service serviceinterfaces.ClassService, includes serviceinterfaces.IncludeService) *ClassHandler {
+	return &ClassHandler{Service: service, Includes: includes}
 }
 
 // Create Class
@@ -45,18 +46,31 @@ func (h *ClassHandler) CreateClass(c *gin.Context) {
 
 // Get All Classes
 // @Summary      Get all classes
-// @Description  Retrieves all classes
+// @Description  Retrieves all classes, ordered by ID. Use limit and offset to page through them.
 // @Tags         classes
 // @Produce      json
+// @Param        include  query     string  false  "Relations to embed: discipline"
+// @Param        limit   query     int  false  "Maximum number of classes"
+// @Param        offset  query     int  false  "Number of classes to skip"
 // @Success      200   {array}   domain.Class
+// @Failure      400   {object}  domain.ErrorResponse "Invalid page"
 // @Failure      500   {object}  domain.ErrorResponse "Internal server error"
 // @Router       /classes [get]
 func (h *ClassHandler) GetClasses(c *gin.Context) {
-	classes, err := h.Service.GetClasses()
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	classes, err := h.Service.GetClasses(page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if err := h.Includes.IncludeClasses(classes, includeQuery(c)); err != nil {
+		includeFailed(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, classes)
 }
 
@@ -66,6 +80,7 @@ func (h *ClassHandler) GetClasses(c *gin.Context) {
 // @Tags         classes
 // @Produce      json
 // @Param        id   path      int  true  "Class ID"
+// @Param        include  query     string  false  "Relations to embed: discipline"
 // @Success      200  {object}  domain.Class
 // @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
 // @Failure      404  {object} domain.ErrorResponse "Class not found"
@@ -81,6 +96,10 @@ func (h *ClassHandler) GetClassByID(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
+	if err := includeOne(class, h.Includes.IncludeClasses, includeQuery(c)); err != nil {
+		includeFailed(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, class)
 }
 
@@ -136,3 +155,39 @@ func (h *ClassHandler) DeleteClass(c *gin.Context) {
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// GetClassLectures
+// @Summary      List the lectures of a class
+// @Description  Retrieves the lectures of the given class, ordered by date. Use limit and offset to page through them.
+// @Tags         classes
+// @Produce      json
+// @Param        id      path      int  true   "Class ID"
+// @Param        limit   query     int  false  "Maximum number of lectures"
+// @Param        offset  query     int  false  "Number of lectures to skip"
+// @Param        include  query     string  false  "Relations to embed: class, class.discipline, room, room.building, presence"
+// @Success      200  {array}   domain.Lecture
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID or page"
+// @Failure      404  {object}  domain.ErrorResponse "Class not found"
+// @Router       /classes/{id}/lectures [get]
+func (h *ClassHandler) GetClassLectures(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	lectures, err := h.Service.GetClassLectures(uint(id), page)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.Includes.IncludeLectures(lectures, includeQuery(c)); err != nil {
+		includeFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, lectures)
+}
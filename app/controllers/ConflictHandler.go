@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"sarc/core/domain"
+	serviceinterfaces "sarc/core/services/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ConflictHandler struct {
+	Service serviceinterfaces.ConflictService
+}
+
+func NewConflictHandler(service serviceinterfaces.ConflictService) *ConflictHandler {
+	return &ConflictHandler{Service: service}
+}
+
+// Get Conflicts
+// @Summary      Schedule conflicts of a term
+// @Description  Lists every teacher, assistant or student expected at two overlapping lectures of different classes, through their enrollments. Only lectures with start and end times are checked.
+// @Tags         conflicts
+// @Produce      json
+// @Param        from  query  string  false  "First day, YYYY-MM-DD; defaults to today"
+// @Param        to    query  string  false  "Last day, YYYY-MM-DD; defaults to six months after from"
+// @Success      200  {array}   domain.ScheduleConflict
+// @Failure      400  {object}  domain.ErrorResponse "Invalid date range"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /conflicts [get]
+func (h *ConflictHandler) GetConflicts(c *gin.Context) {
+	conflicts, err := h.Service.GetConflicts(c.Query("from"), c.Query("to"))
+	if errors.Is(err, domain.ErrInvalidDateRange) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, conflicts)
+}
@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -20,13 +22,14 @@ func NewCurriculumHandler(service serviceinterfaces.CurriculumService) *Curricul
 
 // Create Curriculum
 // @Summary      Create a new curriculum
-// @Description  Creates a new curriculum in the system
+// @Description  Creates a new curriculum in the system, placing each discipline listed by ID in its semester
 // @Tags         curriculums
 // @Accept       json
 // @Produce      json
 // @Param        curriculum  body      domain.Curriculum   true  "Curriculum data"
 // @Success      201   {object}  domain.Curriculum
-// @Failure      400   {object}  domain.ErrorResponse "Invalid request"
+// @Failure      400   {object}  domain.ErrorResponse "Invalid request, placement or unknown discipline"
+// @Failure      409   {object}  domain.ErrorResponse "Credit limit exceeded"
 // @Failure      500   {object}  domain.ErrorResponse "Internal server error"
 // @Router       /curriculums [post]
 func (h *CurriculumHandler) CreateCurriculum(c *gin.Context) {
@@ -37,7 +40,7 @@ func (h *CurriculumHandler) CreateCurriculum(c *gin.Context) {
 	}
 	created, err := h.Service.CreateCurriculum(&curriculum)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		curriculumFailed(c, err)
 		return
 	}
 	c.JSON(http.StatusCreated, created)
@@ -45,14 +48,22 @@ func (h *CurriculumHandler) CreateCurriculum(c *gin.Context) {
 
 // Get All Curriculums
 // @Summary      Get all curriculums
-// @Description  Retrieves all curriculums
+// @Description  Retrieves all curriculums, ordered by ID. Use limit and offset to page through them.
 // @Tags         curriculums
 // @Produce      json
+// @Param        limit   query     int  false  "Maximum number of curriculums"
+// @Param        offset  query     int  false  "Number of curriculums to skip"
 // @Success      200   {array}   domain.Curriculum
+// @Failure      400   {object}  domain.ErrorResponse "Invalid page"
 // @Failure      500   {object}  domain.ErrorResponse "Internal server error"
 // @Router       /curriculums [get]
 func (h *CurriculumHandler) GetCurriculums(c *gin.Context) {
-	curriculums, err := h.Service.GetCurriculums()
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	curriculums, err := h.Service.GetCurriculums(page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -62,7 +73,7 @@ func (h *CurriculumHandler) GetCurriculums(c *gin.Context) {
 
 // Get Curriculum by ID
 // @Summary      Get curriculum by ID
-// @Description  Retrieves a curriculum by its ID
+// @Description  Retrieves a curriculum by its ID, with its credits per semester and in total, and the issues that keep it from being published: credit limits broken, disciplines without a semester and requisites placed too late
 // @Tags         curriculums
 // @Produce      json
 // @Param        id   path      int  true  "Curriculum ID"
@@ -86,14 +97,16 @@ func (h *CurriculumHandler) GetCurriculumByID(c *gin.Context) {
 
 // Update Curriculum
 // @Summary      Update an existing curriculum
-// @Description  Updates the curriculum information for the given curriculum ID
+// @Description  Updates the curriculum information for the given curriculum ID. When `disciplines` is given it replaces the curriculum's disciplines, as PUT /curriculums/{id}/disciplines does; when it is left out they are kept.
 // @Tags         curriculums
 // @Accept       json
 // @Produce      json
 // @Param        id         path      int                true  "Curriculum ID"
 // @Param        curriculum body      domain.Curriculum  true  "Curriculum data"
 // @Success      200   {object}  domain.Curriculum
-// @Failure      400   {object}  domain.ErrorResponse "Invalid ID or bad request"
+// @Failure      400   {object}  domain.ErrorResponse "Invalid ID, bad request, placement or unknown discipline"
+// @Failure      404   {object}  domain.ErrorResponse "Curriculum not found"
+// @Failure      409   {object}  domain.ErrorResponse "Credit limit exceeded"
 // @Failure      500   {object}  domain.ErrorResponse "Internal server error"
 // @Router       /curriculums/{id} [put]
 func (h *CurriculumHandler) UpdateCurriculum(c *gin.Context) {
@@ -109,7 +122,7 @@ func (h *CurriculumHandler) UpdateCurriculum(c *gin.Context) {
 	}
 	updated, err := h.Service.UpdateCurriculum(uint(id), &curriculum)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		curriculumFailed(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, updated)
@@ -139,14 +152,16 @@ func (h *CurriculumHandler) DeleteCurriculum(c *gin.Context) {
 
 // Add Discipline to Curriculum
 // @Summary      Add a discipline to a curriculum
-// @Description  Associates a discipline with a curriculum (many-to-many relation)
+// @Description  Associates a discipline with a curriculum (many-to-many relation), placed in a suggested semester as mandatory (default) or elective. Placements that take a semester or the curriculum over its maximum credits are rejected. Adding a discipline already in the curriculum changes nothing.
 // @Tags         curriculums
 // @Accept       json
 // @Produce      json
 // @Param        id           path      int     true  "Curriculum ID"
-// @Param        discipline   body      object  true  "Discipline ID to add"  Schema({"disciplineId":1})
+// @Param        discipline   body      object  true  "Discipline ID to add and its placement"  Schema({"disciplineId":1,"semester":1,"elective":false})
 // @Success      204  {string}  string "No Content"
-// @Failure      400  {object}  domain.ErrorResponse "Invalid curriculum ID or bad request"
+// @Failure      400  {object}  domain.ErrorResponse "Invalid curriculum ID, bad request or unknown discipline"
+// @Failure      404  {object}  domain.ErrorResponse "Curriculum not found"
+// @Failure      409  {object}  domain.ErrorResponse "Credit limit exceeded"
 // @Failure      500  {object}  domain.ErrorResponse "Internal server error"
 // @Router       /curriculums/{id}/disciplines [post]
 func (h *CurriculumHandler) AddDisciplineToCurriculum(c *gin.Context) {
@@ -157,15 +172,365 @@ func (h *CurriculumHandler) AddDisciplineToCurriculum(c *gin.Context) {
 	}
 	var req struct {
 		DisciplineID uint `json:"disciplineId"`
+		Semester     int  `json:"semester"`
+		Elective     bool `json:"elective"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
-	err = h.Service.AddDisciplineToCurriculum(uint(curriculumID), req.DisciplineID)
+	err = h.Service.AddDisciplineToCurriculum(uint(curriculumID), domain.Discipline{ID: req.DisciplineID, Semester: req.Semester, Elective: req.Elective})
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		curriculumFailed(c, err)
 		return
 	}
 	c.Status(204)
 }
+
+// Set Curriculum Disciplines
+// @Summary      Replace the disciplines of a curriculum
+// @Description  Makes the given list the curriculum's disciplines: missing ones are added, listed ones are moved to the given semester, and unlisted ones are removed with their requisites. Nothing changes unless the whole list is accepted.
+// @Tags         curriculums
+// @Accept       json
+// @Produce      json
+// @Param        id           path      int     true  "Curriculum ID"
+// @Param        disciplines  body      object  true  "Disciplines and their placements"  Schema([{"disciplineId":1,"semester":1,"elective":false}])
+// @Success      200  {object}  domain.Curriculum
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID, bad request, placement or unknown discipline"
+// @Failure      404  {object}  domain.ErrorResponse "Curriculum not found"
+// @Failure      409  {object}  domain.ErrorResponse "Credit limit exceeded"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /curriculums/{id}/disciplines [put]
+func (h *CurriculumHandler) SetDisciplines(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var req []struct {
+		DisciplineID uint `json:"disciplineId" binding:"required"`
+		Semester     int  `json:"semester"`
+		Elective     bool `json:"elective"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	disciplines := make([]domain.Discipline, len(req))
+	for i, d := range req {
+		disciplines[i] = domain.Discipline{ID: d.DisciplineID, Semester: d.Semester, Elective: d.Elective}
+	}
+	curriculum, err := h.Service.SetDisciplines(uint(id), disciplines)
+	if err != nil {
+		curriculumFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, curriculum)
+}
+
+// Remove Discipline from Curriculum
+// @Summary      Remove a discipline from a curriculum
+// @Description  Removes a discipline from the curriculum, along with the requisites to and from it
+// @Tags         curriculums
+// @Param        id            path  int  true  "Curriculum ID"
+// @Param        disciplineId  path  int  true  "Discipline ID"
+// @Success      204
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "Curriculum not found or discipline not part of it"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /curriculums/{id}/disciplines/{disciplineId} [delete]
+func (h *CurriculumHandler) RemoveDisciplineFromCurriculum(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	disciplineID, err := strconv.Atoi(c.Param("disciplineId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid discipline ID"})
+		return
+	}
+	if err := h.Service.RemoveDisciplineFromCurriculum(uint(id), uint(disciplineID)); err != nil {
+		curriculumFailed(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Place Discipline in Curriculum
+// @Summary      Place a discipline of a curriculum
+// @Description  Moves a discipline of the curriculum to another semester or changes whether it is elective. Moves that take the semester over its maximum mandatory credits are rejected.
+// @Tags         curriculums
+// @Accept       json
+// @Produce      json
+// @Param        id            path      int     true  "Curriculum ID"
+// @Param        disciplineId  path      int     true  "Discipline ID"
+// @Param        placement     body      object  true  "Semester and elective flag"  Schema({"semester":2,"elective":false})
+// @Success      200  {object}  domain.Curriculum
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID, bad request or negative semester"
+// @Failure      404  {object}  domain.ErrorResponse "Curriculum not found or discipline not part of it"
+// @Failure      409  {object}  domain.ErrorResponse "Credit limit exceeded"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /curriculums/{id}/disciplines/{disciplineId} [put]
+func (h *CurriculumHandler) PlaceDiscipline(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	disciplineID, err := strconv.Atoi(c.Param("disciplineId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid discipline ID"})
+		return
+	}
+	var req struct {
+		Semester int  `json:"semester"`
+		Elective bool `json:"elective"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	curriculum, err := h.Service.PlaceDiscipline(uint(id), domain.Discipline{ID: uint(disciplineID), Semester: req.Semester, Elective: req.Elective})
+	if err != nil {
+		curriculumFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, curriculum)
+}
+
+// Get Curriculum Requisites
+// @Summary      List the requisites of a curriculum
+// @Description  Retrieves the prerequisite and co-requisite relations between the disciplines of a curriculum
+// @Tags         curriculums
+// @Produce      json
+// @Param        id   path      int  true  "Curriculum ID"
+// @Success      200  {array}   domain.Requisite
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "Curriculum not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /curriculums/{id}/requisites [get]
+func (h *CurriculumHandler) GetRequisites(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	requisites, err := h.Service.GetRequisites(uint(id))
+	if err != nil {
+		requisiteFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, requisites)
+}
+
+// Add Curriculum Requisite
+// @Summary      Add a requisite to a curriculum
+// @Description  Makes a discipline of the curriculum require another one, as a prerequisite (default) that must be passed first or as a co-requisite that may be taken in the same term. Both disciplines must belong to the curriculum, and requisites that would make a discipline depend on itself through a prerequisite are rejected.
+// @Tags         curriculums
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int     true  "Curriculum ID"
+// @Param        requisite  body      object  true  "Discipline, requisite and kind"  Schema({"disciplineId":2,"requisiteId":1,"kind":"prerequisite"})
+// @Success      201  {object}  domain.Requisite
+// @Failure      400  {object}  domain.ErrorResponse "Invalid request, or discipline outside the curriculum"
+// @Failure      404  {object}  domain.ErrorResponse "Curriculum not found"
+// @Failure      409  {object}  domain.ErrorResponse "Requisite already exists or would create a cycle"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /curriculums/{id}/requisites [post]
+func (h *CurriculumHandler) AddRequisite(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var req struct {
+		DisciplineID uint                 `json:"disciplineId" binding:"required"`
+		RequisiteID  uint                 `json:"requisiteId" binding:"required"`
+		Kind         domain.RequisiteKind `json:"kind"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	requisite, err := h.Service.AddRequisite(&domain.Requisite{CurriculumID: uint(id), DisciplineID: req.DisciplineID, RequisiteID: req.RequisiteID, Kind: req.Kind})
+	if err != nil {
+		requisiteFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, requisite)
+}
+
+// Remove Curriculum Requisite
+// @Summary      Remove a requisite from a curriculum
+// @Description  Removes the relation between a discipline and one of its requisites
+// @Tags         curriculums
+// @Param        id            path  int  true  "Curriculum ID"
+// @Param        disciplineId  path  int  true  "Discipline ID"
+// @Param        requisiteId   path  int  true  "Requisite discipline ID"
+// @Success      204
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "Curriculum or requisite not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /curriculums/{id}/requisites/{disciplineId}/{requisiteId} [delete]
+func (h *CurriculumHandler) RemoveRequisite(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	disciplineID, err := strconv.Atoi(c.Param("disciplineId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid discipline ID"})
+		return
+	}
+	requisiteID, err := strconv.Atoi(c.Param("requisiteId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid requisite ID"})
+		return
+	}
+	if err := h.Service.RemoveRequisite(uint(id), uint(disciplineID), uint(requisiteID)); err != nil {
+		requisiteFailed(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Get Curriculum Graph
+// @Summary      Get the requisite graph of a curriculum
+// @Description  Returns the disciplines of the curriculum as nodes, and an edge from each discipline to each of its requisites
+// @Tags         curriculums
+// @Produce      json
+// @Param        id   path      int  true  "Curriculum ID"
+// @Success      200  {object}  domain.CurriculumGraph
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "Curriculum not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /curriculums/{id}/graph [get]
+func (h *CurriculumHandler) GetGraph(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	graph, err := h.Service.GetGraph(uint(id))
+	if err != nil {
+		requisiteFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, graph)
+}
+
+// Clone Curriculum
+// @Summary      Create a new version of a curriculum
+// @Description  Creates the next version in the curriculum's lineage, copying its disciplines with their semesters and its requisites. The body is optional; any name or date given replaces the copied one.
+// @Tags         curriculums
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int     true   "Curriculum ID"
+// @Param        curriculum  body      object  false  "Name and dates of the new version"  Schema({"courseName":"Engineering","dataInicio":"2030-01-01","dataFim":"2034-12-31"})
+// @Success      201  {object}  domain.Curriculum
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID or bad request"
+// @Failure      404  {object}  domain.ErrorResponse "Curriculum not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /curriculums/{id}/clone [post]
+func (h *CurriculumHandler) CloneCurriculum(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var overrides domain.Curriculum
+	if err := c.ShouldBindJSON(&overrides); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	clone, err := h.Service.CloneCurriculum(uint(id), overrides)
+	if err != nil {
+		curriculumFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, clone)
+}
+
+// Get Curriculum Versions
+// @Summary      List the versions of a curriculum
+// @Description  Retrieves every curriculum in the same lineage as the given one, ordered by version
+// @Tags         curriculums
+// @Produce      json
+// @Param        id   path      int  true  "Curriculum ID"
+// @Success      200  {array}   domain.Curriculum
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "Curriculum not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /curriculums/{id}/versions [get]
+func (h *CurriculumHandler) GetVersions(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	versions, err := h.Service.GetVersions(uint(id))
+	if err != nil {
+		curriculumFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, versions)
+}
+
+// Diff Curriculums
+// @Summary      Compare two curriculums
+// @Description  Reports the disciplines added, removed, and changed in credits, semester or elective flag from one curriculum to another. Disciplines are matched by ID, then by name, so one replaced by a revised discipline of the same name counts as changed.
+// @Tags         curriculums
+// @Produce      json
+// @Param        id       path      int  true  "Curriculum ID to compare from"
+// @Param        otherId  path      int  true  "Curriculum ID to compare to"
+// @Success      200  {object}  domain.CurriculumDiff
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "Curriculum not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /curriculums/{id}/diff/{otherId} [get]
+func (h *CurriculumHandler) DiffCurriculums(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	otherID, err := strconv.Atoi(c.Param("otherId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	diff, err := h.Service.DiffCurriculums(uint(id), uint(otherID))
+	if err != nil {
+		curriculumFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
+
+func curriculumFailed(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrCurriculumNotFound), errors.Is(err, domain.ErrNotInCurriculum):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidPlacement), errors.Is(err, domain.ErrDisciplineNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrCreditLimit):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func requisiteFailed(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrCurriculumNotFound), errors.Is(err, domain.ErrRequisiteNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidRequisite), errors.Is(err, domain.ErrNotInCurriculum):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrRequisiteExists), errors.Is(err, domain.ErrRequisiteCycle):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
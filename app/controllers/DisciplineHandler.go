@@ -11,11 +11,12 @@ import (
 )
 
 type DisciplineHandler struct {
-	Service serviceinterfaces.DisciplineService
+	Service  serviceinterfaces.DisciplineService
+	Includes serviceinterfaces.IncludeService
 }
 
-func NewDisciplineHandler(service serviceinterfaces.DisciplineService) *DisciplineHandler {
-	return &DisciplineHandler{Service: service}
+func NewDisciplineHandler(service serviceinterfaces.DisciplineService, includes serviceinterfaces.IncludeService) *DisciplineHandler {
+	return &DisciplineHandler{Service: service, Includes: includes}
 }
 
 // Create Discipline
@@ -45,14 +46,22 @@ func (h *DisciplineHandler) CreateDiscipline(c *gin.Context) {
 
 // Get All Disciplines
 // @Summary      Get all disciplines
-// @Description  Retrieves all disciplines
+// @Description  Retrieves all disciplines, ordered by ID. Use limit and offset to page through them.
 // @Tags         disciplines
 // @Produce      json
+// @Param        limit   query     int  false  "Maximum number of disciplines"
+// @Param        offset  query     int  false  "Number of disciplines to skip"
 // @Success      200   {array}   domain.Discipline
+// @Failure      400   {object}  domain.ErrorResponse "Invalid page"
 // @Failure      500   {object}  domain.ErrorResponse "Internal server error"
 // @Router       /disciplines [get]
 func (h *DisciplineHandler) GetDisciplines(c *gin.Context) {
-	disciplines, err := h.Service.GetDisciplines()
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	disciplines, err := h.Service.GetDisciplines(page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -136,3 +145,39 @@ func (h *DisciplineHandler) DeleteDiscipline(c *gin.Context) {
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// GetDisciplineClasses
+// @Summary      List the classes of a discipline
+// @Description  Retrieves the classes of the given discipline, ordered by ID. Use limit and offset to page through them.
+// @Tags         disciplines
+// @Produce      json
+// @Param        id      path      int  true   "Discipline ID"
+// @Param        limit   query     int  false  "Maximum number of classes"
+// @Param        offset  query     int  false  "Number of classes to skip"
+// @Param        include  query     string  false  "Relations to embed: discipline"
+// @Success      200  {array}   domain.Class
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID or page"
+// @Failure      404  {object}  domain.ErrorResponse "Discipline not found"
+// @Router       /disciplines/{id}/classes [get]
+func (h *DisciplineHandler) GetDisciplineClasses(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	classes, err := h.Service.GetDisciplineClasses(uint(id), page)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.Includes.IncludeClasses(classes, includeQuery(c)); err != nil {
+		includeFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, classes)
+}
@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"sarc/core/domain"
+	serviceinterfaces "sarc/core/services/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EnrollmentHandler struct {
+	Service serviceinterfaces.EnrollmentService
+}
+
+func NewEnrollmentHandler(service serviceinterfaces.EnrollmentService) *EnrollmentHandler {
+	return &EnrollmentHandler{Service: service}
+}
+
+// Enroll User
+// @Summary      Enroll a user in a class
+// @Description  Enrolls a user as student (default), teacher or assistant. Only users whose profile allows teaching can be enrolled as teachers. Enrolling a dropped user reactivates the enrollment; enrolling someone already active changes their role and returns 200 instead of 201. Enrolling a user in a class whose lectures overlap their other classes is rejected, or only reported in warnings when the conflict limit is soft.
+// @Tags         enrollments
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int     true  "Class ID"
+// @Param        enrollment  body      object  true  "User and role"  Schema({"userId":1,"role":"student"})
+// @Success      201  {object}  domain.Enrollment
+// @Success      200  {object}  domain.Enrollment
+// @Failure      400  {object}  domain.ErrorResponse "Invalid request, role or unknown user"
+// @Failure      403  {object}  domain.ErrorResponse "User's profile does not allow teaching"
+// @Failure      404  {object}  domain.ErrorResponse "Class not found"
+// @Failure      409  {object}  domain.ErrorResponse "Class lectures overlap the user's schedule"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /classes/{id}/enrollments [post]
+func (h *EnrollmentHandler) Enroll(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var req struct {
+		UserID uint                  `json:"userId" binding:"required"`
+		Role   domain.EnrollmentRole `json:"role"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	enrollment, created, err := h.Service.Enroll(uint(id), req.UserID, req.Role)
+	if err != nil {
+		enrollmentFailed(c, err)
+		return
+	}
+	if created {
+		c.JSON(http.StatusCreated, enrollment)
+		return
+	}
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// Get Roster
+// @Summary      List the roster of a class
+// @Description  Retrieves the users enrolled in a class, optionally filtered by status and role
+// @Tags         enrollments
+// @Produce      json
+// @Param        id      path   int     true   "Class ID"
+// @Param        status  query  string  false  "active, dropped or completed"
+// @Param        role    query  string  false  "student, teacher or assistant"
+// @Success      200  {array}   domain.Enrollment
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID, status or role"
+// @Failure      404  {object}  domain.ErrorResponse "Class not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /classes/{id}/enrollments [get]
+func (h *EnrollmentHandler) GetRoster(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	roster, err := h.Service.GetRoster(uint(id), domain.EnrollmentStatus(c.Query("status")), domain.EnrollmentRole(c.Query("role")))
+	if err != nil {
+		enrollmentFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, roster)
+}
+
+// Drop Enrollment
+// @Summary      Drop a user from a class
+// @Description  Marks the user's enrollment as dropped. The enrollment is kept, with the date it was dropped.
+// @Tags         enrollments
+// @Param        id      path  int  true  "Class ID"
+// @Param        userId  path  int  true  "User ID"
+// @Success      204
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "User not enrolled in the class"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /classes/{id}/enrollments/{userId} [delete]
+func (h *EnrollmentHandler) Drop(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	if err := h.Service.Drop(uint(id), uint(userID)); err != nil {
+		enrollmentFailed(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Get User Enrollments
+// @Summary      List the classes of a user
+// @Description  Retrieves the user's enrollments in every class, including dropped and completed ones
+// @Tags         enrollments
+// @Produce      json
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {array}   domain.Enrollment
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "User not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /users/{id}/enrollments [get]
+func (h *EnrollmentHandler) GetUserEnrollments(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	enrollments, err := h.Service.GetUserEnrollments(uint(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, enrollments)
+}
+
+func enrollmentFailed(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrClassNotFound), errors.Is(err, domain.ErrNotEnrolled):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidEnrollment), errors.Is(err, domain.ErrUserNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrCannotTeach):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrScheduleConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
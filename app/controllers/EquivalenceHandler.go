@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"sarc/core/domain"
+	serviceinterfaces "sarc/core/services/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EquivalenceHandler struct {
+	Service serviceinterfaces.EquivalenceService
+}
+
+func NewEquivalenceHandler(service serviceinterfaces.EquivalenceService) *EquivalenceHandler {
+	return &EquivalenceHandler{Service: service}
+}
+
+// Create Equivalence
+// @Summary      Create a discipline equivalence
+// @Description  Declares that passing every source discipline credits the target discipline. One source makes a one-to-one equivalence, several a many-to-one. Equivalences only work from the sources to the target.
+// @Tags         equivalences
+// @Accept       json
+// @Produce      json
+// @Param        equivalence  body      object  true  "Target and source disciplines"  Schema({"targetId":3,"sourceIds":[1,2]})
+// @Success      201  {object}  domain.Equivalence
+// @Failure      400  {object}  domain.ErrorResponse "Invalid equivalence or unknown discipline"
+// @Failure      409  {object}  domain.ErrorResponse "Equivalence already exists"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /equivalences [post]
+func (h *EquivalenceHandler) CreateEquivalence(c *gin.Context) {
+	var equivalence domain.Equivalence
+	if err := c.ShouldBindJSON(&equivalence); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	created, err := h.Service.CreateEquivalence(&equivalence)
+	if err != nil {
+		equivalenceFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// Get Equivalences
+// @Summary      List discipline equivalences
+// @Description  Retrieves every equivalence, or only those in which a discipline is the target or a source, ordered by ID. Use limit and offset to page through them.
+// @Tags         equivalences
+// @Produce      json
+// @Param        disciplineId  query     int  false  "Discipline ID"
+// @Param        limit   query     int  false  "Maximum number of equivalences"
+// @Param        offset  query     int  false  "Number of equivalences to skip"
+// @Success      200  {array}   domain.Equivalence
+// @Failure      400  {object}  domain.ErrorResponse "Invalid discipline ID or page"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /equivalences [get]
+func (h *EquivalenceHandler) GetEquivalences(c *gin.Context) {
+	disciplineID := 0
+	if v := c.Query("disciplineId"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid discipline ID"})
+			return
+		}
+		disciplineID = n
+	}
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	equivalences, err := h.Service.GetEquivalences(uint(disciplineID), page)
+	if err != nil {
+		equivalenceFailed(c, err)
+		return
+	}
+	if equivalences == nil {
+		equivalences = []domain.Equivalence{}
+	}
+	c.JSON(http.StatusOK, equivalences)
+}
+
+// Get Equivalence by ID
+// @Summary      Get a discipline equivalence
+// @Tags         equivalences
+// @Produce      json
+// @Param        id   path      int  true  "Equivalence ID"
+// @Success      200  {object}  domain.Equivalence
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "Equivalence not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /equivalences/{id} [get]
+func (h *EquivalenceHandler) GetEquivalenceByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	equivalence, err := h.Service.GetEquivalenceByID(uint(id))
+	if err != nil {
+		equivalenceFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, equivalence)
+}
+
+// Delete Equivalence
+// @Summary      Delete a discipline equivalence
+// @Tags         equivalences
+// @Param        id   path  int  true  "Equivalence ID"
+// @Success      204
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "Equivalence not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /equivalences/{id} [delete]
+func (h *EquivalenceHandler) DeleteEquivalence(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	if err := h.Service.DeleteEquivalence(uint(id)); err != nil {
+		equivalenceFailed(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Transfer Credits
+// @Summary      Credit completed disciplines in a curriculum
+// @Description  Works out which disciplines of the curriculum are credited by a set of completed disciplines, either directly or through equivalences. Each completed discipline credits one discipline at most; the disciplines left over are listed as unused.
+// @Tags         equivalences
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int     true  "Curriculum ID"
+// @Param        completed  body      object  true  "Completed disciplines"  Schema({"completed":[1,2]})
+// @Success      200  {object}  domain.CreditTransfer
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID, bad request or unknown discipline"
+// @Failure      404  {object}  domain.ErrorResponse "Curriculum not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /curriculums/{id}/credit-transfer [post]
+func (h *EquivalenceHandler) TransferCredits(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var req struct {
+		Completed []uint `json:"completed"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	transfer, err := h.Service.TransferCredits(uint(id), req.Completed)
+	if err != nil {
+		equivalenceFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, transfer)
+}
+
+func equivalenceFailed(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrEquivalenceNotFound), errors.Is(err, domain.ErrCurriculumNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidEquivalence), errors.Is(err, domain.ErrDisciplineNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrEquivalenceExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,265 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"sarc/core/domain"
+	serviceinterfaces "sarc/core/services/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GradeHandler struct {
+	Service serviceinterfaces.GradeService
+}
+
+func NewGradeHandler(service serviceinterfaces.GradeService) *GradeHandler {
+	return &GradeHandler{Service: service}
+}
+
+// Create Assessment
+// @Summary      Create an assessment in a class
+// @Description  Adds a graded piece of work to the class. Final grades are the average of a student's grades weighted by the assessments' weights.
+// @Tags         grades
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int     true  "Class ID"
+// @Param        assessment  body      object  true  "Name and weight"  Schema({"name":"Prova 1","weight":2})
+// @Success      201  {object}  domain.Assessment
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID, bad request or assessment"
+// @Failure      404  {object}  domain.ErrorResponse "Class not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /classes/{id}/assessments [post]
+func (h *GradeHandler) CreateAssessment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var assessment domain.Assessment
+	if err := c.ShouldBindJSON(&assessment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	assessment.ID, assessment.ClassID = 0, uint(id)
+	created, err := h.Service.CreateAssessment(&assessment)
+	if err != nil {
+		gradeFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// Get Assessments
+// @Summary      List the assessments of a class
+// @Tags         grades
+// @Produce      json
+// @Param        id   path      int  true  "Class ID"
+// @Success      200  {array}   domain.Assessment
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "Class not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /classes/{id}/assessments [get]
+func (h *GradeHandler) GetAssessments(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	assessments, err := h.Service.GetAssessments(uint(id))
+	if err != nil {
+		gradeFailed(c, err)
+		return
+	}
+	if assessments == nil {
+		assessments = []domain.Assessment{}
+	}
+	c.JSON(http.StatusOK, assessments)
+}
+
+// Update Assessment
+// @Summary      Update an assessment
+// @Description  Changes the name and weight of an assessment
+// @Tags         grades
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int     true  "Assessment ID"
+// @Param        assessment  body      object  true  "Name and weight"  Schema({"name":"Prova 1","weight":3})
+// @Success      200  {object}  domain.Assessment
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID, bad request or assessment"
+// @Failure      404  {object}  domain.ErrorResponse "Assessment not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /assessments/{id} [put]
+func (h *GradeHandler) UpdateAssessment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var assessment domain.Assessment
+	if err := c.ShouldBindJSON(&assessment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	updated, err := h.Service.UpdateAssessment(uint(id), &assessment)
+	if err != nil {
+		gradeFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// Delete Assessment
+// @Summary      Delete an assessment
+// @Description  Removes an assessment along with its grades
+// @Tags         grades
+// @Param        id   path  int  true  "Assessment ID"
+// @Success      204
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "Assessment not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /assessments/{id} [delete]
+func (h *GradeHandler) DeleteAssessment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	if err := h.Service.DeleteAssessment(uint(id)); err != nil {
+		gradeFailed(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Set Grade
+// @Summary      Grade a student in an assessment
+// @Description  Saves a student's grade in an assessment, replacing any previous one. Only students of the assessment's class can be graded.
+// @Tags         grades
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int     true  "Assessment ID"
+// @Param        userId  path      int     true  "User ID"
+// @Param        grade   body      object  true  "Grade"  Schema({"value":8.5})
+// @Success      200  {object}  domain.Grade
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID, bad request or grade out of range"
+// @Failure      404  {object}  domain.ErrorResponse "Assessment not found or user not a student of the class"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /assessments/{id}/grades/{userId} [put]
+func (h *GradeHandler) SetGrade(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	var req struct {
+		Value *float64 `json:"value" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	grade, err := h.Service.SetGrade(&domain.Grade{AssessmentID: uint(id), UserID: uint(userID), Value: *req.Value})
+	if err != nil {
+		gradeFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, grade)
+}
+
+// Get Class Results
+// @Summary      Get the results of a class
+// @Description  Works out every student's final grade and status so far. Students with assessments still to be graded are in progress, and those who can no longer reach the minimum attendance have failed by attendance.
+// @Tags         grades
+// @Produce      json
+// @Param        id   path      int  true  "Class ID"
+// @Success      200  {object}  domain.ClassResults
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "Class not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /classes/{id}/results [get]
+func (h *GradeHandler) GetClassResults(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	results, err := h.Service.GetClassResults(uint(id))
+	if err != nil {
+		gradeFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
+
+// Close Class
+// @Summary      Close a class
+// @Description  Settles the results of the class, counting missing grades as zero and judging attendance on the lectures held, records them in each student's history and marks their enrollments completed. Closing a class again replaces its records.
+// @Tags         grades
+// @Produce      json
+// @Param        id   path      int  true  "Class ID"
+// @Success      200  {object}  domain.ClassResults
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID or class without assessments"
+// @Failure      404  {object}  domain.ErrorResponse "Class not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /classes/{id}/close [post]
+func (h *GradeHandler) CloseClass(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	results, err := h.Service.CloseClass(uint(id))
+	if err != nil {
+		gradeFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
+
+// Get Academic History
+// @Summary      Get a user's academic history
+// @Description  Lists the user's results in every closed class, with the discipline. The approved ones are the disciplines the user has completed.
+// @Tags         grades
+// @Produce      json
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {array}   domain.AcademicRecord
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "User not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /users/{id}/history [get]
+func (h *GradeHandler) GetHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	history, err := h.Service.GetHistory(uint(id))
+	if err != nil {
+		gradeFailed(c, err)
+		return
+	}
+	if history == nil {
+		history = []domain.AcademicRecord{}
+	}
+	c.JSON(http.StatusOK, history)
+}
+
+func gradeFailed(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrClassNotFound), errors.Is(err, domain.ErrAssessmentNotFound),
+		errors.Is(err, domain.ErrUserNotFound), errors.Is(err, domain.ErrNotEnrolled):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidAssessment), errors.Is(err, domain.ErrInvalidGrade):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
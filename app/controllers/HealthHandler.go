@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"sarc/core/domain"
+	"sarc/pkg/db"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	DB          *sql.DB
+	PingTimeout time.Duration
+	draining    atomic.Bool
+}
+
+func NewHealthHandler(database *sql.DB) *HealthHandler {
+	return &HealthHandler{DB: database, PingTimeout: 2 * time.Second}
+}
+
+// MarkDraining makes the readiness probe fail so load balancers stop routing
+// new requests while the server drains in-flight ones.
+func (h *HealthHandler) MarkDraining() {
+	h.draining.Store(true)
+}
+
+// Liveness
+// @Summary      Liveness probe
+// @Description  Reports that the process is alive. Does not touch the database
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  domain.HealthStatus
+// @Router       /healthz [get]
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, domain.HealthStatus{Status: "ok"})
+}
+
+// Readiness
+// @Summary      Readiness probe
+// @Description  Pings the database, checks the schema version and reports connection pool usage
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  domain.ReadinessReport
+// @Failure      503  {object}  domain.ReadinessReport "Not ready"
+// @Router       /readyz [get]
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.PingTimeout)
+	defer cancel()
+
+	report := domain.ReadinessReport{Status: "ready"}
+
+	start := time.Now()
+	if err := h.DB.PingContext(ctx); err != nil {
+		report.Database = domain.DatabaseCheck{Status: "down", Error: err.Error()}
+	} else {
+		report.Database = domain.DatabaseCheck{Status: "up"}
+	}
+	report.Database.LatencyMs = time.Since(start).Milliseconds()
+
+	report.Migrations = domain.MigrationsCheck{Expected: db.SchemaVersion()}
+	if report.Database.Status == "up" {
+		current, err := db.CurrentVersion(ctx, h.DB)
+		report.Migrations.Current = current
+		switch {
+		case err != nil:
+			report.Migrations.Status = "unknown"
+			report.Migrations.Error = err.Error()
+		case current != report.Migrations.Expected:
+			report.Migrations.Status = "mismatch"
+		default:
+			report.Migrations.Status = "up"
+		}
+	} else {
+		report.Migrations.Status = "unknown"
+	}
+
+	stats := h.DB.Stats()
+	report.Pool = domain.PoolCheck{
+		Status:             "up",
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		MaxOpenConnections: stats.MaxOpenConnections,
+		WaitCount:          stats.WaitCount,
+	}
+	if stats.MaxOpenConnections > 0 {
+		saturation := float64(stats.InUse) / float64(stats.MaxOpenConnections)
+		report.Pool.Saturation = &saturation
+		if stats.InUse >= stats.MaxOpenConnections {
+			report.Pool.Status = "saturated"
+		}
+	}
+
+	status := http.StatusOK
+	if h.draining.Load() {
+		report.Status = "draining"
+		status = http.StatusServiceUnavailable
+	} else if report.Database.Status != "up" || report.Migrations.Status != "up" {
+		report.Status = "not ready"
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
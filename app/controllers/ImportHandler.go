@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"sarc/core/domain"
+	serviceinterfaces "sarc/core/services/interfaces"
+	"sarc/pkg/tabular"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ImportHandler struct {
+	Service serviceinterfaces.ImportService
+}
+
+func NewImportHandler(service serviceinterfaces.ImportService) *ImportHandler {
+	return &ImportHandler{Service: service}
+}
+
+// Import spreadsheet
+// @Summary      Import a spreadsheet
+// @Description  Inserts or updates buildings, rooms, users, resources or class enrollments from a CSV or XLSX file. Rows are matched by natural key (building name; building name and room number; email; resource type and description; class and email). Every row is validated first and nothing is written unless all rows are valid.
+// @Tags         import
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        entity   path      string  true   "buildings, rooms, users, resources or enrollments"
+// @Param        file     formData  file    true   "CSV or XLSX file with a header row"
+// @Param        mapping  query     string  false  "Column mapping, e.g. buildingName=Prédio,roomNumber=Sala"
+// @Param        dryRun   query     bool    false  "Validate only, write nothing"
+// @Success      200   {object}  domain.ImportReport
+// @Failure      400   {object}  domain.ErrorResponse "Invalid request"
+// @Failure      422   {object}  domain.ImportReport "Some rows are invalid, nothing was written"
+// @Failure      500   {object}  domain.ErrorResponse "Internal server error"
+// @Router       /import/{entity} [post]
+func (h *ImportHandler) Import(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	format, err := tabular.FormatFromFilename(header.Filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	mapping, err := tabular.ParseColumnMapping(c.Query("mapping"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	dryRun := false
+	if v := c.Query("dryRun"); v != "" {
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dryRun"})
+			return
+		}
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+	table, err := tabular.Read(file, format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.Service.Import(c.Param("entity"), table, domain.ImportOptions{Mapping: mapping, DryRun: dryRun})
+	if errors.Is(err, domain.ErrUnknownImportEntity) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(report.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
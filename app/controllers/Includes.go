@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"sarc/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// includeQuery parses ?include=, e.g. "class.discipline,room.building".
+func includeQuery(c *gin.Context) domain.Includes {
+	return domain.ParseIncludes(c.Query("include"))
+}
+
+// includeOne runs an IncludeService call on a single record.
+func includeOne[T any](v *T, include func([]T, domain.Includes) error, relations domain.Includes) error {
+	list := []T{*v}
+	if err := include(list, relations); err != nil {
+		return err
+	}
+	*v = list[0]
+	return nil
+}
+
+// includeFailed reports an include error: 400 for an unknown relation, 500
+// when loading failed.
+func includeFailed(c *gin.Context, err error) {
+	if errors.Is(err, domain.ErrInvalidInclude) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
@@ -1,32 +1,36 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"sarc/core/domain"
 	serviceinterfaces "sarc/core/services/interfaces"
+	"sarc/pkg/tabular"
 
 	"github.com/gin-gonic/gin"
 )
 
 type LectureHandler struct {
-	Service serviceinterfaces.LectureService
+	Service  serviceinterfaces.LectureService
+	Includes serviceinterfaces.IncludeService
 }
 
-func NewLectureHandler(service serviceinterfaces.LectureService) *LectureHandler {
-	return &LectureHandler{Service: service}
+func NewLectureHandler(service serviceinterfaces.LectureService, includes serviceinterfaces.IncludeService) *LectureHandler {
+	return &LectureHandler{Service: service, Includes: includes}
 }
 
 // Create Lecture
 // @Summary      Create a new lecture
-// @Description  Creates a new lecture in the system
+// @Description  Creates a new lecture in the system. A room with fewer seats than the class has active students, or a time when one of the class's teachers is in another lecture, is rejected, or only reported in warnings when the limit is soft.
 // @Tags         lectures
 // @Accept       json
 // @Produce      json
 // @Param        lecture  body      domain.Lecture   true  "Lecture data"
 // @Success      201   {object}  domain.Lecture
-// @Failure      400   {object}  domain.ErrorResponse "Invalid request"
+// @Failure      400   {object}  domain.ErrorResponse "Invalid request or lecture time"
+// @Failure      409   {object}  domain.ErrorResponse "Room too small for the class or a teacher already busy"
 // @Failure      500   {object}  domain.ErrorResponse "Internal server error"
 // @Router       /lectures [post]
 func (h *LectureHandler) CreateLecture(c *gin.Context) {
@@ -37,7 +41,7 @@ func (h *LectureHandler) CreateLecture(c *gin.Context) {
 	}
 	created, err := h.Service.CreateLecture(&lecture)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		lectureFailed(c, err)
 		return
 	}
 	c.JSON(http.StatusCreated, created)
@@ -45,27 +49,58 @@ func (h *LectureHandler) CreateLecture(c *gin.Context) {
 
 // Get All Lectures
 // @Summary      Get all lectures
-// @Description  Retrieves all lectures
+// @Description  Retrieves all lectures, as JSON or streamed as a CSV, XLSX or NDJSON export. The JSON list is ordered by ID; use limit and offset to page through it.
 // @Tags         lectures
-// @Produce      json
+// @Produce      json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/x-ndjson
+// @Param        format  query  string  false  "json (default), csv, xlsx or ndjson; the Accept header is used when omitted"
+// @Param        include  query     string  false  "Relations to embed: class, class.discipline, room, room.building, presence"
+// @Param        limit   query     int  false  "Maximum number of lectures"
+// @Param        offset  query     int  false  "Number of lectures to skip"
 // @Success      200   {array}   domain.Lecture
+// @Failure      400   {object}  domain.ErrorResponse "Invalid format or page"
 // @Failure      500   {object}  domain.ErrorResponse "Internal server error"
 // @Router       /lectures [get]
 func (h *LectureHandler) GetLectures(c *gin.Context) {
-	lectures, err := h.Service.GetLectures()
+	format, err := exportFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if format != formatJSON {
+		writeExport(c, format, "lectures", lectureColumns, h.Service.StreamLectures)
+		return
+	}
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	lectures, err := h.Service.GetLectures(page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if err := h.Includes.IncludeLectures(lectures, includeQuery(c)); err != nil {
+		includeFailed(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, lectures)
 }
 
+var lectureColumns = exportColumns[domain.Lecture]{
+	header: []string{"lectureId", "classId", "roomId", "date", "startTime", "endTime", "content"},
+	record: func(l domain.Lecture) []string {
+		return []string{exportID(l.LectureID), exportID(l.ClassID), exportID(l.RoomID), exportDate(l.Date), l.StartTime, l.EndTime, tabular.JoinList(l.Content)}
+	},
+}
+
 // Get Lecture by ID
 // @Summary      Get lecture by ID
 // @Description  Retrieves a lecture by its ID
 // @Tags         lectures
 // @Produce      json
 // @Param        id   path      int  true  "Lecture ID"
+// @Param        include  query     string  false  "Relations to embed: class, class.discipline, room, room.building, presence"
 // @Success      200  {object}  domain.Lecture
 // @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
 // @Failure      404  {object}  domain.ErrorResponse "Lecture not found"
@@ -81,12 +116,16 @@ func (h *LectureHandler) GetLectureByID(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
+	if err := includeOne(lecture, h.Includes.IncludeLectures, includeQuery(c)); err != nil {
+		includeFailed(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, lecture)
 }
 
 // Update Lecture
 // @Summary      Update an existing lecture
-// @Description  Updates the lecture information for the given lecture ID
+// @Description  Updates the lecture information for the given lecture ID. Moving it to another room, class or time is checked against the room's capacity and the teachers' schedules like on create.
 // @Tags         lectures
 // @Accept       json
 // @Produce      json
@@ -94,6 +133,7 @@ func (h *LectureHandler) GetLectureByID(c *gin.Context) {
 // @Param        lecture body      domain.Lecture  true  "Lecture data"
 // @Success      200   {object}  domain.Lecture
 // @Failure      400   {object}  domain.ErrorResponse "Invalid ID or bad request"
+// @Failure      409   {object}  domain.ErrorResponse "Room too small for the class or a teacher already busy"
 // @Failure      500   {object}  domain.ErrorResponse "Internal server error"
 // @Router       /lectures/{id} [put]
 func (h *LectureHandler) UpdateLecture(c *gin.Context) {
@@ -109,12 +149,36 @@ func (h *LectureHandler) UpdateLecture(c *gin.Context) {
 	}
 	updated, err := h.Service.UpdateLecture(uint(id), &lecture)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		lectureFailed(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, updated)
 }
 
+// Get Capacity Report
+// @Summary      Lectures over or under room capacity
+// @Description  Lists upcoming lectures in the date range whose class has more active students than the room has seats, or fills less of it than the configured share. Rooms without a capacity and classes without students are skipped.
+// @Tags         lectures
+// @Produce      json
+// @Param        from  query  string  false  "First day, YYYY-MM-DD; defaults to today"
+// @Param        to    query  string  false  "Last day, YYYY-MM-DD; defaults to six months after from"
+// @Success      200  {array}   domain.LectureCapacity
+// @Failure      400  {object}  domain.ErrorResponse "Invalid date range"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /lectures/capacity [get]
+func (h *LectureHandler) GetCapacityReport(c *gin.Context) {
+	report, err := h.Service.GetCapacityReport(c.Query("from"), c.Query("to"))
+	if errors.Is(err, domain.ErrInvalidDateRange) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
 // Delete Lecture
 // @Summary      Delete a lecture
 // @Description  Deletes a lecture by its ID
@@ -136,3 +200,50 @@ func (h *LectureHandler) DeleteLecture(c *gin.Context) {
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// GetLectureReservations
+// @Summary      List the reservations of a lecture
+// @Description  Retrieves the reservations of the given lecture, with their resources, ordered by ID. Use limit and offset to page through them.
+// @Tags         lectures
+// @Produce      json
+// @Param        id      path      int  true   "Lecture ID"
+// @Param        limit   query     int  false  "Maximum number of reservations"
+// @Param        offset  query     int  false  "Number of reservations to skip"
+// @Param        include  query     string  false  "Relations to embed: lecture (and lecture.class, lecture.room, ...), resources.resourceType"
+// @Success      200  {array}   domain.Reservation
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID or page"
+// @Failure      404  {object}  domain.ErrorResponse "Lecture not found"
+// @Router       /lectures/{id}/reservations [get]
+func (h *LectureHandler) GetLectureReservations(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	reservations, err := h.Service.GetLectureReservations(uint(id), page)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.Includes.IncludeReservations(reservations, includeQuery(c)); err != nil {
+		includeFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, reservations)
+}
+
+func lectureFailed(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidLectureTime):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrRoomOverCapacity), errors.Is(err, domain.ErrScheduleConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
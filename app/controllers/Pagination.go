@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"sarc/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pageQuery reads the optional ?limit= and ?offset= parameters. Without
+// them the whole list is returned.
+func pageQuery(c *gin.Context) (domain.Page, error) {
+	var page domain.Page
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return page, errors.New("Invalid limit")
+		}
+		page.Limit = n
+	}
+	if v := c.Query("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return page, errors.New("Invalid offset")
+		}
+		page.Offset = n
+	}
+	return page, nil
+}
@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"sarc/core/domain"
+	serviceinterfaces "sarc/core/services/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PresenceHandler struct {
+	Service serviceinterfaces.PresenceService
+}
+
+func NewPresenceHandler(service serviceinterfaces.PresenceService) *PresenceHandler {
+	return &PresenceHandler{Service: service}
+}
+
+// Get Presence
+// @Summary      List the attendance of a lecture
+// @Description  Retrieves who was marked present at a lecture, with how and when
+// @Tags         presence
+// @Produce      json
+// @Param        id   path      int  true  "Lecture ID"
+// @Success      200  {array}   domain.Presence
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "Lecture not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /lectures/{id}/presence [get]
+func (h *PresenceHandler) GetPresence(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	presence, err := h.Service.GetPresence(uint(id))
+	if err != nil {
+		presenceFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, presence)
+}
+
+// Mark Presence
+// @Summary      Mark a user present
+// @Description  Marks one user present at a lecture. Marking someone already present keeps the original record and returns 200 instead of 201.
+// @Tags         presence
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int     true  "Lecture ID"
+// @Param        user  body      object  true  "User to mark"  Schema({"userId":1})
+// @Success      201   {object}  domain.Presence
+// @Success      200   {object}  domain.Presence
+// @Failure      400   {object}  domain.ErrorResponse "Invalid request or unknown user"
+// @Failure      404   {object}  domain.ErrorResponse "Lecture not found"
+// @Failure      500   {object}  domain.ErrorResponse "Internal server error"
+// @Router       /lectures/{id}/presence [post]
+func (h *PresenceHandler) MarkPresence(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var req struct {
+		UserID uint `json:"userId"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	presence, created, err := h.Service.MarkPresence(uint(id), req.UserID)
+	if err != nil {
+		presenceFailed(c, err)
+		return
+	}
+	if created {
+		c.JSON(http.StatusCreated, presence)
+		return
+	}
+	c.JSON(http.StatusOK, presence)
+}
+
+// Mark Presence in Bulk
+// @Summary      Mark a roll call
+// @Description  Marks every listed user present at a lecture in one step. Users already present are left as they are. Nobody is marked if any user does not exist.
+// @Tags         presence
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int     true  "Lecture ID"
+// @Param        users  body      object  true  "Users to mark"  Schema({"userIds":[1,2,3]})
+// @Success      200    {object}  domain.PresenceMarkResult
+// @Failure      400    {object}  domain.ErrorResponse "Invalid request or unknown users"
+// @Failure      404    {object}  domain.ErrorResponse "Lecture not found"
+// @Failure      500    {object}  domain.ErrorResponse "Internal server error"
+// @Router       /lectures/{id}/presence/bulk [post]
+func (h *PresenceHandler) MarkPresenceBulk(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var req struct {
+		UserIDs []uint `json:"userIds"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(req.UserIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "userIds is required"})
+		return
+	}
+	result, err := h.Service.MarkPresenceBulk(uint(id), req.UserIDs)
+	if err != nil {
+		presenceFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// Unmark Presence
+// @Summary      Unmark a user
+// @Description  Removes a user's presence from a lecture
+// @Tags         presence
+// @Param        id      path      int  true  "Lecture ID"
+// @Param        userId  path      int  true  "User ID"
+// @Success      204  {string}  string "No Content"
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "Lecture not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /lectures/{id}/presence/{userId} [delete]
+func (h *PresenceHandler) UnmarkPresence(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	if err := h.Service.UnmarkPresence(uint(id), uint(userID)); err != nil {
+		presenceFailed(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func presenceFailed(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrLectureNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrUserNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -45,14 +45,22 @@ func (h *ProfileHandler) CreateProfile(c *gin.Context) {
 
 // Get All Profiles
 // @Summary      Get all profiles
-// @Description  Retrieves all profiles
+// @Description  Retrieves all profiles, ordered by ID. Use limit and offset to page through them.
 // @Tags         profiles
 // @Produce      json
+// @Param        limit   query     int  false  "Maximum number of profiles"
+// @Param        offset  query     int  false  "Number of profiles to skip"
 // @Success      200   {array}   domain.Profile
+// @Failure      400   {object}  domain.ErrorResponse "Invalid page"
 // @Failure      500   {object}  domain.ErrorResponse "Internal server error"
 // @Router       /profiles [get]
 func (h *ProfileHandler) GetProfiles(c *gin.Context) {
-	profiles, err := h.Service.GetProfiles()
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	profiles, err := h.Service.GetProfiles(page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"sarc/core/domain"
+	serviceinterfaces "sarc/core/services/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProgressHandler struct {
+	Service serviceinterfaces.ProgressService
+}
+
+func NewProgressHandler(service serviceinterfaces.ProgressService) *ProgressHandler {
+	return &ProgressHandler{Service: service}
+}
+
+// Get Progress
+// @Summary      Get a user's progress in a curriculum
+// @Description  Credits the disciplines the user was approved in, directly or through equivalences, and splits the rest into the ones being taken, the ones unlocked by their prerequisites and the locked ones with what is missing.
+// @Tags         progress
+// @Produce      json
+// @Param        id            path      int  true  "User ID"
+// @Param        curriculumId  query     int  true  "Curriculum ID"
+// @Success      200  {object}  domain.CurriculumProgress
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID or curriculum ID"
+// @Failure      404  {object}  domain.ErrorResponse "User or curriculum not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /users/{id}/progress [get]
+func (h *ProgressHandler) GetProgress(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	curriculumID, err := strconv.Atoi(c.Query("curriculumId"))
+	if err != nil || curriculumID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid curriculum ID"})
+		return
+	}
+	progress, err := h.Service.GetProgress(uint(id), uint(curriculumID))
+	if err != nil {
+		progressFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, progress)
+}
+
+// Get Study Plan
+// @Summary      Suggest a study plan for the next semester
+// @Description  Goes through the unlocked disciplines from the earliest semester, mandatory ones first, and picks for each the first class offered whose lectures do not overlap the ones already picked, without going over the credit cap. Disciplines linked by co-requisites are planned together or not at all. Disciplines left out come with the reason.
+// @Tags         progress
+// @Produce      json
+// @Param        id            path      int  true   "User ID"
+// @Param        curriculumId  query     int  true   "Curriculum ID"
+// @Param        maxCredits    query     int  false  "Credit cap, 0 for no cap; curriculum.studyPlanMaxCredits when omitted"
+// @Success      200  {object}  domain.StudyPlan
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID, curriculum ID or credit cap"
+// @Failure      404  {object}  domain.ErrorResponse "User or curriculum not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /users/{id}/study-plan [get]
+func (h *ProgressHandler) GetStudyPlan(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	curriculumID, err := strconv.Atoi(c.Query("curriculumId"))
+	if err != nil || curriculumID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid curriculum ID"})
+		return
+	}
+	var maxCredits *int
+	if raw, ok := c.GetQuery("maxCredits"); ok {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid maxCredits"})
+			return
+		}
+		maxCredits = &n
+	}
+	plan, err := h.Service.GetStudyPlan(uint(id), uint(curriculumID), maxCredits)
+	if err != nil {
+		progressFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, plan)
+}
+
+func progressFailed(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound), errors.Is(err, domain.ErrCurriculumNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidStudyPlan), errors.Is(err, domain.ErrDisciplineNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -6,16 +6,18 @@ import (
 
 	"sarc/core/domain"
 	serviceinterfaces "sarc/core/services/interfaces"
+	"sarc/pkg/tabular"
 
 	"github.com/gin-gonic/gin"
 )
 
 type ReservationsHandler struct {
-	Service serviceinterfaces.ReservationsService
+	Service  serviceinterfaces.ReservationsService
+	Includes serviceinterfaces.IncludeService
 }
 
-func NewReservationsHandler(service serviceinterfaces.ReservationsService) *ReservationsHandler {
-	return &ReservationsHandler{Service: service}
+func NewReservationsHandler(service serviceinterfaces.ReservationsService, includes serviceinterfaces.IncludeService) *ReservationsHandler {
+	return &ReservationsHandler{Service: service, Includes: includes}
 }
 
 // Create Reservation
@@ -45,27 +47,64 @@ func (h *ReservationsHandler) CreateReservation(c *gin.Context) {
 
 // Get All Reservations
 // @Summary      Get all reservations
-// @Description  Retrieves all reservations
+// @Description  Retrieves all reservations, as JSON or streamed as a CSV, XLSX or NDJSON export. The JSON list is ordered by ID; use limit and offset to page through it.
 // @Tags         reservations
-// @Produce      json
+// @Produce      json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/x-ndjson
+// @Param        format  query  string  false  "json (default), csv, xlsx or ndjson; the Accept header is used when omitted"
+// @Param        include  query     string  false  "Relations to embed: lecture (and lecture.class, lecture.room, ...), resources.resourceType"
+// @Param        limit   query     int  false  "Maximum number of reservations"
+// @Param        offset  query     int  false  "Number of reservations to skip"
 // @Success      200   {array}   domain.Reservation
+// @Failure      400   {object}  domain.ErrorResponse "Invalid format or page"
 // @Failure      500   {object}  domain.ErrorResponse "Internal server error"
 // @Router       /reservations [get]
 func (h *ReservationsHandler) GetReservations(c *gin.Context) {
-	reservations, err := h.Service.GetReservations()
+	format, err := exportFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if format != formatJSON {
+		writeExport(c, format, "reservations", reservationColumns, h.Service.StreamReservations)
+		return
+	}
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	reservations, err := h.Service.GetReservations(page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if err := h.Includes.IncludeReservations(reservations, includeQuery(c)); err != nil {
+		includeFailed(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, reservations)
 }
 
+var reservationColumns = exportColumns[domain.Reservation]{
+	header: []string{"reservationId", "lectureId", "observation", "resourceIds", "resources"},
+	record: func(r domain.Reservation) []string {
+		ids := make([]string, len(r.Resources))
+		descriptions := make([]string, len(r.Resources))
+		for i, res := range r.Resources {
+			ids[i] = exportID(res.ResourceID)
+			descriptions[i] = res.Description
+		}
+		return []string{exportID(r.ReservationID), exportID(r.LectureID), r.Observation, tabular.JoinList(ids), tabular.JoinList(descriptions)}
+	},
+}
+
 // Get Reservation by ID
 // @Summary      Get reservation by ID
 // @Description  Retrieves a reservation by its ID
 // @Tags         reservations
 // @Produce      json
 // @Param        id   path      int  true  "Reservation ID"
+// @Param        include  query     string  false  "Relations to embed: lecture (and lecture.class, lecture.room, ...), resources.resourceType"
 // @Success      200  {object}  domain.Reservation
 // @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
 // @Failure      404  {object}  domain.ErrorResponse "Reservation not found"
@@ -81,6 +120,10 @@ func (h *ReservationsHandler) GetReservationByID(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
+	if err := includeOne(reservation, h.Includes.IncludeReservations, includeQuery(c)); err != nil {
+		includeFailed(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, reservation)
 }
 
@@ -6,16 +6,18 @@ import (
 
 	"sarc/core/domain"
 	serviceinterfaces "sarc/core/services/interfaces"
+	"sarc/pkg/tabular"
 
 	"github.com/gin-gonic/gin"
 )
 
 type ResourceHandler struct {
-	Service serviceinterfaces.ResourceService
+	Service  serviceinterfaces.ResourceService
+	Includes serviceinterfaces.IncludeService
 }
 
-func NewResourceHandler(service serviceinterfaces.ResourceService) *ResourceHandler {
-	return &ResourceHandler{Service: service}
+func NewResourceHandler(service serviceinterfaces.ResourceService, includes serviceinterfaces.IncludeService) *ResourceHandler {
+	return &ResourceHandler{Service: service, Includes: includes}
 }
 
 // Create Resource
@@ -45,27 +47,62 @@ func (h *ResourceHandler) CreateResource(c *gin.Context) {
 
 // Get All Resources
 // @Summary      Get all resources
-// @Description  Retrieves all resources
+// @Description  Retrieves all resources, as JSON or streamed as a CSV, XLSX or NDJSON export. The JSON list is ordered by ID; use limit and offset to page through it.
 // @Tags         resources
-// @Produce      json
+// @Produce      json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/x-ndjson
+// @Param        format  query  string  false  "json (default), csv, xlsx or ndjson; the Accept header is used when omitted"
+// @Param        include  query     string  false  "Relations to embed: resourceType"
+// @Param        limit   query     int  false  "Maximum number of resources"
+// @Param        offset  query     int  false  "Number of resources to skip"
 // @Success      200   {array}   domain.Resource
+// @Failure      400   {object}  domain.ErrorResponse "Invalid format or page"
 // @Failure      500   {object}  domain.ErrorResponse "Internal server error"
 // @Router       /resources [get]
 func (h *ResourceHandler) GetResources(c *gin.Context) {
-	resources, err := h.Service.GetResources()
+	format, err := exportFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if format != formatJSON {
+		writeExport(c, format, "resources", resourceColumns, h.Service.StreamResources)
+		return
+	}
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	resources, err := h.Service.GetResources(page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if err := h.Includes.IncludeResources(resources, includeQuery(c)); err != nil {
+		includeFailed(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, resources)
 }
 
+var resourceColumns = exportColumns[domain.Resource]{
+	header: []string{"resourceId", "description", "status", "characteristics", "resourceTypeId", "resourceType"},
+	record: func(r domain.Resource) []string {
+		typeName := ""
+		if r.ResourceType != nil {
+			typeName = r.ResourceType.Name
+		}
+		return []string{exportID(r.ResourceID), r.Description, string(r.Status), tabular.JoinList(r.Characteristics), exportID(r.ResourceTypeID), typeName}
+	},
+}
+
 // Get Resource by ID
 // @Summary      Get resource by ID
 // @Description  Retrieves a resource by its ID
 // @Tags         resources
 // @Produce      json
 // @Param        id   path      int  true  "Resource ID"
+// @Param        include  query     string  false  "Relations to embed: resourceType"
 // @Success      200  {object}  domain.Resource
 // @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
 // @Failure      404  {object}  domain.ErrorResponse "Resource not found"
@@ -81,6 +118,10 @@ func (h *ResourceHandler) GetResourceByID(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
+	if err := includeOne(resource, h.Includes.IncludeResources, includeQuery(c)); err != nil {
+		includeFailed(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, resource)
 }
 
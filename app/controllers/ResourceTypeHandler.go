@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"sarc/core/domain"
+	serviceinterfaces "sarc/core/services/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ResourceTypeHandler struct {
+	Service  serviceinterfaces.ResourceTypeService
+	Includes serviceinterfaces.IncludeService
+}
+
+func NewResourceTypeHandler(service serviceinterfaces.ResourceTypeService, includes serviceinterfaces.IncludeService) *ResourceTypeHandler {
+	return &ResourceTypeHandler{Service: service, Includes: includes}
+}
+
+// Create ResourceType
+// @Summary      Create a new resource type
+// @Description  Creates a new resource type, e.g. "Microphone"
+// @Tags         resource-types
+// @Accept       json
+// @Produce      json
+// @Param        resourceType  body      domain.ResourceType  true  "Resource type data"
+// @Success      201   {object}  domain.ResourceType
+// @Failure      400   {object}  domain.ErrorResponse "Invalid request"
+// @Failure      500   {object}  domain.ErrorResponse "Internal server error"
+// @Router       /resource-types [post]
+func (h *ResourceTypeHandler) CreateResourceType(c *gin.Context) {
+	var resourceType domain.ResourceType
+	if err := c.ShouldBindJSON(&resourceType); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if strings.TrimSpace(resourceType.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+	created, err := h.Service.CreateResourceType(&resourceType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// Get All ResourceTypes
+// @Summary      Get all resource types
+// @Description  Retrieves all resource types, ordered by ID. Use limit and offset to page through them.
+// @Tags         resource-types
+// @Produce      json
+// @Param        limit   query     int  false  "Maximum number of resource types"
+// @Param        offset  query     int  false  "Number of resource types to skip"
+// @Success      200   {array}   domain.ResourceType
+// @Failure      400   {object}  domain.ErrorResponse "Invalid page"
+// @Failure      500   {object}  domain.ErrorResponse "Internal server error"
+// @Router       /resource-types [get]
+func (h *ResourceTypeHandler) GetResourceTypes(c *gin.Context) {
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	resourceTypes, err := h.Service.GetResourceTypes(page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resourceTypes)
+}
+
+// Get ResourceType by ID
+// @Summary      Get resource type by ID
+// @Description  Retrieves a resource type by its ID
+// @Tags         resource-types
+// @Produce      json
+// @Param        id   path      int  true  "Resource type ID"
+// @Success      200  {object}  domain.ResourceType
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "Resource type not found"
+// @Router       /resource-types/{id} [get]
+func (h *ResourceTypeHandler) GetResourceTypeByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	resourceType, err := h.Service.GetResourceTypeByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resourceType)
+}
+
+// Update ResourceType
+// @Summary      Update an existing resource type
+// @Description  Renames the resource type with the given ID
+// @Tags         resource-types
+// @Accept       json
+// @Produce      json
+// @Param        id            path      int                  true  "Resource type ID"
+// @Param        resourceType  body      domain.ResourceType  true  "Resource type data"
+// @Success      200   {object}  domain.ResourceType
+// @Failure      400   {object}  domain.ErrorResponse "Invalid ID or bad request"
+// @Failure      500   {object}  domain.ErrorResponse "Internal server error"
+// @Router       /resource-types/{id} [put]
+func (h *ResourceTypeHandler) UpdateResourceType(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var resourceType domain.ResourceType
+	if err := c.ShouldBindJSON(&resourceType); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if strings.TrimSpace(resourceType.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+	updated, err := h.Service.UpdateResourceType(uint(id), &resourceType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// Delete ResourceType
+// @Summary      Delete a resource type
+// @Description  Deletes a resource type by its ID. Refused while resources still use it.
+// @Tags         resource-types
+// @Param        id   path      int  true  "Resource type ID"
+// @Success      204  {string}  string "No Content"
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      409  {object}  domain.ErrorResponse "Resource type still in use"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /resource-types/{id} [delete]
+func (h *ResourceTypeHandler) DeleteResourceType(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	if err := h.Service.DeleteResourceType(uint(id)); err != nil {
+		if errors.Is(err, domain.ErrResourceTypeInUse) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Get Resources by ResourceType
+// @Summary      List the resources of a type
+// @Description  Retrieves every resource of the given resource type
+// @Tags         resource-types
+// @Produce      json
+// @Param        id   path      int  true  "Resource type ID"
+// @Param        include  query     string  false  "Relations to embed: resourceType"
+// @Success      200  {array}   domain.Resource
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
+// @Failure      404  {object}  domain.ErrorResponse "Resource type not found"
+// @Router       /resource-types/{id}/resources [get]
+func (h *ResourceTypeHandler) GetResourcesByType(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	resources, err := h.Service.GetResourcesByType(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.Includes.IncludeResources(resources, includeQuery(c)); err != nil {
+		includeFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resources)
+}
@@ -11,11 +11,12 @@ import (
 )
 
 type RoomHandler struct {
-	Service serviceinterfaces.RoomService
+	Service  serviceinterfaces.RoomService
+	Includes serviceinterfaces.IncludeService
 }
 
-func NewRoomHandler(service serviceinterfaces.RoomService) *RoomHandler {
-	return &RoomHandler{Service: service}
+func NewRoomHandler(service serviceinterfaces.RoomService, includes serviceinterfaces.IncludeService) *RoomHandler {
+	return &RoomHandler{Service: service, Includes: includes}
 }
 
 // Create Room
@@ -45,27 +46,58 @@ func (h *RoomHandler) CreateRoom(c *gin.Context) {
 
 // Get All Rooms
 // @Summary      Get all rooms
-// @Description  Retrieves all rooms
+// @Description  Retrieves all rooms, as JSON or streamed as a CSV, XLSX or NDJSON export. The JSON list is ordered by ID; use limit and offset to page through it.
 // @Tags         rooms
-// @Produce      json
+// @Produce      json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/x-ndjson
+// @Param        format  query  string  false  "json (default), csv, xlsx or ndjson; the Accept header is used when omitted"
+// @Param        include  query     string  false  "Relations to embed: building"
+// @Param        limit   query     int  false  "Maximum number of rooms"
+// @Param        offset  query     int  false  "Number of rooms to skip"
 // @Success      200   {array}   domain.Room
+// @Failure      400   {object}  domain.ErrorResponse "Invalid format or page"
 // @Failure      500   {object}  domain.ErrorResponse "Internal server error"
 // @Router       /rooms [get]
 func (h *RoomHandler) GetRooms(c *gin.Context) {
-	rooms, err := h.Service.GetRooms()
+	format, err := exportFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if format != formatJSON {
+		writeExport(c, format, "rooms", roomColumns, h.Service.StreamRooms)
+		return
+	}
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	rooms, err := h.Service.GetRooms(page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if err := h.Includes.IncludeRooms(rooms, includeQuery(c)); err != nil {
+		includeFailed(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, rooms)
 }
 
+var roomColumns = exportColumns[domain.Room]{
+	header: []string{"roomId", "buildingId", "roomNumber", "roomCapacity", "floor"},
+	record: func(r domain.Room) []string {
+		return []string{exportID(r.RoomID), exportID(r.BuildingID), r.RoomNumber, strconv.Itoa(r.RoomCapacity), strconv.Itoa(r.Floor)}
+	},
+}
+
 // Get Room by ID
 // @Summary      Get room by ID
 // @Description  Retrieves a room by its ID
 // @Tags         rooms
 // @Produce      json
 // @Param        id   path      int  true  "Room ID"
+// @Param        include  query     string  false  "Relations to embed: building"
 // @Success      200  {object}  domain.Room
 // @Failure      400  {object}  domain.ErrorResponse "Invalid ID"
 // @Failure      404  {object}  domain.ErrorResponse "Room not found"
@@ -81,6 +113,10 @@ func (h *RoomHandler) GetRoomByID(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
+	if err := includeOne(room, h.Includes.IncludeRooms, includeQuery(c)); err != nil {
+		includeFailed(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, room)
 }
 
@@ -136,3 +172,39 @@ func (h *RoomHandler) DeleteRoom(c *gin.Context) {
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// GetRoomLectures
+// @Summary      List the lectures of a room
+// @Description  Retrieves the lectures of the given room, ordered by date. Use limit and offset to page through them.
+// @Tags         rooms
+// @Produce      json
+// @Param        id      path      int  true   "Room ID"
+// @Param        limit   query     int  false  "Maximum number of lectures"
+// @Param        offset  query     int  false  "Number of lectures to skip"
+// @Param        include  query     string  false  "Relations to embed: class, class.discipline, room, room.building, presence"
+// @Success      200  {array}   domain.Lecture
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID or page"
+// @Failure      404  {object}  domain.ErrorResponse "Room not found"
+// @Router       /rooms/{id}/lectures [get]
+func (h *RoomHandler) GetRoomLectures(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	lectures, err := h.Service.GetRoomLectures(uint(id), page)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.Includes.IncludeLectures(lectures, includeQuery(c)); err != nil {
+		includeFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, lectures)
+}
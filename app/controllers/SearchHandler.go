@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	serviceinterfaces "sarc/core/services/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	Service serviceinterfaces.SearchService
+}
+
+func NewSearchHandler(service serviceinterfaces.SearchService) *SearchHandler {
+	return &SearchHandler{Service: service}
+}
+
+// Search
+// @Summary      Full-text search
+// @Description  Searches disciplines (name, program, bibliography), resources (description, characteristics), buildings (name, address), rooms (by building or room number) and classes. Results are ranked, highlighted with <b></b> and grouped by type.
+// @Tags         search
+// @Produce      json
+// @Param        q      query     string  true   "Search text, e.g. \"projetor HDMI prédio 32\""
+// @Param        limit  query     int     false  "Maximum hits per type (default 10, max 50)"
+// @Success      200   {object}  domain.SearchResults
+// @Failure      400   {object}  domain.ErrorResponse "Invalid request"
+// @Failure      500   {object}  domain.ErrorResponse "Internal server error"
+// @Router       /search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q is required"})
+		return
+	}
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+		limit = n
+	}
+	results, err := h.Service.Search(q, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
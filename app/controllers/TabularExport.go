@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"sarc/pkg/tabular"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+)
+
+// exportMediaTypes maps Accept header media types to export formats.
+var exportMediaTypes = map[string]string{
+	"application/json":                      formatJSON,
+	"text/csv":                              tabular.FormatCSV,
+	"application/x-ndjson":                  formatNDJSON,
+	"application/ndjson":                    formatNDJSON,
+	tabular.ContentType(tabular.FormatXLSX): tabular.FormatXLSX,
+}
+
+// exportFormat returns the format requested with ?format=, or else the first
+// one recognized in the Accept header. JSON is the default.
+func exportFormat(c *gin.Context) (string, error) {
+	if format := strings.ToLower(c.Query("format")); format != "" {
+		switch format {
+		case formatJSON, formatNDJSON, tabular.FormatCSV, tabular.FormatXLSX:
+			return format, nil
+		}
+		return "", fmt.Errorf("invalid format %q: use json, csv, xlsx or ndjson", format)
+	}
+	for _, mediaType := range strings.Split(c.GetHeader("Accept"), ",") {
+		mediaType, _, _ = strings.Cut(mediaType, ";")
+		if format, ok := exportMediaTypes[strings.TrimSpace(mediaType)]; ok {
+			return format, nil
+		}
+	}
+	return formatJSON, nil
+}
+
+// exportColumns describes how one entity is flattened into spreadsheet rows.
+type exportColumns[T any] struct {
+	header []string
+	record func(T) []string
+}
+
+// writeExport streams every row emitted by stream to the response in format,
+// without holding the list in memory. Nothing is sent before the first row
+// arrives, so a failing query still gets a 500; an error after that can only
+// cut the response short.
+func writeExport[T any](c *gin.Context, format, name string, columns exportColumns[T], stream func(func(T) error) error) {
+	var write func(T) error
+	var finish func() error
+	started := false
+	start := func() error {
+		started = true
+		if format == formatNDJSON {
+			c.Header("Content-Type", "application/x-ndjson")
+			c.Status(http.StatusOK)
+			enc := json.NewEncoder(c.Writer)
+			write = func(v T) error { return enc.Encode(v) }
+			finish = func() error { return nil }
+			return nil
+		}
+
+		c.Header("Content-Type", tabular.ContentType(format))
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+		c.Status(http.StatusOK)
+		w, err := tabular.NewWriter(c.Writer, format)
+		if err != nil {
+			return err
+		}
+		write = func(v T) error { return w.Write(columns.record(v)) }
+		finish = w.Close
+		return w.Write(columns.header)
+	}
+
+	err := stream(func(v T) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return write(v)
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		err = finish()
+	}
+	if err != nil {
+		if !c.Writer.Written() {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Error(err)
+	}
+}
+
+// streamSlice adapts an in-memory list to writeExport.
+func streamSlice[T any](rows []T) func(func(T) error) error {
+	return func(fn func(T) error) error {
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// exportDate trims the time Postgres appends to DATE columns.
+func exportDate(date string) string {
+	if len(date) > 10 && date[10] == 'T' {
+		return date[:10]
+	}
+	return date
+}
+
+// exportID formats an ID cell.
+func exportID(id uint) string {
+	return fmt.Sprint(id)
+}
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"sarc/app/middleware"
+	"sarc/core/domain"
+	serviceinterfaces "sarc/core/services/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TimetableHandler struct {
+	Service serviceinterfaces.TimetableService
+}
+
+func NewTimetableHandler(service serviceinterfaces.TimetableService) *TimetableHandler {
+	return &TimetableHandler{Service: service}
+}
+
+// Get User Timetable
+// @Summary      Timetable of a user
+// @Description  The user's lectures in every class they study, teach or assist, with class, room, building and reserved resources, ordered by start. Lectures overlapping another one list it in conflicts.
+// @Tags         timetable
+// @Produce      json
+// @Param        id    path   int     true   "User ID"
+// @Param        from  query  string  false  "First day, YYYY-MM-DD; defaults to today"
+// @Param        to    query  string  false  "Last day, YYYY-MM-DD; defaults to a week after from"
+// @Success      200  {object}  domain.Timetable
+// @Failure      400  {object}  domain.ErrorResponse "Invalid ID or date range"
+// @Failure      404  {object}  domain.ErrorResponse "User not found"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /users/{id}/timetable [get]
+func (h *TimetableHandler) GetUserTimetable(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	h.timetable(c, uint(id), http.StatusNotFound)
+}
+
+// Get My Timetable
+// @Summary      Timetable of the caller
+// @Description  Same as /users/{id}/timetable for the user in the X-User-ID header
+// @Tags         timetable
+// @Produce      json
+// @Param        X-User-ID  header  int     true   "Authenticated user ID"
+// @Param        from       query   string  false  "First day, YYYY-MM-DD; defaults to today"
+// @Param        to         query   string  false  "Last day, YYYY-MM-DD; defaults to a week after from"
+// @Success      200  {object}  domain.Timetable
+// @Failure      400  {object}  domain.ErrorResponse "Invalid date range"
+// @Failure      401  {object}  domain.ErrorResponse "Unknown or missing user"
+// @Failure      500  {object}  domain.ErrorResponse "Internal server error"
+// @Router       /me/timetable [get]
+func (h *TimetableHandler) GetMyTimetable(c *gin.Context) {
+	h.timetable(c, middleware.UserID(c), http.StatusUnauthorized)
+}
+
+// timetable answers unknownUser when userID does not exist.
+func (h *TimetableHandler) timetable(c *gin.Context, userID uint, unknownUser int) {
+	timetable, err := h.Service.GetTimetable(userID, c.Query("from"), c.Query("to"))
+	switch {
+	case errors.Is(err, domain.ErrInvalidDateRange):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrUserNotFound):
+		c.JSON(unknownUser, gin.H{"error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusOK, timetable)
+	}
+}
@@ -45,14 +45,32 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 // Get All Users
 // @Summary      Get all users
-// @Description  Retrieves all users
+// @Description  Retrieves all users, as JSON or streamed as a CSV, XLSX or NDJSON export. The JSON list is ordered by ID; use limit and offset to page through it.
 // @Tags         users
-// @Produce      json
+// @Produce      json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/x-ndjson
+// @Param        format  query  string  false  "json (default), csv, xlsx or ndjson; the Accept header is used when omitted"
+// @Param        limit   query     int  false  "Maximum number of users"
+// @Param        offset  query     int  false  "Number of users to skip"
 // @Success      200   {array}   domain.User
+// @Failure      400   {object}  domain.ErrorResponse "Invalid format or page"
 // @Failure      500   {object}  domain.ErrorResponse "Internal server error"
 // @Router       /users [get]
 func (h *UserHandler) GetUsers(c *gin.Context) {
-	users, err := h.Service.GetUsers()
+	format, err := exportFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if format != formatJSON {
+		writeExport(c, format, "users", userColumns, h.Service.StreamUsers)
+		return
+	}
+	page, err := pageQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	users, err := h.Service.GetUsers(page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -60,6 +78,13 @@ func (h *UserHandler) GetUsers(c *gin.Context) {
 	c.JSON(http.StatusOK, users)
 }
 
+var userColumns = exportColumns[domain.User]{
+	header: []string{"id", "email", "nome", "birthDate", "sex", "telephone", "profileId"},
+	record: func(u domain.User) []string {
+		return []string{exportID(u.ID), u.Email, u.Nome, exportDate(u.BirthDate), u.Sex, u.Telephone, exportID(u.ProfileID)}
+	},
+}
+
 // Get User by ID
 // @Summary      Get user by ID
 // @Description  Retrieves a user by their ID
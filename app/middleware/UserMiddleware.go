@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserIDHeader carries the ID of the authenticated caller. SARC has no login
+// of its own: the gateway in front of it authenticates users and sets this
+// header, and must strip it from incoming requests.
+const UserIDHeader = "X-User-ID"
+
+const userIDKey = "userID"
+
+// RequireUser rejects requests without a valid UserIDHeader and makes the ID
+// available to handlers through UserID.
+func RequireUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.GetHeader(UserIDHeader), 10, 64)
+		if err != nil || id == 0 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing or invalid " + UserIDHeader + " header"})
+			return
+		}
+		c.Set(userIDKey, uint(id))
+		c.Next()
+	}
+}
+
+// UserID returns the caller stored by RequireUser, or 0 outside it.
+func UserID(c *gin.Context) uint {
+	return c.GetUint(userIDKey)
+}
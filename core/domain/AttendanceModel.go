@@ -0,0 +1,61 @@
+package domain
+
+import "errors"
+
+// AttendanceStatus classifies a student's attendance in a class against the
+// minimum required.
+type AttendanceStatus string
+
+const (
+	AttendanceOK AttendanceStatus = "ok"
+	// AttendanceAtRisk has missed lectures and can afford only a few more
+	// absences before failing.
+	AttendanceAtRisk AttendanceStatus = "at_risk"
+	// AttendanceBelowMinimum attended less than the minimum of the lectures
+	// held so far, but can still reach it.
+	AttendanceBelowMinimum AttendanceStatus = "below_minimum"
+	// AttendanceFailed has missed too many lectures to reach the minimum.
+	AttendanceFailed AttendanceStatus = "failed"
+)
+
+// AttendancePolicy sets the attendance rules.
+type AttendancePolicy struct {
+	// MinimumPercentage of a class's lectures a student must attend.
+	MinimumPercentage float64
+	// WarnAbsencesLeft flags students with this many absences or fewer left.
+	WarnAbsencesLeft int
+}
+
+// StudentAttendance is one student's attendance in one class. Only lectures
+// that have started count as held.
+type StudentAttendance struct {
+	ClassID      uint    `json:"classId"`
+	UserID       uint    `json:"userId"`
+	User         *User   `json:"user,omitempty"`
+	LecturesHeld int     `json:"lecturesHeld"`
+	Present      int     `json:"present"`
+	Absent       int     `json:"absent"`
+	Percentage   float64 `json:"percentage"`
+	// AbsencesLeft is how many more lectures the student can miss and still
+	// reach the minimum over all of the class's lectures.
+	AbsencesLeft int              `json:"absencesLeft"`
+	Status       AttendanceStatus `json:"status"`
+}
+
+// ClassAttendanceReport lists the attendance of every student in a class.
+type ClassAttendanceReport struct {
+	ClassID           uint                `json:"classId"`
+	LecturesTotal     int                 `json:"lecturesTotal"`
+	LecturesHeld      int                 `json:"lecturesHeld"`
+	MinimumPercentage float64             `json:"minimumPercentage"`
+	Students          []StudentAttendance `json:"students"`
+}
+
+// AttendanceAlert is sent for a student whose status is not AttendanceOK.
+type AttendanceAlert struct {
+	StudentAttendance
+	Message string `json:"message"`
+}
+
+// ErrClassNotFound is returned when a class referenced by ID does not exist.
+var ErrClassNotFound = errors.New("class not found")
@@ -12,4 +12,6 @@ type Room struct {
 	Floor        int    `json:"floor"`
 	BuildingID   uint   `json:"buildingId"`
 	RoomNumber   string `gorm:"uniqueIndex:idx_room_building" json:"roomNumber"`
+	// Building is only loaded with ?include=building.
+	Building *Building `gorm:"-" json:"building,omitempty"`
 }
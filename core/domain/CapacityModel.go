@@ -0,0 +1,35 @@
+package domain
+
+import "errors"
+
+// CapacityPolicy sets how lectures are checked against their room's seats.
+type CapacityPolicy struct {
+	// SoftLimit accepts lectures over capacity with a warning instead of
+	// rejecting them.
+	SoftLimit bool
+	// UnderusedPercentage flags upcoming lectures filling less than this
+	// share of the room's seats.
+	UnderusedPercentage float64
+}
+
+// CapacityIssue is what is wrong with a lecture's room.
+type CapacityIssue string
+
+const (
+	CapacityOver      CapacityIssue = "over_capacity"
+	CapacityUnderused CapacityIssue = "underused"
+)
+
+// LectureCapacity compares a lecture's headcount, the active students
+// enrolled in its class, with its room's seats.
+type LectureCapacity struct {
+	Lecture      Lecture       `json:"lecture"`
+	RoomCapacity int           `json:"roomCapacity"`
+	Enrolled     int           `json:"enrolled"`
+	Occupancy    float64       `json:"occupancy"`
+	Issue        CapacityIssue `json:"issue"`
+}
+
+// ErrRoomOverCapacity is returned when a lecture is put in a room with fewer
+// seats than its class has students.
+var ErrRoomOverCapacity = errors.New("room has fewer seats than the class has students")
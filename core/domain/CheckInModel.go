@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// CheckInToken is the rotating code shown as a QR code during a lecture.
+type CheckInToken struct {
+	LectureID uint      `json:"lectureId"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// CheckInPolicy configures how check-in tokens are signed and when they are
+// accepted.
+type CheckInPolicy struct {
+	Secret []byte
+	// Period is how often the token rotates.
+	Period time.Duration
+	// OpenBefore is how early before a lecture starts check-in opens.
+	OpenBefore time.Duration
+	// Location is the zone lecture dates and times are in.
+	Location *time.Location
+}
+
+var (
+	ErrCheckInTokenInvalid  = errors.New("invalid check-in token")
+	ErrCheckInTokenExpired  = errors.New("check-in token expired")
+	ErrCheckInTokenReused   = errors.New("check-in token already used")
+	ErrLectureNotInProgress = errors.New("lecture is not in progress")
+	// ErrNotLectureStaff is returned when someone other than an active
+	// teacher or assistant of the class asks for a lecture's token.
+	ErrNotLectureStaff = errors.New("only the class's teachers and assistants can get its check-in token")
+)
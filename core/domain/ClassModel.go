@@ -5,4 +5,6 @@ type Class struct {
 	Name         string `json:"name"`
 	Description  string `json:"description"`
 	DisciplineID uint   `json:"disciplineId"`
+	// Discipline is only loaded with ?include=discipline.
+	Discipline *Discipline `gorm:"-" json:"discipline,omitempty"`
 }
@@ -0,0 +1,25 @@
+package domain
+
+import "errors"
+
+// ConflictPolicy sets how schedule conflicts are handled when lectures are
+// created or moved and when users are enrolled.
+type ConflictPolicy struct {
+	// SoftLimit accepts the change with a warning instead of rejecting it.
+	SoftLimit bool
+}
+
+// ScheduleConflict is a user expected at two overlapping lectures of
+// different classes. Only lectures with times are checked.
+type ScheduleConflict struct {
+	UserID    uint           `json:"userId"`
+	User      *User          `json:"user,omitempty"`
+	Role      EnrollmentRole `json:"role"`
+	Lecture   Lecture        `json:"lecture"`
+	OtherRole EnrollmentRole `json:"otherRole"`
+	Other     Lecture        `json:"other"`
+}
+
+// ErrScheduleConflict is returned when a change would put a user in two
+// lectures at once.
+var ErrScheduleConflict = errors.New("schedule conflict")
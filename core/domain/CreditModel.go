@@ -0,0 +1,39 @@
+package domain
+
+import "errors"
+
+// CreditLimits bound the credits of a curriculum. Zero means no limit.
+type CreditLimits struct {
+	// MinSemester and MaxSemester apply to the mandatory credits of each
+	// semester, since students only take some of the electives.
+	MinSemester int
+	MaxSemester int
+	// MinTotal and MaxTotal apply to all credits, electives included.
+	MinTotal int
+	MaxTotal int
+}
+
+// SemesterCredits sums the credits of the disciplines placed in a semester.
+type SemesterCredits struct {
+	Semester  int `json:"semester"`
+	Mandatory int `json:"mandatory"`
+	Elective  int `json:"elective"`
+}
+
+// CurriculumCredits sums a curriculum's credits from Discipline.Credits.
+type CurriculumCredits struct {
+	Semesters []SemesterCredits `json:"semesters"`
+	Mandatory int               `json:"mandatory"`
+	Elective  int               `json:"elective"`
+	Total     int               `json:"total"`
+	// Issues keep the curriculum from being published: broken credit
+	// limits, disciplines without a semester and requisites placed too late.
+	Issues []string `json:"issues"`
+}
+
+var (
+	ErrInvalidPlacement = errors.New("invalid placement")
+	// ErrCreditLimit is returned when placing a discipline would take a
+	// semester or the curriculum over its maximum credits.
+	ErrCreditLimit = errors.New("credit limit exceeded")
+)
@@ -0,0 +1,20 @@
+package domain
+
+// DisciplineChange is a discipline found in both curriculums of a diff with
+// different credits, semester or elective flag. A discipline replaced by
+// another one with the same name is also reported as changed.
+type DisciplineChange struct {
+	Before Discipline `json:"before"`
+	After  Discipline `json:"after"`
+	// Changes names what differs: credits, semester or elective.
+	Changes []string `json:"changes"`
+}
+
+// CurriculumDiff compares two curriculums, usually versions of the same one.
+type CurriculumDiff struct {
+	From    uint               `json:"from"`
+	To      uint               `json:"to"`
+	Added   []Discipline       `json:"added"`
+	Removed []Discipline       `json:"removed"`
+	Changed []DisciplineChange `json:"changed"`
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"errors"
+
 	"github.com/lib/pq"
 )
 
@@ -10,12 +12,28 @@ type Discipline struct {
 	Credits      int            `json:"credits"`
 	Program      string         `json:"program"`
 	Bibliography pq.StringArray `gorm:"type:text[]" json:"bibliography" swaggertype:"array,string"`
+	// Semester and Elective place the discipline in a curriculum, and are
+	// only set on a curriculum's disciplines. Semester 0 is not placed yet.
+	Semester int  `gorm:"-" json:"semester,omitempty"`
+	Elective bool `gorm:"-" json:"elective,omitempty"`
 }
 
 type Curriculum struct {
-	ID          uint         `gorm:"primaryKey" json:"id,omitempty" swaggerignore:"true"`
-	CourseName  string       `json:"courseName"`
-	DataInicio  string       `json:"dataInicio"`
-	DataFim     string       `json:"dataFim"`
+	ID         uint   `gorm:"primaryKey" json:"id,omitempty" swaggerignore:"true"`
+	CourseName string `json:"courseName"`
+	DataInicio string `json:"dataInicio"`
+	DataFim    string `json:"dataFim"`
+	// LineageID is the ID of the first version of the curriculum, shared by
+	// every version cloned from it.
+	LineageID   uint         `json:"lineageId" swaggerignore:"true"`
+	Version     int          `json:"version" swaggerignore:"true"`
 	Disciplines []Discipline `gorm:"many2many:curriculum_disciplines;" json:"disciplines"`
+	// Credits is only returned when a single curriculum is read.
+	Credits *CurriculumCredits `gorm:"-" json:"credits,omitempty"`
 }
+
+var (
+	ErrDisciplineNotFound = errors.New("discipline not found")
+	ErrCurriculumNotFound = errors.New("curriculum not found")
+	ErrNotInCurriculum    = errors.New("discipline is not part of the curriculum")
+)
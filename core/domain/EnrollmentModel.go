@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// EnrollmentRole is the part a user plays in a class.
+type EnrollmentRole string
+
+const (
+	EnrollmentRoleStudent   EnrollmentRole = "student"
+	EnrollmentRoleTeacher   EnrollmentRole = "teacher"
+	EnrollmentRoleAssistant EnrollmentRole = "assistant"
+)
+
+// EnrollmentStatus is where an enrollment stands.
+type EnrollmentStatus string
+
+const (
+	EnrollmentActive    EnrollmentStatus = "active"
+	EnrollmentDropped   EnrollmentStatus = "dropped"
+	EnrollmentCompleted EnrollmentStatus = "completed"
+)
+
+// Enrollment links a user to a class. A user has at most one enrollment per
+// class; enrolling again after dropping reactivates it.
+type Enrollment struct {
+	EnrollmentID uint             `json:"enrollmentId"`
+	ClassID      uint             `json:"classId"`
+	UserID       uint             `json:"userId"`
+	Role         EnrollmentRole   `json:"role"`
+	Status       EnrollmentStatus `json:"status"`
+	EnrolledAt   time.Time        `json:"enrolledAt"`
+	DroppedAt    *time.Time       `json:"droppedAt,omitempty"`
+	User         *User            `json:"user,omitempty"`
+	// Warnings are only returned when enrolling, e.g. for schedule conflicts
+	// under a soft limit.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+var (
+	ErrInvalidEnrollment = errors.New("invalid enrollment")
+	// ErrCannotTeach is returned when enrolling a user as a teacher whose
+	// profile does not allow teaching.
+	ErrCannotTeach = errors.New("user's profile does not allow teaching")
+	ErrNotEnrolled = errors.New("user is not enrolled in the class")
+)
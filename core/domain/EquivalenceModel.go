@@ -0,0 +1,41 @@
+package domain
+
+import "errors"
+
+// Equivalence says that passing every discipline in SourceIDs credits
+// TargetID: one source makes a one-to-one equivalence, several a many-to-one.
+// Equivalences only work one way, so crediting the sources from the target
+// needs an equivalence of its own.
+type Equivalence struct {
+	ID        uint   `json:"id"`
+	TargetID  uint   `json:"targetId"`
+	SourceIDs []uint `json:"sourceIds"`
+}
+
+// CreditedDiscipline is a discipline of a curriculum that counts as passed,
+// either because it was completed itself or through an equivalence.
+type CreditedDiscipline struct {
+	Discipline Discipline `json:"discipline"`
+	// EquivalenceID is 0 when the discipline itself was completed.
+	EquivalenceID uint         `json:"equivalenceId,omitempty"`
+	From          []Discipline `json:"from"`
+}
+
+// CreditTransfer is what a set of completed disciplines is worth in a
+// curriculum.
+type CreditTransfer struct {
+	CurriculumID uint                 `json:"curriculumId"`
+	Credited     []CreditedDiscipline `json:"credited"`
+	// Pending lists the curriculum's disciplines that are not credited.
+	Pending []Discipline `json:"pending"`
+	// Unused lists the completed disciplines that credited nothing.
+	Unused []Discipline `json:"unused"`
+	// Credits adds up the credits of the credited disciplines.
+	Credits int `json:"credits"`
+}
+
+var (
+	ErrInvalidEquivalence  = errors.New("invalid equivalence")
+	ErrEquivalenceExists   = errors.New("equivalence already exists")
+	ErrEquivalenceNotFound = errors.New("equivalence not found")
+)
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Assessment is one graded piece of work in a class, such as an exam or a
+// project. A student's final grade is the average of their grades weighted
+// by Weight, so weights need not add up to anything in particular.
+type Assessment struct {
+	ID      uint    `json:"id"`
+	ClassID uint    `json:"classId"`
+	Name    string  `json:"name"`
+	Weight  float64 `json:"weight"`
+}
+
+// Grade is a student's grade in an assessment.
+type Grade struct {
+	AssessmentID uint    `json:"assessmentId"`
+	UserID       uint    `json:"userId"`
+	Value        float64 `json:"value"`
+}
+
+// GradingPolicy sets the grade scale.
+type GradingPolicy struct {
+	// MaxGrade is the highest grade; grades go from 0 to MaxGrade.
+	MaxGrade float64
+	// PassingGrade is the lowest final grade that approves a student.
+	PassingGrade float64
+}
+
+// ResultStatus is where a student stands in a class.
+type ResultStatus string
+
+const (
+	// ResultInProgress has assessments still to be graded.
+	ResultInProgress ResultStatus = "in_progress"
+	ResultApproved   ResultStatus = "approved"
+	ResultFailed     ResultStatus = "failed"
+	// ResultFailedAttendance attended less than the minimum, whatever the
+	// grades.
+	ResultFailedAttendance ResultStatus = "failed_attendance"
+)
+
+// StudentResult is a student's grades and standing in a class.
+type StudentResult struct {
+	ClassID uint    `json:"classId"`
+	UserID  uint    `json:"userId"`
+	User    *User   `json:"user,omitempty"`
+	Grades  []Grade `json:"grades"`
+	// FinalGrade is the weighted average of the grades, counting the ones
+	// still missing as zero.
+	FinalGrade float64 `json:"finalGrade"`
+	// Attendance is the percentage of the lectures held that the student
+	// attended.
+	Attendance float64      `json:"attendance"`
+	Status     ResultStatus `json:"status"`
+}
+
+// ClassResults lists the results of every student in a class.
+type ClassResults struct {
+	ClassID      uint            `json:"classId"`
+	Assessments  []Assessment    `json:"assessments"`
+	PassingGrade float64         `json:"passingGrade"`
+	Students     []StudentResult `json:"students"`
+}
+
+// AcademicRecord is a student's final result in a class, kept when the class
+// is closed. Records of every class make up the student's history, and the
+// approved ones are the disciplines the student has completed.
+type AcademicRecord struct {
+	UserID       uint         `json:"userId"`
+	ClassID      uint         `json:"classId"`
+	DisciplineID uint         `json:"disciplineId"`
+	Discipline   *Discipline  `json:"discipline,omitempty"`
+	FinalGrade   float64      `json:"finalGrade"`
+	Attendance   float64      `json:"attendance"`
+	Status       ResultStatus `json:"status"`
+	CompletedAt  time.Time    `json:"completedAt"`
+}
+
+var (
+	ErrInvalidAssessment  = errors.New("invalid assessment")
+	ErrAssessmentNotFound = errors.New("assessment not found")
+	ErrInvalidGrade       = errors.New("invalid grade")
+)
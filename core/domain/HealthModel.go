@@ -0,0 +1,39 @@
+package domain
+
+// HealthStatus is the body returned by the liveness probe.
+type HealthStatus struct {
+	Status string `json:"status"`
+}
+
+// ReadinessReport is the body returned by the readiness probe.
+type ReadinessReport struct {
+	Status     string          `json:"status"`
+	Database   DatabaseCheck   `json:"database"`
+	Migrations MigrationsCheck `json:"migrations"`
+	Pool       PoolCheck       `json:"pool"`
+}
+
+type DatabaseCheck struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+type MigrationsCheck struct {
+	Status   string `json:"status"`
+	Current  int    `json:"current"`
+	Expected int    `json:"expected"`
+	Error    string `json:"error,omitempty"`
+}
+
+// PoolCheck reports connection pool usage. Saturation is InUse divided by
+// MaxOpenConnections and is omitted when the pool is unbounded.
+type PoolCheck struct {
+	Status             string   `json:"status"`
+	OpenConnections    int      `json:"openConnections"`
+	InUse              int      `json:"inUse"`
+	Idle               int      `json:"idle"`
+	MaxOpenConnections int      `json:"maxOpenConnections"`
+	WaitCount          int64    `json:"waitCount"`
+	Saturation         *float64 `json:"saturation,omitempty"`
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "errors"
+
+// ImportOptions controls how a spreadsheet is imported.
+type ImportOptions struct {
+	// Mapping maps a field name (e.g. "buildingName") to the header of the
+	// column holding it. Fields not mapped are read from a column with the
+	// field's own name.
+	Mapping map[string]string
+	// DryRun validates the rows without writing anything.
+	DryRun bool
+}
+
+// ImportReport is the outcome of an import. Rows are only written when
+// Errors is empty: a single invalid row rejects the whole file. Warnings
+// flag rows that are accepted anyway, such as schedule conflicts under a
+// soft limit.
+type ImportReport struct {
+	Entity    string           `json:"entity"`
+	Rows      int              `json:"rows"`
+	Inserted  int              `json:"inserted"`
+	Updated   int              `json:"updated"`
+	DryRun    bool             `json:"dryRun"`
+	Committed bool             `json:"committed"`
+	Errors    []ImportRowError `json:"errors,omitempty"`
+	Warnings  []ImportRowError `json:"warnings,omitempty"`
+}
+
+// ImportRowError describes a problem with one row. Row is the line number in
+// the spreadsheet, counting the header as line 1.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// ErrUnknownImportEntity is returned when importing an entity that has no
+// importer.
+var ErrUnknownImportEntity = errors.New("unknown import entity")
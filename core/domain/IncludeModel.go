@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidInclude is returned when ?include= names a relation the
+// endpoint does not have.
+var ErrInvalidInclude = errors.New("invalid include")
+
+// Includes is a parsed ?include= list. Each relation maps to the relations to
+// load on it in turn, so "class.discipline,room" becomes
+// {"class": {"discipline": {}}, "room": {}}.
+type Includes map[string]Includes
+
+// ParseIncludes parses a comma separated list of dotted relation paths.
+func ParseIncludes(s string) Includes {
+	include := Includes{}
+	for _, path := range strings.Split(s, ",") {
+		node := include
+		for _, name := range strings.Split(strings.TrimSpace(path), ".") {
+			if name == "" {
+				continue
+			}
+			if node[name] == nil {
+				node[name] = Includes{}
+			}
+			node = node[name]
+		}
+	}
+	return include
+}
+
+// Check returns ErrInvalidInclude if inc names a relation missing from
+// allowed, at any depth.
+func (inc Includes) Check(allowed Includes) error {
+	return inc.check(allowed, "")
+}
+
+func (inc Includes) check(allowed Includes, prefix string) error {
+	names := make([]string, 0, len(inc))
+	for name := range inc {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sub, ok := allowed[name]
+		if !ok {
+			return fmt.Errorf("%w: %q, expected one of %s", ErrInvalidInclude, prefix+name, allowed.paths(prefix))
+		}
+		if err := inc[name].check(sub, prefix+name+"."); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// paths lists the relations of inc, for error messages.
+func (inc Includes) paths(prefix string) string {
+	var out []string
+	for name := range inc {
+		out = append(out, prefix+name)
+	}
+	if len(out) == 0 {
+		return "nothing"
+	}
+	sort.Strings(out)
+	return strings.Join(out, ", ")
+}
@@ -1,14 +1,67 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/lib/pq"
 )
 
 type Lecture struct {
-	LectureID uint           `gorm:"primaryKey" json:"lectureId,omitempty" swaggerignore:"true"`
-	ClassID   uint           `json:"classId"`
-	RoomID    uint           `json:"roomId"`
-	Date      string         `json:"date"`
+	LectureID uint   `gorm:"primaryKey" json:"lectureId,omitempty" swaggerignore:"true"`
+	ClassID   uint   `json:"classId"`
+	RoomID    uint   `json:"roomId"`
+	Date      string `json:"date"`
+	// StartTime and EndTime are "HH:MM" on Date; both are empty when unscheduled.
+	StartTime string         `json:"startTime,omitempty"`
+	EndTime   string         `json:"endTime,omitempty"`
 	Content   pq.StringArray `gorm:"type:text[]" json:"content" swaggertype:"array,string"`
 	Presence  []User         `gorm:"many2many:lecture_presence;" json:"presence"`
+	// Class and Room are only loaded with ?include=class or ?include=room.
+	Class *Class `gorm:"-" json:"class,omitempty"`
+	Room  *Room  `gorm:"-" json:"room,omitempty"`
+	// Warnings are only returned when creating or updating, e.g. when the
+	// room is too small under a soft capacity limit.
+	Warnings []string `gorm:"-" json:"warnings,omitempty"`
+}
+
+// ErrInvalidLectureTime is returned when a lecture's date or times cannot be
+// parsed, only one of the times is set, or it ends before it starts.
+var ErrInvalidLectureTime = errors.New("invalid lecture time")
+
+// Interval returns when the lecture starts and ends in loc. A lecture without
+// times spans its whole date.
+func (l *Lecture) Interval(loc *time.Location) (start, end time.Time, err error) {
+	date := l.Date
+	if len(date) > len("2006-01-02") {
+		date = date[:len("2006-01-02")]
+	}
+	day, err := time.ParseInLocation("2006-01-02", date, loc)
+	if err != nil {
+		return start, end, fmt.Errorf("%w: date %q", ErrInvalidLectureTime, l.Date)
+	}
+	if l.StartTime == "" && l.EndTime == "" {
+		return day, day.AddDate(0, 0, 1), nil
+	}
+	start, err = clockOn(day, l.StartTime)
+	if err != nil {
+		return start, end, err
+	}
+	end, err = clockOn(day, l.EndTime)
+	if err != nil {
+		return start, end, err
+	}
+	if !end.After(start) {
+		return start, end, fmt.Errorf("%w: ends at %s before it starts at %s", ErrInvalidLectureTime, l.EndTime, l.StartTime)
+	}
+	return start, end, nil
+}
+
+func clockOn(day time.Time, clock string) (time.Time, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return t, fmt.Errorf("%w: %q, expected HH:MM", ErrInvalidLectureTime, clock)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, day.Location()), nil
 }
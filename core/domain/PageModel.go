@@ -0,0 +1,8 @@
+package domain
+
+// Page selects a window of a list. A zero Limit returns every row from
+// Offset on.
+type Page struct {
+	Limit  int
+	Offset int
+}
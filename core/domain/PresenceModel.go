@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// PresenceMethod records how a student's attendance was taken.
+type PresenceMethod string
+
+const (
+	// PresenceMethodManual is a single student marked by the teacher.
+	PresenceMethodManual PresenceMethod = "manual"
+	// PresenceMethodRollCall is a student marked in a bulk roll call.
+	PresenceMethodRollCall PresenceMethod = "roll_call"
+	// PresenceMethodQRCode is a student who checked in by scanning the
+	// lecture's QR code.
+	PresenceMethodQRCode PresenceMethod = "qr_code"
+)
+
+// Presence is one user's attendance at a lecture.
+type Presence struct {
+	LectureID uint           `json:"lectureId"`
+	UserID    uint           `json:"userId"`
+	Method    PresenceMethod `json:"method"`
+	MarkedAt  time.Time      `json:"markedAt"`
+	User      *User          `json:"user,omitempty"`
+}
+
+// PresenceMarkResult summarizes a bulk roll call.
+type PresenceMarkResult struct {
+	Marked         int `json:"marked"`
+	AlreadyPresent int `json:"alreadyPresent"`
+}
+
+var (
+	ErrLectureNotFound = errors.New("lecture not found")
+	ErrUserNotFound    = errors.New("user not found")
+)
@@ -0,0 +1,55 @@
+package domain
+
+import "errors"
+
+// LockedDiscipline is a discipline a student cannot take yet.
+type LockedDiscipline struct {
+	Discipline Discipline `json:"discipline"`
+	// Missing lists the prerequisites the student has not completed.
+	Missing []Discipline `json:"missing"`
+}
+
+// CurriculumProgress is how far a student is through a curriculum. A
+// discipline counts as completed when the student was approved in it, or in
+// disciplines equivalent to it.
+type CurriculumProgress struct {
+	UserID       uint                 `json:"userId"`
+	CurriculumID uint                 `json:"curriculumId"`
+	Completed    []CreditedDiscipline `json:"completed"`
+	// InProgress lists the disciplines the student is taking now.
+	InProgress []Discipline `json:"inProgress"`
+	// Unlocked lists the disciplines whose prerequisites are all completed.
+	Unlocked []Discipline       `json:"unlocked"`
+	Locked   []LockedDiscipline `json:"locked"`
+
+	CreditsTotal     int `json:"creditsTotal"`
+	CreditsCompleted int `json:"creditsCompleted"`
+	CreditsRemaining int `json:"creditsRemaining"`
+	// MandatoryCreditsRemaining leaves electives out.
+	MandatoryCreditsRemaining int `json:"mandatoryCreditsRemaining"`
+}
+
+// PlannedClass is a class suggested for a discipline in a study plan.
+type PlannedClass struct {
+	Discipline Discipline `json:"discipline"`
+	Class      Class      `json:"class"`
+}
+
+// SkippedDiscipline is an unlocked discipline left out of a study plan.
+type SkippedDiscipline struct {
+	Discipline Discipline `json:"discipline"`
+	Reason     string     `json:"reason"`
+}
+
+// StudyPlan suggests the classes a student could take next semester.
+type StudyPlan struct {
+	UserID       uint `json:"userId"`
+	CurriculumID uint `json:"curriculumId"`
+	// MaxCredits caps the plan; 0 means no cap.
+	MaxCredits int                 `json:"maxCredits"`
+	Credits    int                 `json:"credits"`
+	Classes    []PlannedClass      `json:"classes"`
+	Skipped    []SkippedDiscipline `json:"skipped"`
+}
+
+var ErrInvalidStudyPlan = errors.New("invalid study plan")
@@ -0,0 +1,42 @@
+package domain
+
+import "errors"
+
+// RequisiteKind says when a requisite must be taken.
+type RequisiteKind string
+
+const (
+	// RequisitePrerequisite must be passed before the discipline is taken.
+	RequisitePrerequisite RequisiteKind = "prerequisite"
+	// RequisiteCorequisite must be taken before or together with the
+	// discipline.
+	RequisiteCorequisite RequisiteKind = "corequisite"
+)
+
+// Requisite is an edge of a curriculum's graph: within CurriculumID, taking
+// DisciplineID requires RequisiteID. Both disciplines belong to the
+// curriculum, so the same pair may be related differently in another one.
+type Requisite struct {
+	CurriculumID uint          `json:"curriculumId"`
+	DisciplineID uint          `json:"disciplineId"`
+	RequisiteID  uint          `json:"requisiteId"`
+	Kind         RequisiteKind `json:"kind"`
+}
+
+// CurriculumGraph is the directed graph of a curriculum: its disciplines, and
+// an edge from each discipline to each of its requisites.
+type CurriculumGraph struct {
+	CurriculumID uint         `json:"curriculumId"`
+	Nodes        []Discipline `json:"nodes"`
+	Edges        []Requisite  `json:"edges"`
+}
+
+var (
+	ErrInvalidRequisite = errors.New("invalid requisite")
+	// ErrRequisiteCycle is returned when a requisite would make a discipline
+	// depend on itself through at least one prerequisite. Co-requisites alone
+	// may form cycles, since they can all be taken together.
+	ErrRequisiteCycle    = errors.New("requisite would create a cycle")
+	ErrRequisiteExists   = errors.New("requisite already exists")
+	ErrRequisiteNotFound = errors.New("requisite not found")
+)
@@ -5,4 +5,6 @@ type Reservation struct {
 	LectureID     uint       `json:"lectureId"`
 	Observation   string     `json:"observation"`
 	Resources     []Resource `gorm:"many2many:reservation_resources;" json:"resources"`
+	// Lecture is only loaded with ?include=lecture.
+	Lecture *Lecture `gorm:"-" json:"lecture,omitempty"`
 }
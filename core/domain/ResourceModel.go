@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"errors"
+
 	"github.com/lib/pq"
 )
 
@@ -33,3 +35,7 @@ const (
 	ResourceStatusUnavailable ResourceStatus = "unavailable"
 	ResourceStatusReserved    ResourceStatus = "reserved"
 )
+
+// ErrResourceTypeInUse is returned when deleting a resource type that
+// resources still reference.
+var ErrResourceTypeInUse = errors.New("resource type is still used by resources")
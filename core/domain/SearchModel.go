@@ -0,0 +1,61 @@
+package domain
+
+import "strings"
+
+// Search result types.
+const (
+	SearchTypeDiscipline = "discipline"
+	SearchTypeResource   = "resource"
+	SearchTypeBuilding   = "building"
+	SearchTypeRoom       = "room"
+	SearchTypeClass      = "class"
+)
+
+// SearchQuery is a free-text search as passed to the repositories.
+type SearchQuery struct {
+	// Text is the query as typed by the user.
+	Text string
+	// Terms are the lowercased words of Text, for backends without a
+	// full-text engine.
+	Terms []string
+	// Limit is the maximum number of hits returned per type.
+	Limit int
+}
+
+// SearchHit is one matching record.
+type SearchHit struct {
+	Type  string  `json:"type"`
+	ID    uint    `json:"id"`
+	Title string  `json:"title"`
+	Rank  float64 `json:"rank"`
+	// Headline is an HTML-escaped excerpt of the matching text with the
+	// matched words wrapped in <b></b>.
+	Headline string `json:"headline"`
+	// Text is the searchable text of the record, used to build the headline
+	// when the backend doesn't.
+	Text string `json:"-"`
+}
+
+// SearchGroup holds the hits of one type, best first.
+type SearchGroup struct {
+	Type string      `json:"type"`
+	Hits []SearchHit `json:"hits"`
+}
+
+// SearchResults are grouped by type, the group with the best hit first.
+type SearchResults struct {
+	Query  string        `json:"query"`
+	Total  int           `json:"total"`
+	Groups []SearchGroup `json:"groups"`
+}
+
+// SearchText joins the non-empty searchable fields of a record.
+func SearchText(fields ...string) string {
+	parts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			parts = append(parts, f)
+		}
+	}
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// TimetableEntry is one lecture in a user's timetable, with its class, room
+// and building, and what was reserved for it.
+type TimetableEntry struct {
+	Lecture      Lecture        `json:"lecture"`
+	Role         EnrollmentRole `json:"role"`
+	Start        time.Time      `json:"start"`
+	End          time.Time      `json:"end"`
+	Reservations []Reservation  `json:"reservations"`
+	// Conflicts lists the other lectures in the timetable that overlap this
+	// one. Lectures without times are never reported.
+	Conflicts []uint `json:"conflicts,omitempty"`
+}
+
+// Timetable lists a user's lectures between From and To, both inclusive
+// YYYY-MM-DD dates, in the classes they study, teach or assist.
+type Timetable struct {
+	UserID       uint             `json:"userId"`
+	From         string           `json:"from"`
+	To           string           `json:"to"`
+	HasConflicts bool             `json:"hasConflicts"`
+	Entries      []TimetableEntry `json:"entries"`
+}
+
+// ErrInvalidDateRange is returned for a malformed or reversed date range, or
+// one that is too long.
+var ErrInvalidDateRange = errors.New("invalid date range")
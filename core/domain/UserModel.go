@@ -13,4 +13,6 @@ type User struct {
 type Profile struct {
 	ID   uint   `gorm:"primaryKey" json:"id,omitempty" swaggerignore:"true"`
 	Role string `json:"role"`
+	// CanTeach allows users with this profile to be enrolled as teachers.
+	CanTeach bool `json:"canTeach"`
 }
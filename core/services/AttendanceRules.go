@@ -0,0 +1,71 @@
+package services
+
+import (
+	"fmt"
+	"math"
+
+	"sarc/core/domain"
+)
+
+// attendanceRule flags a student with status when applies holds. allowed is
+// how many of the class's lectures a student may miss in total.
+type attendanceRule struct {
+	status  domain.AttendanceStatus
+	applies func(a domain.StudentAttendance, allowed int, policy domain.AttendancePolicy) bool
+	message func(a domain.StudentAttendance, allowed int, policy domain.AttendancePolicy) string
+}
+
+// attendanceRules are checked in order and the first match sets the status,
+// so the most severe come first.
+var attendanceRules = []attendanceRule{
+	{
+		status: domain.AttendanceFailed,
+		applies: func(a domain.StudentAttendance, allowed int, _ domain.AttendancePolicy) bool {
+			return a.Absent > allowed
+		},
+		message: func(a domain.StudentAttendance, allowed int, policy domain.AttendancePolicy) string {
+			return fmt.Sprintf("missed %d lectures, more than the %d allowed, and can no longer reach %g%% attendance", a.Absent, allowed, policy.MinimumPercentage)
+		},
+	},
+	{
+		status: domain.AttendanceBelowMinimum,
+		applies: func(a domain.StudentAttendance, _ int, policy domain.AttendancePolicy) bool {
+			return a.LecturesHeld > 0 && a.Percentage < policy.MinimumPercentage
+		},
+		message: func(a domain.StudentAttendance, _ int, policy domain.AttendancePolicy) string {
+			return fmt.Sprintf("attended %g%% of the lectures held, below the %g%% minimum", a.Percentage, policy.MinimumPercentage)
+		},
+	},
+	{
+		status: domain.AttendanceAtRisk,
+		applies: func(a domain.StudentAttendance, _ int, policy domain.AttendancePolicy) bool {
+			return a.Absent > 0 && a.AbsencesLeft <= policy.WarnAbsencesLeft
+		},
+		message: func(a domain.StudentAttendance, _ int, policy domain.AttendancePolicy) string {
+			return fmt.Sprintf("can miss only %d more lectures before falling below %g%% attendance", a.AbsencesLeft, policy.MinimumPercentage)
+		},
+	},
+}
+
+// evaluateAttendance fills in the derived fields of a from its present count
+// and returns the message of the rule that set its status, if any.
+func evaluateAttendance(a *domain.StudentAttendance, total, held int, policy domain.AttendancePolicy) string {
+	a.LecturesHeld = held
+	a.Absent = held - a.Present
+	a.Percentage = 100
+	if held > 0 {
+		a.Percentage = math.Round(1000*float64(a.Present)/float64(held)) / 10
+	}
+	required := int(math.Ceil(float64(total)*policy.MinimumPercentage/100 - 1e-9))
+	allowed := total - required
+	a.AbsencesLeft = max(allowed-a.Absent, 0)
+
+	a.Status = domain.AttendanceOK
+	for _, rule := range attendanceRules {
+		if rule.applies(*a, allowed, policy) {
+			a.Status = rule.status
+			return rule.message(*a, allowed, policy)
+		}
+	}
+	return ""
+}
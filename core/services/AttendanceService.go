@@ -0,0 +1,303 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"sarc/core/domain"
+	interfaces "sarc/core/services/interfaces"
+	"sarc/infrastructure/notifications"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type attendanceService struct {
+	presenceRepo   repositories.PresenceRepository
+	lectureRepo    repositories.LectureRepository
+	classRepo      repositories.ClassRepository
+	userRepo       repositories.UserRepository
+	enrollmentRepo repositories.EnrollmentRepository
+	alertRepo      repositories.AttendanceAlertRepository
+	notifier       notifications.Notifier
+	policy         domain.AttendancePolicy
+	loc            *time.Location
+	now            func() time.Time
+}
+
+func NewAttendanceService(repos repositories.Repositories, notifier notifications.Notifier, policy domain.AttendancePolicy, loc *time.Location) interfaces.AttendanceService {
+	return &attendanceService{
+		presenceRepo:   repos.Presence,
+		lectureRepo:    repos.Lecture,
+		classRepo:      repos.Class,
+		userRepo:       repos.User,
+		enrollmentRepo: repos.Enrollment,
+		alertRepo:      repos.AttendanceAlert,
+		notifier:       notifier,
+		policy:         policy,
+		loc:            loc,
+		now:            time.Now,
+	}
+}
+
+func (s *attendanceService) GetClassAttendance(classID uint) (*domain.ClassAttendanceReport, error) {
+	report, _, err := s.classAttendance(classID)
+	return report, err
+}
+
+func (s *attendanceService) GetStudentAttendance(userID uint) ([]domain.StudentAttendance, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	presence, err := s.presenceRepo.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	attended := make(map[uint]bool, len(presence))
+	lectureIDs := make([]uint, len(presence))
+	for i, p := range presence {
+		attended[p.LectureID] = true
+		lectureIDs[i] = p.LectureID
+	}
+	lectures, err := s.lectureRepo.FindByIDs(lectureIDs)
+	if err != nil {
+		return nil, err
+	}
+	enrollments, err := s.enrollmentRepo.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	// Classes the user is a student of, plus those attended without an
+	// enrollment, skipping the ones dropped or taught.
+	var classIDs []uint
+	seen := make(map[uint]bool)
+	for _, e := range enrollments {
+		seen[e.ClassID] = true
+		if countsAsStudent(e) {
+			classIDs = append(classIDs, e.ClassID)
+		}
+	}
+	for _, l := range lectures {
+		if !seen[l.ClassID] {
+			seen[l.ClassID] = true
+			classIDs = append(classIDs, l.ClassID)
+		}
+	}
+	sort.Slice(classIDs, func(i, j int) bool { return classIDs[i] < classIDs[j] })
+
+	var out []domain.StudentAttendance
+	for _, classID := range classIDs {
+		lectures, err := s.lectureRepo.FindByClass(classID, domain.Page{})
+		if err != nil {
+			return nil, err
+		}
+		held, err := s.held(lectures)
+		if err != nil {
+			return nil, err
+		}
+		a := domain.StudentAttendance{ClassID: classID, UserID: userID, User: user}
+		for id := range held {
+			if attended[id] {
+				a.Present++
+			}
+		}
+		evaluateAttendance(&a, len(lectures), len(held), s.policy)
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+func (s *attendanceService) NotifyClassAlerts(classID uint) ([]domain.AttendanceAlert, error) {
+	report, alerts, err := s.classAttendance(classID)
+	if err != nil {
+		return nil, err
+	}
+	return s.notifyChanges(report, alerts)
+}
+
+func (s *attendanceService) NotifyAllAlerts() ([]domain.AttendanceAlert, error) {
+	classes, err := s.classRepo.FindAll(domain.Page{})
+	if err != nil {
+		return nil, err
+	}
+	var all []domain.AttendanceAlert
+	var errs []error
+	for _, c := range classes {
+		report, alerts, err := s.classAttendance(c.ClassID)
+		if err != nil {
+			return all, err
+		}
+		sent, err := s.notifyChanges(report, alerts)
+		all = append(all, sent...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return all, errors.Join(errs...)
+}
+
+// classAttendance builds the class report along with an alert for every
+// student whose status is not ok.
+func (s *attendanceService) classAttendance(classID uint) (*domain.ClassAttendanceReport, []domain.AttendanceAlert, error) {
+	if _, err := s.classRepo.FindByID(classID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, domain.ErrClassNotFound
+		}
+		return nil, nil, err
+	}
+	lectures, err := s.lectureRepo.FindByClass(classID, domain.Page{})
+	if err != nil {
+		return nil, nil, err
+	}
+	held, err := s.held(lectures)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids := make([]uint, len(lectures))
+	for i, l := range lectures {
+		ids[i] = l.LectureID
+	}
+	presence, err := s.presenceRepo.FindByLectures(ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	enrollments, err := s.enrollmentRepo.FindByClass(classID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The report covers the class's students, plus anyone marked present
+	// without an enrollment; dropped students and staff are left out.
+	byUser := make(map[uint]*domain.StudentAttendance)
+	excluded := make(map[uint]bool)
+	var order []uint
+	for _, e := range enrollments {
+		if !countsAsStudent(e) {
+			excluded[e.UserID] = true
+			continue
+		}
+		byUser[e.UserID] = &domain.StudentAttendance{ClassID: classID, UserID: e.UserID, User: e.User}
+		order = append(order, e.UserID)
+	}
+	for _, p := range presence {
+		if excluded[p.UserID] {
+			continue
+		}
+		a, ok := byUser[p.UserID]
+		if !ok {
+			a = &domain.StudentAttendance{ClassID: classID, UserID: p.UserID, User: p.User}
+			byUser[p.UserID] = a
+			order = append(order, p.UserID)
+		}
+		if held[p.LectureID] {
+			a.Present++
+		}
+	}
+	sort.Slice(order, func(i, j int) bool { return order[i] < order[j] })
+
+	report := &domain.ClassAttendanceReport{
+		ClassID:           classID,
+		LecturesTotal:     len(lectures),
+		LecturesHeld:      len(held),
+		MinimumPercentage: s.policy.MinimumPercentage,
+		Students:          make([]domain.StudentAttendance, 0, len(order)),
+	}
+	var alerts []domain.AttendanceAlert
+	for _, id := range order {
+		a := byUser[id]
+		if message := evaluateAttendance(a, len(lectures), len(held), s.policy); message != "" {
+			alerts = append(alerts, domain.AttendanceAlert{StudentAttendance: *a, Message: message})
+		}
+		report.Students = append(report.Students, *a)
+	}
+	return report, alerts, nil
+}
+
+// countsAsStudent reports whether an enrollment puts its user in the class's
+// attendance report.
+func countsAsStudent(e domain.Enrollment) bool {
+	return e.Role == domain.EnrollmentRoleStudent && e.Status != domain.EnrollmentDropped
+}
+
+// held returns the IDs of the lectures that have already started.
+func (s *attendanceService) held(lectures []domain.Lecture) (map[uint]bool, error) {
+	now := s.now()
+	held := make(map[uint]bool)
+	for i := range lectures {
+		start, _, err := lectures[i].Interval(s.loc)
+		if err != nil {
+			return nil, fmt.Errorf("lecture %d: %w", lectures[i].LectureID, err)
+		}
+		if !now.Before(start) {
+			held[lectures[i].LectureID] = true
+		}
+	}
+	return held, nil
+}
+
+// notifyChanges sends the alerts whose status differs from the last one
+// notified to the student and returns those sent. Students back to ok are
+// recorded as such, so falling behind again alerts them again.
+func (s *attendanceService) notifyChanges(report *domain.ClassAttendanceReport, alerts []domain.AttendanceAlert) ([]domain.AttendanceAlert, error) {
+	notified, err := s.alertRepo.FindNotifiedStatuses(report.ClassID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	for _, a := range report.Students {
+		if last, ok := notified[a.UserID]; ok && last != domain.AttendanceOK && a.Status == domain.AttendanceOK {
+			if err := s.alertRepo.SaveNotifiedStatus(report.ClassID, a.UserID, domain.AttendanceOK, now); err != nil {
+				return nil, err
+			}
+		}
+	}
+	var changed []domain.AttendanceAlert
+	for _, alert := range alerts {
+		if notified[alert.UserID] != alert.Status {
+			changed = append(changed, alert)
+		}
+	}
+
+	sent, err := s.notify(changed)
+	errs := []error{err}
+	for _, alert := range sent {
+		errs = append(errs, s.alertRepo.SaveNotifiedStatus(report.ClassID, alert.UserID, alert.Status, now))
+	}
+	return sent, errors.Join(errs...)
+}
+
+// notifyConcurrency bounds the notifications sent at once.
+const notifyConcurrency = 8
+
+// notify sends the alerts a few at a time, continuing past failures, and
+// returns those delivered along with every failure.
+func (s *attendanceService) notify(alerts []domain.AttendanceAlert) ([]domain.AttendanceAlert, error) {
+	errs := make([]error, len(alerts))
+	slots := make(chan struct{}, notifyConcurrency)
+	var wg sync.WaitGroup
+	for i := range alerts {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = s.notifier.NotifyAttendance(alerts[i])
+			<-slots
+		}()
+	}
+	wg.Wait()
+
+	var sent []domain.AttendanceAlert
+	for i, alert := range alerts {
+		if errs[i] == nil {
+			sent = append(sent, alert)
+		}
+	}
+	return sent, errors.Join(errs...)
+}
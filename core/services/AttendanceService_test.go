@@ -0,0 +1,200 @@
+package services_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"sarc/core/domain"
+	"sarc/core/services"
+	memimpl "sarc/infrastructure/repositories/MEMimpl"
+)
+
+type recordingNotifier struct {
+	mu     sync.Mutex
+	alerts []domain.AttendanceAlert
+}
+
+func (n *recordingNotifier) NotifyAttendance(alert domain.AttendanceAlert) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.alerts = append(n.alerts, alert)
+	return nil
+}
+
+func TestAttendanceReportFlagsStudents(t *testing.T) {
+	repos := memimpl.NewRepositories(memimpl.NewStore())
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	profile := domain.Profile{Role: "student"}
+	must(repos.Profile.Create(&profile))
+	building := domain.Building{BuildingName: "Prédio 32"}
+	must(repos.Building.Create(&building))
+	room := domain.Room{RoomNumber: "101", BuildingID: building.BuildingID}
+	must(repos.Room.Create(&room))
+	discipline := domain.Discipline{Name: "Cálculo I", Credits: 4}
+	must(repos.Discipline.Create(&discipline))
+	class := domain.Class{Name: "Turma 10", DisciplineID: discipline.ID}
+	must(repos.Class.Create(&class))
+
+	// Six lectures already held and two still to come: with a 75% minimum
+	// a student may miss two of the eight.
+	var held []uint
+	for i := 0; i < 8; i++ {
+		date := fmt.Sprintf("2020-03-%02d", i+1)
+		if i >= 6 {
+			date = fmt.Sprintf("2999-03-%02d", i+1)
+		}
+		lecture := domain.Lecture{ClassID: class.ClassID, RoomID: room.RoomID, Date: date}
+		must(repos.Lecture.Create(&lecture))
+		if i < 6 {
+			held = append(held, lecture.LectureID)
+		}
+	}
+	// Each student attends a different number of the held lectures; the last
+	// is enrolled but never came, and a dropped student is left out.
+	attended := []int{6, 5, 4, 3, 0}
+	students := make([]domain.User, len(attended))
+	for i, n := range attended {
+		students[i] = domain.User{Email: fmt.Sprintf("s%d@example.com", i), Nome: fmt.Sprintf("S%d", i), ProfileID: profile.ID}
+		must(repos.User.Create(&students[i]))
+		for _, id := range held[:n] {
+			_, err := repos.Presence.Mark(id, []uint{students[i].ID}, domain.PresenceMethodManual, time.Now())
+			must(err)
+		}
+		must(repos.Enrollment.Create(&domain.Enrollment{ClassID: class.ClassID, UserID: students[i].ID, Role: domain.EnrollmentRoleStudent, Status: domain.EnrollmentActive, EnrolledAt: time.Now()}))
+	}
+	dropped := domain.User{Email: "dropped@example.com", Nome: "D", ProfileID: profile.ID}
+	must(repos.User.Create(&dropped))
+	_, err := repos.Presence.Mark(held[0], []uint{dropped.ID}, domain.PresenceMethodManual, time.Now())
+	must(err)
+	must(repos.Enrollment.Create(&domain.Enrollment{ClassID: class.ClassID, UserID: dropped.ID, Role: domain.EnrollmentRoleStudent, Status: domain.EnrollmentDropped, EnrolledAt: time.Now()}))
+
+	notifier := &recordingNotifier{}
+	svc := services.NewAttendanceService(repos, notifier, domain.AttendancePolicy{MinimumPercentage: 75, WarnAbsencesLeft: 2}, time.UTC)
+	report, err := svc.GetClassAttendance(class.ClassID)
+	must(err)
+	if report.LecturesTotal != 8 || report.LecturesHeld != 6 || len(report.Students) != 5 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	want := []struct {
+		status       domain.AttendanceStatus
+		percentage   float64
+		absencesLeft int
+	}{
+		{domain.AttendanceOK, 100, 2},
+		{domain.AttendanceAtRisk, 83.3, 1},
+		{domain.AttendanceBelowMinimum, 66.7, 0},
+		{domain.AttendanceFailed, 50, 0},
+		{domain.AttendanceFailed, 0, 0},
+	}
+	for i, w := range want {
+		got := report.Students[i]
+		if got.Status != w.status || got.Percentage != w.percentage || got.AbsencesLeft != w.absencesLeft {
+			t.Errorf("student %d: got %s %.1f%% %d left, want %s %.1f%% %d left",
+				i, got.Status, got.Percentage, got.AbsencesLeft, w.status, w.percentage, w.absencesLeft)
+		}
+	}
+
+	alerts, err := svc.NotifyClassAlerts(class.ClassID)
+	must(err)
+	if len(alerts) != 4 || len(notifier.alerts) != 4 {
+		t.Fatalf("expected 4 alerts sent, got %d returned and %d sent", len(alerts), len(notifier.alerts))
+	}
+	if alerts[0].UserID != students[1].ID || alerts[0].Message == "" {
+		t.Errorf("unexpected first alert: %+v", alerts[0])
+	}
+
+	mine, err := svc.GetStudentAttendance(students[1].ID)
+	must(err)
+	if len(mine) != 1 || mine[0].ClassID != class.ClassID || mine[0].Present != 5 || mine[0].Status != domain.AttendanceAtRisk {
+		t.Errorf("unexpected student report: %+v", mine)
+	}
+	if gone, err := svc.GetStudentAttendance(dropped.ID); err != nil || len(gone) != 0 {
+		t.Errorf("dropped student still reported: %+v %v", gone, err)
+	}
+}
+
+func TestAttendanceAlertsAreSentOncePerStatus(t *testing.T) {
+	repos := memimpl.NewRepositories(memimpl.NewStore())
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	profile := domain.Profile{Role: "student"}
+	must(repos.Profile.Create(&profile))
+	building := domain.Building{BuildingName: "Prédio 32"}
+	must(repos.Building.Create(&building))
+	room := domain.Room{RoomNumber: "101", BuildingID: building.BuildingID}
+	must(repos.Room.Create(&room))
+	discipline := domain.Discipline{Name: "Cálculo I", Credits: 4}
+	must(repos.Discipline.Create(&discipline))
+	class := domain.Class{Name: "Turma 10", DisciplineID: discipline.ID}
+	must(repos.Class.Create(&class))
+	student := domain.User{Email: "s@example.com", Nome: "S", ProfileID: profile.ID}
+	must(repos.User.Create(&student))
+	must(repos.Enrollment.Create(&domain.Enrollment{ClassID: class.ClassID, UserID: student.ID, Role: domain.EnrollmentRoleStudent, Status: domain.EnrollmentActive, EnrolledAt: time.Now()}))
+
+	// Six of eight lectures held; the student attends five, so is at risk.
+	var held []uint
+	for i := 0; i < 8; i++ {
+		date := fmt.Sprintf("2020-03-%02d", i+1)
+		if i >= 6 {
+			date = fmt.Sprintf("2999-03-%02d", i+1)
+		}
+		lecture := domain.Lecture{ClassID: class.ClassID, RoomID: room.RoomID, Date: date}
+		must(repos.Lecture.Create(&lecture))
+		if i < 6 {
+			held = append(held, lecture.LectureID)
+		}
+	}
+	for _, id := range held[:5] {
+		_, err := repos.Presence.Mark(id, []uint{student.ID}, domain.PresenceMethodManual, time.Now())
+		must(err)
+	}
+
+	notifier := &recordingNotifier{}
+	svc := services.NewAttendanceService(repos, notifier, domain.AttendancePolicy{MinimumPercentage: 75, WarnAbsencesLeft: 2}, time.UTC)
+	run := func(want int, status domain.AttendanceStatus) {
+		t.Helper()
+		before := len(notifier.alerts)
+		sent, err := svc.NotifyClassAlerts(class.ClassID)
+		must(err)
+		if len(sent) != want || len(notifier.alerts)-before != want {
+			t.Fatalf("expected %d alerts sent, got %d returned and %d sent", want, len(sent), len(notifier.alerts)-before)
+		}
+		if want > 0 && sent[0].Status != status {
+			t.Errorf("expected a %s alert, got %s", status, sent[0].Status)
+		}
+	}
+	run(1, domain.AttendanceAtRisk)
+	// Nothing changed, so nothing is sent again
+	run(0, "")
+	run(0, "")
+
+	// Back to ok: no alert, but falling behind again alerts again
+	_, err := repos.Presence.Mark(held[5], []uint{student.ID}, domain.PresenceMethodManual, time.Now())
+	must(err)
+	run(0, "")
+	must(repos.Presence.Unmark(held[5], student.ID))
+	run(1, domain.AttendanceAtRisk)
+
+	// A worse status is a change too
+	must(repos.Presence.Unmark(held[4], student.ID))
+	run(1, domain.AttendanceBelowMinimum)
+	run(0, "")
+
+	// NotifyAllAlerts shares the record
+	all, err := svc.NotifyAllAlerts()
+	must(err)
+	if len(all) != 0 {
+		t.Errorf("expected no alerts from NotifyAllAlerts, got %+v", all)
+	}
+}
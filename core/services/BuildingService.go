@@ -8,11 +8,12 @@ import (
 )
 
 type buildingService struct {
-	repo repositories.BuildingRepository
+	repo     repositories.BuildingRepository
+	roomRepo repositories.RoomRepository
 }
 
-func NewBuildingService(repo repositories.BuildingRepository) interfaces.BuildingService {
-	return &buildingService{repo: repo}
+func NewBuildingService(repo repositories.BuildingRepository, roomRepo repositories.RoomRepository) interfaces.BuildingService {
+	return &buildingService{repo: repo, roomRepo: roomRepo}
 }
 
 func (s *buildingService) CreateBuilding(building *domain.Building) (*domain.Building, error) {
@@ -22,8 +23,8 @@ func (s *buildingService) CreateBuilding(building *domain.Building) (*domain.Bui
 	return building, nil
 }
 
-func (s *buildingService) GetBuildings() ([]domain.Building, error) {
-	return s.repo.FindAll()
+func (s *buildingService) GetBuildings(page domain.Page) ([]domain.Building, error) {
+	return s.repo.FindAll(page)
 }
 
 func (s *buildingService) GetBuildingByID(id uint) (*domain.Building, error) {
@@ -47,3 +48,10 @@ func (s *buildingService) UpdateBuilding(id uint, building *domain.Building) (*d
 func (s *buildingService) DeleteBuilding(id uint) error {
 	return s.repo.Delete(id)
 }
+
+func (s *buildingService) GetBuildingRooms(id uint, page domain.Page) ([]domain.Room, error) {
+	if _, err := s.GetBuildingByID(id); err != nil {
+		return nil, err
+	}
+	return s.roomRepo.FindByBuilding(id, page)
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"sarc/core/domain"
+	interfaces "sarc/core/services/interfaces"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type checkInService struct {
+	presence       *presenceService
+	enrollmentRepo repositories.EnrollmentRepository
+	tx             repositories.Transactor
+	policy         domain.CheckInPolicy
+}
+
+// NewCheckInService keeps the tokens used to check in in the database until
+// they expire, so a token cannot be replayed after the presence is removed,
+// nor on another instance or after a restart.
+func NewCheckInService(repos repositories.Repositories, tx repositories.Transactor, policy domain.CheckInPolicy) interfaces.CheckInService {
+	return &checkInService{
+		presence:       &presenceService{repo: repos.Presence, lectureRepo: repos.Lecture, userRepo: repos.User, now: time.Now},
+		enrollmentRepo: repos.Enrollment,
+		tx:             tx,
+		policy:         policy,
+	}
+}
+
+func (s *checkInService) IssueToken(lectureID uint, userID uint) (*domain.CheckInToken, error) {
+	lecture, err := s.presence.lectureRepo.FindByID(lectureID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrLectureNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	enrollment, err := s.findEnrollment(lecture.ClassID, userID)
+	if errors.Is(err, domain.ErrNotEnrolled) {
+		return nil, domain.ErrNotLectureStaff
+	}
+	if err != nil {
+		return nil, err
+	}
+	if enrollment.Role == domain.EnrollmentRoleStudent || enrollment.Status != domain.EnrollmentActive {
+		return nil, domain.ErrNotLectureStaff
+	}
+	window := s.window(s.presence.now())
+	return &domain.CheckInToken{
+		LectureID: lectureID,
+		Token:     s.sign(lectureID, window),
+		ExpiresAt: s.expiry(window),
+	}, nil
+}
+
+func (s *checkInService) CheckIn(lectureID uint, userID uint, token string) (*domain.Presence, bool, error) {
+	now := s.presence.now()
+	window, err := s.verify(lectureID, token, now)
+	if err != nil {
+		return nil, false, err
+	}
+
+	lecture, err := s.presence.lectureRepo.FindByID(lectureID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, domain.ErrLectureNotFound
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	start, end, err := lecture.Interval(s.policy.Location)
+	if err != nil {
+		return nil, false, err
+	}
+	if now.Before(start.Add(-s.policy.OpenBefore)) || !now.Before(end) {
+		return nil, false, domain.ErrLectureNotInProgress
+	}
+	if err := s.checkEnrolled(lecture.ClassID, userID); err != nil {
+		return nil, false, err
+	}
+
+	var presence *domain.Presence
+	var created bool
+	err = s.tx.WithinTransaction(func(repos repositories.Repositories) error {
+		claimed, err := repos.CheckInClaim.Claim(lectureID, userID, window, s.expiry(window), now)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return domain.ErrCheckInTokenReused
+		}
+		marker := &presenceService{repo: repos.Presence, lectureRepo: repos.Lecture, userRepo: repos.User, now: s.presence.now}
+		presence, created, err = marker.markOne(lectureID, userID, domain.PresenceMethodQRCode)
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return presence, created, nil
+}
+
+// checkEnrolled only lets active students of the class check in.
+func (s *checkInService) checkEnrolled(classID uint, userID uint) error {
+	enrollment, err := s.findEnrollment(classID, userID)
+	if err != nil {
+		return err
+	}
+	if enrollment.Role != domain.EnrollmentRoleStudent || enrollment.Status != domain.EnrollmentActive {
+		return domain.ErrNotEnrolled
+	}
+	return nil
+}
+
+// findEnrollment returns ErrUserNotFound for unknown users and
+// ErrNotEnrolled when the user is not in the class.
+func (s *checkInService) findEnrollment(classID uint, userID uint) (*domain.Enrollment, error) {
+	if _, err := s.presence.userRepo.FindByID(userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	enrollment, err := s.enrollmentRepo.Find(classID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotEnrolled
+	}
+	return enrollment, err
+}
+
+// A token is "<lectureID>.<window>.<signature>", where window counts token
+// periods since the Unix epoch.
+func (s *checkInService) sign(lectureID uint, window int64) string {
+	return fmt.Sprintf("%d.%d.%s", lectureID, window, s.signature(lectureID, window))
+}
+
+func (s *checkInService) signature(lectureID uint, window int64) string {
+	mac := hmac.New(sha256.New, s.policy.Secret)
+	fmt.Fprintf(mac, "%d.%d", lectureID, window)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// verify checks token was signed for lectureID and is still within the
+// period it was issued in or the next one, and returns its window.
+func (s *checkInService) verify(lectureID uint, token string, now time.Time) (int64, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, domain.ErrCheckInTokenInvalid
+	}
+	id, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil || uint(id) != lectureID {
+		return 0, domain.ErrCheckInTokenInvalid
+	}
+	window, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, domain.ErrCheckInTokenInvalid
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(s.signature(lectureID, window))) {
+		return 0, domain.ErrCheckInTokenInvalid
+	}
+	current := s.window(now)
+	if window > current {
+		return 0, domain.ErrCheckInTokenInvalid
+	}
+	if window < current-1 {
+		return 0, domain.ErrCheckInTokenExpired
+	}
+	return window, nil
+}
+
+func (s *checkInService) window(t time.Time) int64 {
+	return t.UnixNano() / int64(s.policy.Period)
+}
+
+// expiry is when a token issued in window stops being accepted.
+func (s *checkInService) expiry(window int64) time.Time {
+	return time.Unix(0, (window+2)*int64(s.policy.Period))
+}
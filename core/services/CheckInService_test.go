@@ -0,0 +1,214 @@
+package services_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"sarc/core/domain"
+	"sarc/core/services"
+	interfaces "sarc/core/services/interfaces"
+	memimpl "sarc/infrastructure/repositories/MEMimpl"
+)
+
+// newCheckInFixture returns a lecture happening today with an active student
+// and teacher.
+func newCheckInFixture(t *testing.T, period time.Duration) (*memimpl.Store, interfaces.CheckInService, domain.Lecture, domain.User, domain.User) {
+	t.Helper()
+	store := memimpl.NewStore()
+	repos := memimpl.NewRepositories(store)
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	profile := domain.Profile{Role: "student"}
+	must(repos.Profile.Create(&profile))
+	user := domain.User{Email: "bia@example.com", Nome: "Bia", ProfileID: profile.ID}
+	must(repos.User.Create(&user))
+	teacher := domain.User{Email: "rui@example.com", Nome: "Rui", ProfileID: profile.ID}
+	must(repos.User.Create(&teacher))
+	building := domain.Building{BuildingName: "Prédio 32"}
+	must(repos.Building.Create(&building))
+	room := domain.Room{RoomNumber: "101", BuildingID: building.BuildingID}
+	must(repos.Room.Create(&room))
+	discipline := domain.Discipline{Name: "Cálculo I", Credits: 4}
+	must(repos.Discipline.Create(&discipline))
+	class := domain.Class{Name: "Turma 10", DisciplineID: discipline.ID}
+	must(repos.Class.Create(&class))
+	lecture := domain.Lecture{ClassID: class.ClassID, RoomID: room.RoomID, Date: time.Now().UTC().Format("2006-01-02")}
+	must(repos.Lecture.Create(&lecture))
+	must(repos.Enrollment.Create(&domain.Enrollment{ClassID: class.ClassID, UserID: user.ID, Role: domain.EnrollmentRoleStudent, Status: domain.EnrollmentActive, EnrolledAt: time.Now()}))
+	must(repos.Enrollment.Create(&domain.Enrollment{ClassID: class.ClassID, UserID: teacher.ID, Role: domain.EnrollmentRoleTeacher, Status: domain.EnrollmentActive, EnrolledAt: time.Now()}))
+
+	svc := services.NewCheckInService(repos, memimpl.NewTransactor(store), testCheckInPolicy(period))
+	return store, svc, lecture, user, teacher
+}
+
+func testCheckInPolicy(period time.Duration) domain.CheckInPolicy {
+	return domain.CheckInPolicy{
+		Secret:   []byte("0123456789abcdef"),
+		Period:   period,
+		Location: time.UTC,
+	}
+}
+
+func TestCheckInMarksPresenceOnce(t *testing.T) {
+	_, svc, lecture, user, teacher := newCheckInFixture(t, time.Minute)
+	token, err := svc.IssueToken(lecture.LectureID, teacher.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	presence, created, err := svc.CheckIn(lecture.LectureID, user.ID, token.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created || presence.Method != domain.PresenceMethodQRCode || presence.UserID != user.ID {
+		t.Errorf("unexpected check-in: created=%v %+v", created, presence)
+	}
+
+	if _, _, err := svc.CheckIn(lecture.LectureID, user.ID, token.Token); !errors.Is(err, domain.ErrCheckInTokenReused) {
+		t.Errorf("reusing a token: got %v, want %v", err, domain.ErrCheckInTokenReused)
+	}
+}
+
+func TestCheckInRejectsForgedTokens(t *testing.T) {
+	_, svc, lecture, user, teacher := newCheckInFixture(t, time.Minute)
+	token, err := svc.IssueToken(lecture.LectureID, teacher.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(token.Token, ".")
+
+	for name, forged := range map[string]string{
+		"garbage":       "not-a-token",
+		"other lecture": "999." + parts[1] + "." + parts[2],
+		"other window":  parts[0] + ".1." + parts[2],
+		"bad signature": parts[0] + "." + parts[1] + ".AAAAAAAAAAAAAAAAAAAAAA",
+	} {
+		if _, _, err := svc.CheckIn(lecture.LectureID, user.ID, forged); !errors.Is(err, domain.ErrCheckInTokenInvalid) {
+			t.Errorf("%s: got %v, want %v", name, err, domain.ErrCheckInTokenInvalid)
+		}
+	}
+}
+
+func TestCheckInRejectsExpiredTokens(t *testing.T) {
+	_, svc, lecture, user, teacher := newCheckInFixture(t, 10*time.Millisecond)
+	token, err := svc.IssueToken(lecture.LectureID, teacher.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Until(token.ExpiresAt) + time.Millisecond)
+
+	if _, _, err := svc.CheckIn(lecture.LectureID, user.ID, token.Token); !errors.Is(err, domain.ErrCheckInTokenExpired) {
+		t.Errorf("got %v, want %v", err, domain.ErrCheckInTokenExpired)
+	}
+}
+
+func TestCheckInRequiresLectureInProgress(t *testing.T) {
+	store, svc, lecture, user, teacher := newCheckInFixture(t, time.Minute)
+	repos := memimpl.NewRepositories(store)
+	lecture.Date = "2020-01-01"
+	if err := repos.Lecture.Update(lecture.LectureID, &lecture); err != nil {
+		t.Fatal(err)
+	}
+	token, err := svc.IssueToken(lecture.LectureID, teacher.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := svc.CheckIn(lecture.LectureID, user.ID, token.Token); !errors.Is(err, domain.ErrLectureNotInProgress) {
+		t.Errorf("got %v, want %v", err, domain.ErrLectureNotInProgress)
+	}
+}
+
+func TestCheckInRequiresActiveEnrollment(t *testing.T) {
+	store, svc, lecture, user, teacher := newCheckInFixture(t, time.Minute)
+	repos := memimpl.NewRepositories(store)
+	token, err := svc.IssueToken(lecture.LectureID, teacher.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enrollment, err := repos.Enrollment.Find(lecture.ClassID, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enrollment.Status = domain.EnrollmentDropped
+	if err := repos.Enrollment.Update(enrollment); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := svc.CheckIn(lecture.LectureID, user.ID, token.Token); !errors.Is(err, domain.ErrNotEnrolled) {
+		t.Errorf("got %v, want %v", err, domain.ErrNotEnrolled)
+	}
+}
+
+func TestCheckInTokenRequiresClassStaff(t *testing.T) {
+	_, svc, lecture, user, teacher := newCheckInFixture(t, time.Minute)
+	if _, err := svc.IssueToken(lecture.LectureID, teacher.ID); err != nil {
+		t.Errorf("teacher: %v", err)
+	}
+	if _, err := svc.IssueToken(lecture.LectureID, user.ID); !errors.Is(err, domain.ErrNotLectureStaff) {
+		t.Errorf("student: got %v, want %v", err, domain.ErrNotLectureStaff)
+	}
+	if _, err := svc.IssueToken(lecture.LectureID, 999); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("unknown user: got %v, want %v", err, domain.ErrUserNotFound)
+	}
+}
+
+func TestCheckInRejectsConcurrentReplays(t *testing.T) {
+	_, svc, lecture, user, teacher := newCheckInFixture(t, time.Minute)
+	token, err := svc.IssueToken(lecture.LectureID, teacher.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := svc.CheckIn(lecture.LectureID, user.ID, token.Token)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	accepted := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			accepted++
+		case !errors.Is(err, domain.ErrCheckInTokenReused):
+			t.Error(err)
+		}
+	}
+	if accepted != 1 {
+		t.Errorf("got %d check-ins with the same token, want 1", accepted)
+	}
+}
+
+// A token used on one instance is rejected by another sharing the database,
+// as after a restart.
+func TestCheckInRemembersTokensAcrossInstances(t *testing.T) {
+	store, first, lecture, user, teacher := newCheckInFixture(t, time.Minute)
+	repos := memimpl.NewRepositories(store)
+	token, err := first.IssueToken(lecture.LectureID, teacher.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := first.CheckIn(lecture.LectureID, user.ID, token.Token); err != nil {
+		t.Fatal(err)
+	}
+	if err := repos.Presence.Unmark(lecture.LectureID, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	second := services.NewCheckInService(repos, memimpl.NewTransactor(store), testCheckInPolicy(time.Minute))
+	if _, _, err := second.CheckIn(lecture.LectureID, user.ID, token.Token); !errors.Is(err, domain.ErrCheckInTokenReused) {
+		t.Errorf("got %v, want %v", err, domain.ErrCheckInTokenReused)
+	}
+}
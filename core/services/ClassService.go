@@ -8,11 +8,12 @@ import (
 )
 
 type classService struct {
-	repo repositories.ClassRepository
+	repo        repositories.ClassRepository
+	lectureRepo repositories.LectureRepository
 }
 
-func NewClassService(repo repositories.ClassRepository) interfaces.ClassService {
-	return &classService{repo: repo}
+func NewClassService(repo repositories.ClassRepository, lectureRepo repositories.LectureRepository) interfaces.ClassService {
+	return &classService{repo: repo, lectureRepo: lectureRepo}
 }
 
 func (s *classService) CreateClass(class *domain.Class) (*domain.Class, error) {
@@ -22,8 +23,8 @@ func (s *classService) CreateClass(class *domain.Class) (*domain.Class, error) {
 	return class, nil
 }
 
-func (s *classService) GetClasses() ([]domain.Class, error) {
-	return s.repo.FindAll()
+func (s *classService) GetClasses(page domain.Page) ([]domain.Class, error) {
+	return s.repo.FindAll(page)
 }
 
 func (s *classService) GetClassByID(id uint) (*domain.Class, error) {
@@ -47,3 +48,10 @@ func (s *classService) UpdateClass(id uint, class *domain.Class) (*domain.Class,
 func (s *classService) DeleteClass(id uint) error {
 	return s.repo.Delete(id)
 }
+
+func (s *classService) GetClassLectures(id uint, page domain.Page) ([]domain.Lecture, error) {
+	if _, err := s.GetClassByID(id); err != nil {
+		return nil, err
+	}
+	return s.lectureRepo.FindByClass(id, page)
+}
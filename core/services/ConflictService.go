@@ -0,0 +1,91 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"sarc/core/domain"
+	interfaces "sarc/core/services/interfaces"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type conflictService struct {
+	lectureRepo    repositories.LectureRepository
+	enrollmentRepo repositories.EnrollmentRepository
+	loc            *time.Location
+	now            func() time.Time
+}
+
+func NewConflictService(lectureRepo repositories.LectureRepository, enrollmentRepo repositories.EnrollmentRepository, loc *time.Location) interfaces.ConflictService {
+	return &conflictService{lectureRepo: lectureRepo, enrollmentRepo: enrollmentRepo, loc: loc, now: time.Now}
+}
+
+func (s *conflictService) GetConflicts(from, to string) ([]domain.ScheduleConflict, error) {
+	first, last, err := dateRange(from, to, s.now().In(s.loc), termDays)
+	if err != nil {
+		return nil, err
+	}
+	lectures, err := s.lectureRepo.FindBetween(first, last)
+	if err != nil {
+		return nil, err
+	}
+	byClass := make(map[uint][]domain.Lecture)
+	var classIDs []uint
+	for _, l := range lectures {
+		if l.StartTime == "" {
+			continue
+		}
+		if _, ok := byClass[l.ClassID]; !ok {
+			classIDs = append(classIDs, l.ClassID)
+		}
+		byClass[l.ClassID] = append(byClass[l.ClassID], l)
+	}
+	enrollments, err := s.enrollmentRepo.FindByClasses(classIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every user's timed lectures, through the classes they are enrolled in
+	schedules := make(map[uint][]scheduledLecture)
+	users := make(map[uint]*domain.User)
+	for _, e := range enrollments {
+		if e.Status == domain.EnrollmentDropped {
+			continue
+		}
+		users[e.UserID] = e.User
+		for _, l := range byClass[e.ClassID] {
+			sl, _, err := schedule(l, e.Role)
+			if err != nil {
+				return nil, err
+			}
+			schedules[e.UserID] = append(schedules[e.UserID], sl)
+		}
+	}
+
+	conflicts := []domain.ScheduleConflict{}
+	starts := make(map[uint]time.Time)
+	for userID, lectures := range schedules {
+		sort.Slice(lectures, func(i, j int) bool { return lectures[i].start.Before(lectures[j].start) })
+		for i := range lectures {
+			for j := i + 1; j < len(lectures) && lectures[j].start.Before(lectures[i].end); j++ {
+				if lectures[i].overlaps(lectures[j]) {
+					c := scheduleConflict(userID, lectures[i], lectures[j])
+					c.User = users[userID]
+					conflicts = append(conflicts, c)
+					starts[c.Lecture.LectureID] = lectures[i].start
+				}
+			}
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		a, b := starts[conflicts[i].Lecture.LectureID], starts[conflicts[j].Lecture.LectureID]
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		if conflicts[i].UserID != conflicts[j].UserID {
+			return conflicts[i].UserID < conflicts[j].UserID
+		}
+		return conflicts[i].Other.LectureID < conflicts[j].Other.LectureID
+	})
+	return conflicts, nil
+}
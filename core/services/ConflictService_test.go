@@ -0,0 +1,78 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"sarc/core/domain"
+	"sarc/core/services"
+	memimpl "sarc/infrastructure/repositories/MEMimpl"
+)
+
+func TestScheduleConflicts(t *testing.T) {
+	repos := memimpl.NewRepositories(memimpl.NewStore())
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	teaching := domain.Profile{Role: "teacher", CanTeach: true}
+	must(repos.Profile.Create(&teaching))
+	teacher := domain.User{Email: "ana@example.com", Nome: "Ana", ProfileID: teaching.ID}
+	must(repos.User.Create(&teacher))
+	student := domain.User{Email: "bia@example.com", Nome: "Bia", ProfileID: teaching.ID}
+	must(repos.User.Create(&student))
+	building := domain.Building{BuildingName: "Prédio 32"}
+	must(repos.Building.Create(&building))
+	room := domain.Room{RoomNumber: "101", BuildingID: building.BuildingID}
+	must(repos.Room.Create(&room))
+	discipline := domain.Discipline{Name: "Cálculo I", Credits: 4}
+	must(repos.Discipline.Create(&discipline))
+	morning := domain.Class{Name: "Turma 10", DisciplineID: discipline.ID}
+	must(repos.Class.Create(&morning))
+	other := domain.Class{Name: "Turma 20", DisciplineID: discipline.ID}
+	must(repos.Class.Create(&other))
+
+	strict := services.NewEnrollmentService(repos.Enrollment, repos.Class, repos.User, repos.Profile, repos.Lecture, domain.ConflictPolicy{})
+	_, _, err := strict.Enroll(morning.ClassID, teacher.ID, domain.EnrollmentRoleTeacher)
+	must(err)
+	_, _, err = strict.Enroll(other.ClassID, teacher.ID, domain.EnrollmentRoleTeacher)
+	must(err)
+	_, _, err = strict.Enroll(morning.ClassID, student.ID, "")
+	must(err)
+
+	lectures := services.NewLectureService(repos.Lecture, repos.Reservation, repos.Room, repos.Enrollment, domain.CapacityPolicy{}, domain.ConflictPolicy{}, time.UTC)
+	first, err := lectures.CreateLecture(&domain.Lecture{ClassID: morning.ClassID, RoomID: room.RoomID, Date: "2030-03-04", StartTime: "08:00", EndTime: "09:40"})
+	must(err)
+	clash := &domain.Lecture{ClassID: other.ClassID, RoomID: room.RoomID, Date: "2030-03-04", StartTime: "09:00", EndTime: "10:40"}
+	if _, err := lectures.CreateLecture(clash); !errors.Is(err, domain.ErrScheduleConflict) {
+		t.Fatalf("teacher in two lectures: got %v, want %v", err, domain.ErrScheduleConflict)
+	}
+	later, err := lectures.CreateLecture(&domain.Lecture{ClassID: other.ClassID, RoomID: room.RoomID, Date: "2030-03-04", StartTime: "09:40", EndTime: "11:20"})
+	must(err)
+	moved := *later
+	moved.StartTime = "09:00"
+	if _, err := lectures.UpdateLecture(later.LectureID, &moved); !errors.Is(err, domain.ErrScheduleConflict) {
+		t.Errorf("moving into a teacher's other lecture: got %v, want %v", err, domain.ErrScheduleConflict)
+	}
+
+	soft := services.NewLectureService(repos.Lecture, repos.Reservation, repos.Room, repos.Enrollment, domain.CapacityPolicy{}, domain.ConflictPolicy{SoftLimit: true}, time.UTC)
+	flagged, err := soft.UpdateLecture(later.LectureID, &moved)
+	must(err)
+	if len(flagged.Warnings) != 1 {
+		t.Errorf("soft limit: expected a warning, got %v", flagged.Warnings)
+	}
+
+	// The student's morning lecture now overlaps the other class
+	if _, _, err := strict.Enroll(other.ClassID, student.ID, ""); !errors.Is(err, domain.ErrScheduleConflict) {
+		t.Errorf("enrolling into an overlapping class: got %v, want %v", err, domain.ErrScheduleConflict)
+	}
+
+	report, err := services.NewConflictService(repos.Lecture, repos.Enrollment, time.UTC).GetConflicts("2030-03-01", "2030-03-31")
+	must(err)
+	if len(report) != 1 || report[0].UserID != teacher.ID || report[0].Lecture.LectureID != first.LectureID || report[0].Other.LectureID != later.LectureID {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"sarc/core/domain"
+)
+
+// curriculumCredits sums the credits of disciplines by semester and lists
+// what keeps the curriculum from being published.
+func curriculumCredits(disciplines []domain.Discipline, requisites []domain.Requisite, limits domain.CreditLimits) *domain.CurriculumCredits {
+	credits := sumCredits(disciplines)
+	credits.Issues = []string{}
+	issue := func(format string, args ...any) {
+		credits.Issues = append(credits.Issues, fmt.Sprintf(format, args...))
+	}
+
+	for _, d := range disciplines {
+		if d.Semester == 0 {
+			issue("%s is not placed in a semester", d.Name)
+		}
+	}
+	for _, sc := range credits.Semesters {
+		if limits.MinSemester > 0 && sc.Mandatory < limits.MinSemester {
+			issue("semester %d has %d mandatory credits, under the minimum of %d", sc.Semester, sc.Mandatory, limits.MinSemester)
+		}
+		if limits.MaxSemester > 0 && sc.Mandatory > limits.MaxSemester {
+			issue("semester %d has %d mandatory credits, over the maximum of %d", sc.Semester, sc.Mandatory, limits.MaxSemester)
+		}
+	}
+	if limits.MinTotal > 0 && credits.Total < limits.MinTotal {
+		issue("the curriculum has %d credits, under the minimum of %d", credits.Total, limits.MinTotal)
+	}
+	if limits.MaxTotal > 0 && credits.Total > limits.MaxTotal {
+		issue("the curriculum has %d credits, over the maximum of %d", credits.Total, limits.MaxTotal)
+	}
+
+	byID := make(map[uint]domain.Discipline, len(disciplines))
+	for _, d := range disciplines {
+		byID[d.ID] = d
+	}
+	for _, q := range requisites {
+		d, r := byID[q.DisciplineID], byID[q.RequisiteID]
+		if d.Semester == 0 || r.Semester == 0 {
+			continue
+		}
+		switch {
+		case q.Kind == domain.RequisitePrerequisite && r.Semester >= d.Semester:
+			issue("%s (semester %d) requires %s (semester %d) to be passed in an earlier semester", d.Name, d.Semester, r.Name, r.Semester)
+		case q.Kind == domain.RequisiteCorequisite && r.Semester > d.Semester:
+			issue("%s (semester %d) requires %s (semester %d) by the same semester", d.Name, d.Semester, r.Name, r.Semester)
+		}
+	}
+	return credits
+}
+
+// sumCredits adds up credits per semester, in semester order. Disciplines
+// not placed yet only count towards the totals.
+func sumCredits(disciplines []domain.Discipline) *domain.CurriculumCredits {
+	credits := &domain.CurriculumCredits{Semesters: []domain.SemesterCredits{}}
+	semesters := make(map[int]*domain.SemesterCredits)
+	for _, d := range disciplines {
+		sc := semesters[d.Semester]
+		if sc == nil {
+			sc = &domain.SemesterCredits{Semester: d.Semester}
+			semesters[d.Semester] = sc
+		}
+		if d.Elective {
+			sc.Elective += d.Credits
+			credits.Elective += d.Credits
+		} else {
+			sc.Mandatory += d.Credits
+			credits.Mandatory += d.Credits
+		}
+	}
+	credits.Total = credits.Mandatory + credits.Elective
+	for semester, sc := range semesters {
+		if semester > 0 {
+			credits.Semesters = append(credits.Semesters, *sc)
+		}
+	}
+	sort.Slice(credits.Semesters, func(i, j int) bool { return credits.Semesters[i].Semester < credits.Semesters[j].Semester })
+	return credits
+}
+
+// checkCreditLimits rejects a layout in which changed takes its semester
+// over the maximum mandatory credits or, when it is being added, the
+// curriculum over its maximum total. Minimums are only reported, since a
+// curriculum falls short of them until it is complete.
+func checkCreditLimits(layout []domain.Discipline, changed domain.Discipline, added bool, limits domain.CreditLimits) error {
+	credits := sumCredits(layout)
+	if limits.MaxSemester > 0 && changed.Semester > 0 && !changed.Elective {
+		for _, sc := range credits.Semesters {
+			if sc.Semester == changed.Semester && sc.Mandatory > limits.MaxSemester {
+				return fmt.Errorf("%w: semester %d would have %d mandatory credits, over the maximum of %d", domain.ErrCreditLimit, sc.Semester, sc.Mandatory, limits.MaxSemester)
+			}
+		}
+	}
+	if added && limits.MaxTotal > 0 && credits.Total > limits.MaxTotal {
+		return fmt.Errorf("%w: the curriculum would have %d credits, over the maximum of %d", domain.ErrCreditLimit, credits.Total, limits.MaxTotal)
+	}
+	return nil
+}
@@ -1,60 +1,497 @@
 package services
 
 import (
+	"cmp"
+	"database/sql"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
 	"sarc/core/domain"
 	interfaces "sarc/core/services/interfaces"
 	repositories "sarc/infrastructure/repositories/interfaces"
 )
 
 type curriculumService struct {
-	repo repositories.CurriculumRepository
+	repo           repositories.CurriculumRepository
+	disciplineRepo repositories.DisciplineRepository
+	tx             repositories.Transactor
+	limits         domain.CreditLimits
 }
 
-func NewCurriculumService(repo repositories.CurriculumRepository) interfaces.CurriculumService {
-	return &curriculumService{repo: repo}
+func NewCurriculumService(repo repositories.CurriculumRepository, disciplineRepo repositories.DisciplineRepository, tx repositories.Transactor, limits domain.CreditLimits) interfaces.CurriculumService {
+	return &curriculumService{repo: repo, disciplineRepo: disciplineRepo, tx: tx, limits: limits}
 }
 
 func (s *curriculumService) CreateCurriculum(curriculum *domain.Curriculum) (*domain.Curriculum, error) {
-	// 1. Create the curriculum itself
-	if err := s.repo.Create(curriculum); err != nil {
-		return nil, err
-	}
-	// 2. Add disciplines to curriculum_disciplines (many-to-many)
-	for _, discipline := range curriculum.Disciplines {
-		if err := s.repo.AddDisciplineToCurriculum(curriculum.ID, discipline.ID); err != nil {
-			return nil, err
+	err := s.tx.WithinTransaction(func(repos repositories.Repositories) error {
+		// 1. Create the curriculum itself, as the first version of its lineage
+		curriculum.LineageID, curriculum.Version = 0, 1
+		if err := repos.Curriculum.Create(curriculum); err != nil {
+			return err
 		}
+		// 2. Add disciplines to curriculum_disciplines (many-to-many)
+		return s.setDisciplines(repos, curriculum.ID, curriculum.Disciplines)
+	})
+	if err != nil {
+		return nil, err
 	}
-	return curriculum, nil
+	return s.GetCurriculumByID(curriculum.ID)
 }
 
-func (s *curriculumService) GetCurriculums() ([]domain.Curriculum, error) {
-	return s.repo.FindAll()
+func (s *curriculumService) GetCurriculums(page domain.Page) ([]domain.Curriculum, error) {
+	return s.repo.FindAll(page)
 }
 
+// GetCurriculumByID returns the curriculum with its credits summed by
+// semester and checked against the limits.
 func (s *curriculumService) GetCurriculumByID(id uint) (*domain.Curriculum, error) {
-	curriculum, err := s.repo.FindByID(id)
+	curriculum, err := s.find(id)
 	if err != nil {
 		return nil, err
 	}
-	if curriculum == nil {
-		return nil, errors.New("curriculum not found")
+	requisites, err := s.repo.FindRequisites(id)
+	if err != nil {
+		return nil, err
+	}
+	curriculum.Credits = curriculumCredits(curriculum.Disciplines, requisites, s.limits)
+	return curriculum, nil
+}
+
+func (s *curriculumService) find(id uint) (*domain.Curriculum, error) {
+	return findCurriculum(s.repo, id)
+}
+
+func findCurriculum(repo repositories.CurriculumRepository, id uint) (*domain.Curriculum, error) {
+	curriculum, err := repo.FindByID(id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && curriculum == nil) {
+		return nil, domain.ErrCurriculumNotFound
+	}
+	if err != nil {
+		return nil, err
 	}
 	return curriculum, nil
 }
 
+// UpdateCurriculum changes the name and dates of a curriculum and, when
+// updated lists disciplines, replaces them as SetDisciplines does.
 func (s *curriculumService) UpdateCurriculum(id uint, updated *domain.Curriculum) (*domain.Curriculum, error) {
-	if err := s.repo.Update(id, updated); err != nil {
+	err := s.tx.WithinTransaction(func(repos repositories.Repositories) error {
+		if _, err := findCurriculum(repos.Curriculum, id); err != nil {
+			return err
+		}
+		if err := repos.Curriculum.Update(id, updated); err != nil {
+			return err
+		}
+		if updated.Disciplines == nil {
+			return nil
+		}
+		return s.setDisciplines(repos, id, updated.Disciplines)
+	})
+	if err != nil {
 		return nil, err
 	}
-	return s.repo.FindByID(id)
+	return s.GetCurriculumByID(id)
 }
 
 func (s *curriculumService) DeleteCurriculum(id uint) error {
 	return s.repo.Delete(id)
 }
 
-func (s *curriculumService) AddDisciplineToCurriculum(curriculumID uint, disciplineID uint) error {
-	return s.repo.AddDisciplineToCurriculum(curriculumID, disciplineID)
+// AddDisciplineToCurriculum places discipline.ID in discipline.Semester of
+// the curriculum, rejecting it when a maximum credit limit would be exceeded.
+// Adding a discipline already in the curriculum changes nothing.
+func (s *curriculumService) AddDisciplineToCurriculum(curriculumID uint, discipline domain.Discipline) error {
+	if discipline.Semester < 0 {
+		return fmt.Errorf("%w: semester cannot be negative", domain.ErrInvalidPlacement)
+	}
+	curriculum, err := s.find(curriculumID)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(curriculum.Disciplines, func(d domain.Discipline) bool { return d.ID == discipline.ID }) {
+		return nil
+	}
+	d, err := s.disciplineRepo.FindByID(discipline.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %d", domain.ErrDisciplineNotFound, discipline.ID)
+	}
+	if err != nil {
+		return err
+	}
+	d.Semester, d.Elective = discipline.Semester, discipline.Elective
+	if err := checkCreditLimits(append(curriculum.Disciplines, *d), *d, true, s.limits); err != nil {
+		return err
+	}
+	return s.repo.AddDisciplineToCurriculum(curriculumID, *d)
+}
+
+// RemoveDisciplineFromCurriculum takes a discipline out of the curriculum,
+// along with the requisites to and from it.
+func (s *curriculumService) RemoveDisciplineFromCurriculum(curriculumID uint, disciplineID uint) error {
+	return s.tx.WithinTransaction(func(repos repositories.Repositories) error {
+		curriculum, err := findCurriculum(repos.Curriculum, curriculumID)
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(curriculum.Disciplines, func(d domain.Discipline) bool { return d.ID == disciplineID }) {
+			return fmt.Errorf("%w: discipline %d", domain.ErrNotInCurriculum, disciplineID)
+		}
+		return repos.Curriculum.RemoveDisciplineFromCurriculum(curriculumID, disciplineID)
+	})
+}
+
+// SetDisciplines makes disciplines the exact list of the curriculum's
+// disciplines, with their placements, and returns the updated curriculum.
+func (s *curriculumService) SetDisciplines(curriculumID uint, disciplines []domain.Discipline) (*domain.Curriculum, error) {
+	err := s.tx.WithinTransaction(func(repos repositories.Repositories) error {
+		return s.setDisciplines(repos, curriculumID, disciplines)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetCurriculumByID(curriculumID)
+}
+
+// setDisciplines removes the disciplines of the curriculum missing from
+// disciplines, then adds or moves the others. Only added and moved ones are
+// checked against the maximum credits.
+func (s *curriculumService) setDisciplines(repos repositories.Repositories, curriculumID uint, disciplines []domain.Discipline) error {
+	listed := make(map[uint]bool, len(disciplines))
+	for _, d := range disciplines {
+		if d.Semester < 0 {
+			return fmt.Errorf("%w: semester cannot be negative", domain.ErrInvalidPlacement)
+		}
+		if listed[d.ID] {
+			return fmt.Errorf("%w: discipline %d is listed twice", domain.ErrInvalidPlacement, d.ID)
+		}
+		listed[d.ID] = true
+	}
+	curriculum, err := findCurriculum(repos.Curriculum, curriculumID)
+	if err != nil {
+		return err
+	}
+
+	current := make(map[uint]domain.Discipline, len(curriculum.Disciplines))
+	for _, d := range curriculum.Disciplines {
+		current[d.ID] = d
+		if !listed[d.ID] {
+			if err := repos.Curriculum.RemoveDisciplineFromCurriculum(curriculumID, d.ID); err != nil {
+				return err
+			}
+		}
+	}
+	layout := make([]domain.Discipline, 0, len(disciplines))
+	added := make(map[uint]bool)
+	var changed []domain.Discipline
+	for _, d := range disciplines {
+		next, ok := current[d.ID]
+		if !ok {
+			found, err := repos.Discipline.FindByID(d.ID)
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: %d", domain.ErrDisciplineNotFound, d.ID)
+			}
+			if err != nil {
+				return err
+			}
+			next = *found
+			added[d.ID] = true
+		}
+		if added[d.ID] || next.Semester != d.Semester || next.Elective != d.Elective {
+			next.Semester, next.Elective = d.Semester, d.Elective
+			changed = append(changed, next)
+		}
+		layout = append(layout, next)
+	}
+
+	for _, d := range changed {
+		if err := checkCreditLimits(layout, d, added[d.ID], s.limits); err != nil {
+			return err
+		}
+	}
+	for _, d := range changed {
+		if added[d.ID] {
+			err = repos.Curriculum.AddDisciplineToCurriculum(curriculumID, d)
+		} else {
+			err = repos.Curriculum.PlaceDiscipline(curriculumID, d)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PlaceDiscipline moves a discipline of the curriculum to another semester or
+// changes whether it is elective, and returns the updated curriculum.
+func (s *curriculumService) PlaceDiscipline(curriculumID uint, discipline domain.Discipline) (*domain.Curriculum, error) {
+	if discipline.Semester < 0 {
+		return nil, fmt.Errorf("%w: semester cannot be negative", domain.ErrInvalidPlacement)
+	}
+	curriculum, err := s.find(curriculumID)
+	if err != nil {
+		return nil, err
+	}
+	layout := slices.Clone(curriculum.Disciplines)
+	i := slices.IndexFunc(layout, func(d domain.Discipline) bool { return d.ID == discipline.ID })
+	if i < 0 {
+		return nil, fmt.Errorf("%w: discipline %d", domain.ErrNotInCurriculum, discipline.ID)
+	}
+	layout[i].Semester, layout[i].Elective = discipline.Semester, discipline.Elective
+	if err := checkCreditLimits(layout, layout[i], false, s.limits); err != nil {
+		return nil, err
+	}
+	if err := s.repo.PlaceDiscipline(curriculumID, layout[i]); err != nil {
+		return nil, err
+	}
+	return s.GetCurriculumByID(curriculumID)
+}
+
+func (s *curriculumService) GetRequisites(curriculumID uint) ([]domain.Requisite, error) {
+	if _, err := s.find(curriculumID); err != nil {
+		return nil, err
+	}
+	requisites, err := s.repo.FindRequisites(curriculumID)
+	if err != nil {
+		return nil, err
+	}
+	if requisites == nil {
+		requisites = []domain.Requisite{}
+	}
+	return requisites, nil
+}
+
+// AddRequisite relates two disciplines of a curriculum. Requisites default to
+// prerequisites, and are rejected when they would close a cycle that contains
+// a prerequisite.
+func (s *curriculumService) AddRequisite(requisite *domain.Requisite) (*domain.Requisite, error) {
+	if requisite.Kind == "" {
+		requisite.Kind = domain.RequisitePrerequisite
+	}
+	switch {
+	case requisite.Kind != domain.RequisitePrerequisite && requisite.Kind != domain.RequisiteCorequisite:
+		return nil, fmt.Errorf("%w: unknown kind %q", domain.ErrInvalidRequisite, requisite.Kind)
+	case requisite.DisciplineID == 0 || requisite.RequisiteID == 0:
+		return nil, fmt.Errorf("%w: discipline and requisite are required", domain.ErrInvalidRequisite)
+	case requisite.DisciplineID == requisite.RequisiteID:
+		return nil, fmt.Errorf("%w: a discipline cannot require itself", domain.ErrInvalidRequisite)
+	}
+
+	curriculum, err := s.find(requisite.CurriculumID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range []uint{requisite.DisciplineID, requisite.RequisiteID} {
+		if !slices.ContainsFunc(curriculum.Disciplines, func(d domain.Discipline) bool { return d.ID == id }) {
+			return nil, fmt.Errorf("%w: discipline %d", domain.ErrNotInCurriculum, id)
+		}
+	}
+
+	requisites, err := s.repo.FindRequisites(requisite.CurriculumID)
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range requisites {
+		if q.DisciplineID == requisite.DisciplineID && q.RequisiteID == requisite.RequisiteID {
+			return nil, fmt.Errorf("%w: discipline %d already requires %d as a %s", domain.ErrRequisiteExists, q.DisciplineID, q.RequisiteID, q.Kind)
+		}
+	}
+	if closesCycle(requisites, *requisite) {
+		return nil, fmt.Errorf("%w: discipline %d already depends on %d", domain.ErrRequisiteCycle, requisite.RequisiteID, requisite.DisciplineID)
+	}
+
+	if err := s.repo.AddRequisite(requisite); err != nil {
+		return nil, err
+	}
+	return requisite, nil
+}
+
+func (s *curriculumService) RemoveRequisite(curriculumID uint, disciplineID uint, requisiteID uint) error {
+	requisites, err := s.GetRequisites(curriculumID)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(requisites, func(q domain.Requisite) bool {
+		return q.DisciplineID == disciplineID && q.RequisiteID == requisiteID
+	}) {
+		return domain.ErrRequisiteNotFound
+	}
+	return s.repo.RemoveRequisite(curriculumID, disciplineID, requisiteID)
+}
+
+func (s *curriculumService) GetGraph(curriculumID uint) (*domain.CurriculumGraph, error) {
+	curriculum, err := s.find(curriculumID)
+	if err != nil {
+		return nil, err
+	}
+	requisites, err := s.GetRequisites(curriculumID)
+	if err != nil {
+		return nil, err
+	}
+	graph := &domain.CurriculumGraph{CurriculumID: curriculumID, Nodes: curriculum.Disciplines, Edges: requisites}
+	if graph.Nodes == nil {
+		graph.Nodes = []domain.Discipline{}
+	}
+	return graph, nil
+}
+
+// CloneCurriculum creates the next version of a curriculum's lineage, with
+// the disciplines, placements and requisites of the given version. Non-empty
+// fields of overrides replace the copied name and dates.
+func (s *curriculumService) CloneCurriculum(id uint, overrides domain.Curriculum) (*domain.Curriculum, error) {
+	var clone domain.Curriculum
+	err := s.tx.WithinTransaction(func(repos repositories.Repositories) error {
+		// Lock before reading the versions so concurrent clones of the
+		// lineage cannot both pick the same next version.
+		if err := repos.Curriculum.LockLineage(id); err != nil {
+			return err
+		}
+		source, err := findCurriculum(repos.Curriculum, id)
+		if err != nil {
+			return err
+		}
+		versions, err := repos.Curriculum.FindByLineage(source.LineageID)
+		if err != nil {
+			return err
+		}
+		version := 1
+		for _, v := range versions {
+			version = max(version, v.Version+1)
+		}
+
+		clone = domain.Curriculum{
+			CourseName: cmp.Or(overrides.CourseName, source.CourseName),
+			DataInicio: cmp.Or(overrides.DataInicio, source.DataInicio),
+			DataFim:    cmp.Or(overrides.DataFim, source.DataFim),
+			LineageID:  source.LineageID,
+			Version:    version,
+		}
+		if err := repos.Curriculum.Create(&clone); err != nil {
+			return err
+		}
+		for _, d := range source.Disciplines {
+			if err := repos.Curriculum.AddDisciplineToCurriculum(clone.ID, d); err != nil {
+				return err
+			}
+		}
+		requisites, err := repos.Curriculum.FindRequisites(id)
+		if err != nil {
+			return err
+		}
+		for _, q := range requisites {
+			q.CurriculumID = clone.ID
+			if err := repos.Curriculum.AddRequisite(&q); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetCurriculumByID(clone.ID)
+}
+
+// GetVersions returns every version in the lineage of a curriculum.
+func (s *curriculumService) GetVersions(id uint) ([]domain.Curriculum, error) {
+	curriculum, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindByLineage(curriculum.LineageID)
+}
+
+// DiffCurriculums reports the disciplines added, removed and changed from one
+// curriculum to another. Disciplines are matched by ID, then by name, so a
+// discipline replaced by a revised one counts as changed.
+func (s *curriculumService) DiffCurriculums(fromID uint, toID uint) (*domain.CurriculumDiff, error) {
+	from, err := s.find(fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.find(toID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &domain.CurriculumDiff{From: fromID, To: toID, Added: []domain.Discipline{}, Removed: []domain.Discipline{}, Changed: []domain.DisciplineChange{}}
+	compare := func(before, after domain.Discipline) {
+		var changes []string
+		if before.Credits != after.Credits {
+			changes = append(changes, "credits")
+		}
+		if before.Semester != after.Semester {
+			changes = append(changes, "semester")
+		}
+		if before.Elective != after.Elective {
+			changes = append(changes, "elective")
+		}
+		if len(changes) > 0 {
+			diff.Changed = append(diff.Changed, domain.DisciplineChange{Before: before, After: after, Changes: changes})
+		}
+	}
+
+	matched := make(map[uint]bool)
+	var unmatched []domain.Discipline
+	for _, before := range from.Disciplines {
+		i := slices.IndexFunc(to.Disciplines, func(d domain.Discipline) bool { return d.ID == before.ID })
+		if i < 0 {
+			unmatched = append(unmatched, before)
+			continue
+		}
+		matched[before.ID] = true
+		compare(before, to.Disciplines[i])
+	}
+	for _, before := range unmatched {
+		i := slices.IndexFunc(to.Disciplines, func(d domain.Discipline) bool {
+			return !matched[d.ID] && !slices.ContainsFunc(from.Disciplines, func(f domain.Discipline) bool { return f.ID == d.ID }) &&
+				strings.EqualFold(strings.TrimSpace(d.Name), strings.TrimSpace(before.Name))
+		})
+		if i < 0 {
+			diff.Removed = append(diff.Removed, before)
+			continue
+		}
+		matched[to.Disciplines[i].ID] = true
+		compare(before, to.Disciplines[i])
+	}
+	for _, after := range to.Disciplines {
+		if !matched[after.ID] {
+			diff.Added = append(diff.Added, after)
+		}
+	}
+	return diff, nil
+}
+
+// closesCycle reports whether adding r to the acyclic requisites would make
+// r's discipline depend on itself through at least one prerequisite. It walks
+// from r's requisite along existing edges, remembering whether a prerequisite
+// was crossed on the way.
+func closesCycle(requisites []domain.Requisite, r domain.Requisite) bool {
+	edges := make(map[uint][]domain.Requisite)
+	for _, q := range requisites {
+		edges[q.DisciplineID] = append(edges[q.DisciplineID], q)
+	}
+	type step struct {
+		disciplineID uint
+		strict       bool
+	}
+	start := step{r.RequisiteID, r.Kind == domain.RequisitePrerequisite}
+	seen := map[step]bool{start: true}
+	pending := []step{start}
+	for len(pending) > 0 {
+		at := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if at.disciplineID == r.DisciplineID && at.strict {
+			return true
+		}
+		for _, q := range edges[at.disciplineID] {
+			next := step{q.RequisiteID, at.strict || q.Kind == domain.RequisitePrerequisite}
+			if !seen[next] {
+				seen[next] = true
+				pending = append(pending, next)
+			}
+		}
+	}
+	return false
 }
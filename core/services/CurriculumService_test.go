@@ -0,0 +1,325 @@
+package services_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"sarc/core/domain"
+	"sarc/core/services"
+	memimpl "sarc/infrastructure/repositories/MEMimpl"
+)
+
+func TestCreateCurriculumLinksDisciplines(t *testing.T) {
+	store := memimpl.NewStore()
+	repos := memimpl.NewRepositories(store)
+	calculus := domain.Discipline{Name: "Cálculo I", Credits: 4}
+	algebra := domain.Discipline{Name: "Álgebra", Credits: 4}
+	if err := repos.Discipline.Create(&calculus); err != nil {
+		t.Fatal(err)
+	}
+	if err := repos.Discipline.Create(&algebra); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := services.NewCurriculumService(repos.Curriculum, repos.Discipline, memimpl.NewTransactor(store), domain.CreditLimits{})
+	created, err := svc.CreateCurriculum(&domain.Curriculum{
+		CourseName:  "Engenharia",
+		DataInicio:  "2025-01-01",
+		DataFim:     "2029-12-31",
+		Disciplines: []domain.Discipline{{ID: calculus.ID}, {ID: algebra.ID}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := svc.GetCurriculumByID(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Disciplines) != 2 || got.Disciplines[0].Name != "Cálculo I" || got.Disciplines[1].Name != "Álgebra" {
+		t.Errorf("unexpected disciplines: %+v", got.Disciplines)
+	}
+}
+
+func TestCreateCurriculumFailsOnUnknownDiscipline(t *testing.T) {
+	store := memimpl.NewStore()
+	repos := memimpl.NewRepositories(store)
+	svc := services.NewCurriculumService(repos.Curriculum, repos.Discipline, memimpl.NewTransactor(store), domain.CreditLimits{})
+
+	_, err := svc.CreateCurriculum(&domain.Curriculum{
+		CourseName:  "Engenharia",
+		Disciplines: []domain.Discipline{{ID: 42}},
+	})
+	if err == nil {
+		t.Fatal("expected an error for an unknown discipline")
+	}
+}
+
+func TestCurriculumRequisites(t *testing.T) {
+	store := memimpl.NewStore()
+	repos := memimpl.NewRepositories(store)
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	var disciplines []domain.Discipline
+	for _, name := range []string{"Cálculo I", "Cálculo II", "Física I", "Física Experimental", "Química"} {
+		d := domain.Discipline{Name: name, Credits: 4}
+		must(repos.Discipline.Create(&d))
+		disciplines = append(disciplines, d)
+	}
+	calc1, calc2, phys, lab, chem := disciplines[0].ID, disciplines[1].ID, disciplines[2].ID, disciplines[3].ID, disciplines[4].ID
+
+	svc := services.NewCurriculumService(repos.Curriculum, repos.Discipline, memimpl.NewTransactor(store), domain.CreditLimits{})
+	curriculum, err := svc.CreateCurriculum(&domain.Curriculum{CourseName: "Engenharia", Disciplines: disciplines[:4]})
+	must(err)
+	add := func(discipline, requisite uint, kind domain.RequisiteKind) error {
+		_, err := svc.AddRequisite(&domain.Requisite{CurriculumID: curriculum.ID, DisciplineID: discipline, RequisiteID: requisite, Kind: kind})
+		return err
+	}
+	must(add(calc2, calc1, ""))
+	must(add(phys, calc2, domain.RequisitePrerequisite))
+	// Co-requisites may require each other: they are taken together.
+	must(add(phys, lab, domain.RequisiteCorequisite))
+	must(add(lab, phys, domain.RequisiteCorequisite))
+
+	for _, tc := range []struct {
+		name                  string
+		discipline, requisite uint
+		kind                  domain.RequisiteKind
+		want                  error
+	}{
+		{"prerequisite cycle", calc1, phys, domain.RequisitePrerequisite, domain.ErrRequisiteCycle},
+		{"co-requisite closing a prerequisite cycle", calc1, phys, domain.RequisiteCorequisite, domain.ErrRequisiteCycle},
+		{"prerequisite of a co-requisite pair", lab, calc1, domain.RequisitePrerequisite, nil},
+		{"cycle through co-requisites", calc2, lab, domain.RequisiteCorequisite, domain.ErrRequisiteCycle},
+		{"outside the curriculum", calc2, chem, domain.RequisitePrerequisite, domain.ErrNotInCurriculum},
+		{"itself", calc1, calc1, domain.RequisitePrerequisite, domain.ErrInvalidRequisite},
+		{"unknown kind", calc1, lab, "optional", domain.ErrInvalidRequisite},
+		{"duplicate", calc2, calc1, domain.RequisiteCorequisite, domain.ErrRequisiteExists},
+	} {
+		if err := add(tc.discipline, tc.requisite, tc.kind); !errors.Is(err, tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, err, tc.want)
+		}
+	}
+
+	must(svc.RemoveRequisite(curriculum.ID, phys, calc2))
+	if err := svc.RemoveRequisite(curriculum.ID, phys, calc2); !errors.Is(err, domain.ErrRequisiteNotFound) {
+		t.Errorf("removing twice: got %v, want %v", err, domain.ErrRequisiteNotFound)
+	}
+	// Once Física I no longer requires Cálculo II, they may be taken together.
+	must(add(calc2, phys, domain.RequisiteCorequisite))
+
+	graph, err := svc.GetGraph(curriculum.ID)
+	must(err)
+	if len(graph.Nodes) != 4 || len(graph.Edges) != 5 {
+		t.Errorf("unexpected graph: %+v", graph)
+	}
+	if _, err := svc.GetGraph(999); !errors.Is(err, domain.ErrCurriculumNotFound) {
+		t.Errorf("unknown curriculum: got %v, want %v", err, domain.ErrCurriculumNotFound)
+	}
+}
+
+func TestCurriculumCredits(t *testing.T) {
+	store := memimpl.NewStore()
+	repos := memimpl.NewRepositories(store)
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	var ids []uint
+	for _, name := range []string{"Cálculo I", "Álgebra", "Inglês", "Física I"} {
+		d := domain.Discipline{Name: name, Credits: 4}
+		must(repos.Discipline.Create(&d))
+		ids = append(ids, d.ID)
+	}
+	calculus, algebra, english, physics := ids[0], ids[1], ids[2], ids[3]
+
+	svc := services.NewCurriculumService(repos.Curriculum, repos.Discipline, memimpl.NewTransactor(store), domain.CreditLimits{MaxSemester: 8, MinTotal: 20})
+	curriculum, err := svc.CreateCurriculum(&domain.Curriculum{
+		CourseName:  "Engenharia",
+		Disciplines: []domain.Discipline{{ID: calculus, Semester: 1}, {ID: algebra, Semester: 1}},
+	})
+	must(err)
+	if err := svc.AddDisciplineToCurriculum(curriculum.ID, domain.Discipline{ID: english, Semester: 1}); !errors.Is(err, domain.ErrCreditLimit) {
+		t.Errorf("over the semester maximum: got %v, want %v", err, domain.ErrCreditLimit)
+	}
+	// Electives do not count towards the semester limit.
+	must(svc.AddDisciplineToCurriculum(curriculum.ID, domain.Discipline{ID: english, Semester: 1, Elective: true}))
+	must(svc.AddDisciplineToCurriculum(curriculum.ID, domain.Discipline{ID: physics, Semester: 2}))
+	if err := svc.AddDisciplineToCurriculum(curriculum.ID, domain.Discipline{ID: 999, Semester: 2}); !errors.Is(err, domain.ErrDisciplineNotFound) {
+		t.Errorf("unknown discipline: got %v, want %v", err, domain.ErrDisciplineNotFound)
+	}
+	_, err = svc.AddRequisite(&domain.Requisite{CurriculumID: curriculum.ID, DisciplineID: calculus, RequisiteID: physics})
+	must(err)
+
+	got, err := svc.GetCurriculumByID(curriculum.ID)
+	must(err)
+	credits := got.Credits
+	want := []domain.SemesterCredits{{Semester: 1, Mandatory: 8, Elective: 4}, {Semester: 2, Mandatory: 4}}
+	if credits == nil || credits.Total != 16 || credits.Elective != 4 || !reflect.DeepEqual(credits.Semesters, want) {
+		t.Fatalf("unexpected credits: %+v", credits)
+	}
+	// Under the minimum total, and Física I is placed after Cálculo I.
+	if len(credits.Issues) != 2 {
+		t.Errorf("expected two issues, got %q", credits.Issues)
+	}
+
+	if _, err := svc.PlaceDiscipline(curriculum.ID, domain.Discipline{ID: physics, Semester: 1}); !errors.Is(err, domain.ErrCreditLimit) {
+		t.Errorf("moving into a full semester: got %v, want %v", err, domain.ErrCreditLimit)
+	}
+	if _, err := svc.PlaceDiscipline(curriculum.ID, domain.Discipline{ID: 999, Semester: 1}); !errors.Is(err, domain.ErrNotInCurriculum) {
+		t.Errorf("placing a discipline outside the curriculum: got %v, want %v", err, domain.ErrNotInCurriculum)
+	}
+	moved, err := svc.PlaceDiscipline(curriculum.ID, domain.Discipline{ID: calculus, Semester: 3})
+	must(err)
+	if len(moved.Credits.Semesters) != 3 || len(moved.Credits.Issues) != 1 {
+		t.Errorf("unexpected credits after moving Cálculo I: %+v", moved.Credits)
+	}
+}
+
+func TestCloneAndDiffCurriculum(t *testing.T) {
+	store := memimpl.NewStore()
+	repos := memimpl.NewRepositories(store)
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	var ids []uint
+	for _, d := range []domain.Discipline{{Name: "Cálculo I", Credits: 4}, {Name: "Álgebra", Credits: 4}, {Name: "Física I", Credits: 4}, {Name: "Física I", Credits: 6}, {Name: "Inglês", Credits: 2}} {
+		must(repos.Discipline.Create(&d))
+		ids = append(ids, d.ID)
+	}
+	calculus, algebra, physics, newPhysics, english := ids[0], ids[1], ids[2], ids[3], ids[4]
+
+	svc := services.NewCurriculumService(repos.Curriculum, repos.Discipline, memimpl.NewTransactor(store), domain.CreditLimits{})
+	first, err := svc.CreateCurriculum(&domain.Curriculum{
+		CourseName:  "Engenharia",
+		DataInicio:  "2025-01-01",
+		Disciplines: []domain.Discipline{{ID: calculus, Semester: 1}, {ID: algebra, Semester: 1}, {ID: physics, Semester: 2}},
+	})
+	must(err)
+	_, err = svc.AddRequisite(&domain.Requisite{CurriculumID: first.ID, DisciplineID: physics, RequisiteID: calculus})
+	must(err)
+
+	second, err := svc.CloneCurriculum(first.ID, domain.Curriculum{DataInicio: "2030-01-01"})
+	must(err)
+	if second.LineageID != first.ID || second.Version != 2 || second.CourseName != "Engenharia" || second.DataInicio != "2030-01-01" || len(second.Disciplines) != 3 {
+		t.Fatalf("unexpected clone: %+v", second)
+	}
+	requisites, err := svc.GetRequisites(second.ID)
+	must(err)
+	if len(requisites) != 1 || requisites[0].CurriculumID != second.ID {
+		t.Errorf("requisites were not copied: %+v", requisites)
+	}
+
+	third, err := svc.CloneCurriculum(first.ID, domain.Curriculum{})
+	must(err)
+	if third.Version != 3 {
+		t.Errorf("cloning an older version: got version %d, want 3", third.Version)
+	}
+	versions, err := svc.GetVersions(third.ID)
+	must(err)
+	if len(versions) != 3 || versions[0].ID != first.ID || versions[2].ID != third.ID {
+		t.Errorf("unexpected versions: %+v", versions)
+	}
+
+	// Álgebra moves, Física I is replaced by a revised discipline and Inglês
+	// is new.
+	revised, err := svc.CreateCurriculum(&domain.Curriculum{
+		CourseName: "Engenharia",
+		Disciplines: []domain.Discipline{
+			{ID: calculus, Semester: 1}, {ID: algebra, Semester: 2}, {ID: newPhysics, Semester: 2}, {ID: english, Semester: 1, Elective: true},
+		},
+	})
+	must(err)
+	diff, err := svc.DiffCurriculums(first.ID, revised.ID)
+	must(err)
+	if len(diff.Added) != 1 || diff.Added[0].ID != english || len(diff.Removed) != 0 {
+		t.Errorf("unexpected added %+v and removed %+v", diff.Added, diff.Removed)
+	}
+	if len(diff.Changed) != 2 ||
+		diff.Changed[0].After.ID != algebra || !reflect.DeepEqual(diff.Changed[0].Changes, []string{"semester"}) ||
+		diff.Changed[1].Before.ID != physics || diff.Changed[1].After.ID != newPhysics || !reflect.DeepEqual(diff.Changed[1].Changes, []string{"credits"}) {
+		t.Errorf("unexpected changes: %+v", diff.Changed)
+	}
+}
+
+func TestSetCurriculumDisciplines(t *testing.T) {
+	store := memimpl.NewStore()
+	repos := memimpl.NewRepositories(store)
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	var ids []uint
+	for _, name := range []string{"Cálculo I", "Cálculo II", "Física I", "Química"} {
+		d := domain.Discipline{Name: name, Credits: 4}
+		must(repos.Discipline.Create(&d))
+		ids = append(ids, d.ID)
+	}
+	calc1, calc2, physics, chemistry := ids[0], ids[1], ids[2], ids[3]
+
+	svc := services.NewCurriculumService(repos.Curriculum, repos.Discipline, memimpl.NewTransactor(store), domain.CreditLimits{MaxSemester: 8})
+	curriculum, err := svc.CreateCurriculum(&domain.Curriculum{
+		CourseName:  "Engenharia",
+		Disciplines: []domain.Discipline{{ID: calc1, Semester: 1}, {ID: calc2, Semester: 2}},
+	})
+	must(err)
+	_, err = svc.AddRequisite(&domain.Requisite{CurriculumID: curriculum.ID, DisciplineID: calc2, RequisiteID: calc1})
+	must(err)
+	// Adding a discipline twice keeps its placement.
+	must(svc.AddDisciplineToCurriculum(curriculum.ID, domain.Discipline{ID: calc1, Semester: 5}))
+
+	semesters := func(c *domain.Curriculum) map[uint]int {
+		got := make(map[uint]int)
+		for _, d := range c.Disciplines {
+			got[d.ID] = d.Semester
+		}
+		return got
+	}
+	set, err := svc.SetDisciplines(curriculum.ID, []domain.Discipline{{ID: calc2, Semester: 1}, {ID: physics, Semester: 2}})
+	must(err)
+	if want := map[uint]int{calc2: 1, physics: 2}; !reflect.DeepEqual(semesters(set), want) {
+		t.Errorf("after set: got %v, want %v", semesters(set), want)
+	}
+	requisites, err := svc.GetRequisites(curriculum.ID)
+	must(err)
+	if len(requisites) != 0 {
+		t.Errorf("requisites of a removed discipline should go with it, got %+v", requisites)
+	}
+
+	// A list that breaks the limits changes nothing.
+	_, err = svc.SetDisciplines(curriculum.ID, []domain.Discipline{{ID: calc2, Semester: 1}, {ID: physics, Semester: 1}, {ID: chemistry, Semester: 1}})
+	if !errors.Is(err, domain.ErrCreditLimit) {
+		t.Errorf("over the semester maximum: got %v, want %v", err, domain.ErrCreditLimit)
+	}
+	if _, err := svc.SetDisciplines(curriculum.ID, []domain.Discipline{{ID: calc2, Semester: 1}, {ID: calc2, Semester: 2}}); !errors.Is(err, domain.ErrInvalidPlacement) {
+		t.Errorf("listing a discipline twice: got %v, want %v", err, domain.ErrInvalidPlacement)
+	}
+	got, err := svc.GetCurriculumByID(curriculum.ID)
+	must(err)
+	if want := map[uint]int{calc2: 1, physics: 2}; !reflect.DeepEqual(semesters(got), want) {
+		t.Errorf("after a rejected set: got %v, want %v", semesters(got), want)
+	}
+
+	must(svc.RemoveDisciplineFromCurriculum(curriculum.ID, physics))
+	if err := svc.RemoveDisciplineFromCurriculum(curriculum.ID, physics); !errors.Is(err, domain.ErrNotInCurriculum) {
+		t.Errorf("removing twice: got %v, want %v", err, domain.ErrNotInCurriculum)
+	}
+	// Updating without disciplines keeps them.
+	updated, err := svc.UpdateCurriculum(curriculum.ID, &domain.Curriculum{CourseName: "Engenharia Civil"})
+	must(err)
+	if updated.CourseName != "Engenharia Civil" || len(updated.Disciplines) != 1 {
+		t.Errorf("unexpected curriculum after update: %+v", updated)
+	}
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"sarc/core/domain"
+)
+
+// maxRangeDays caps the date ranges reports can be asked for.
+const maxRangeDays = 366
+
+// termDays is the range of the conflict and capacity reports when no end
+// date is given.
+const termDays = 183
+
+// dateRange validates the YYYY-MM-DD dates from and to. An empty from is
+// today's date and an empty to spans defaultDays from it.
+func dateRange(from, to string, today time.Time, defaultDays int) (string, string, error) {
+	start := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if from != "" {
+		t, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: from %q, expected YYYY-MM-DD", domain.ErrInvalidDateRange, from)
+		}
+		start = t
+	}
+	end := start.AddDate(0, 0, defaultDays-1)
+	if to != "" {
+		t, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: to %q, expected YYYY-MM-DD", domain.ErrInvalidDateRange, to)
+		}
+		end = t
+	}
+	if end.Before(start) {
+		return "", "", fmt.Errorf("%w: to %s is before from %s", domain.ErrInvalidDateRange, end.Format("2006-01-02"), start.Format("2006-01-02"))
+	}
+	if end.After(start.AddDate(0, 0, maxRangeDays)) {
+		return "", "", fmt.Errorf("%w: at most %d days", domain.ErrInvalidDateRange, maxRangeDays)
+	}
+	return start.Format("2006-01-02"), end.Format("2006-01-02"), nil
+}
+
+// lectureDay is the YYYY-MM-DD date of l, whatever the driver returned.
+func lectureDay(l domain.Lecture) string {
+	return l.Date[:min(len(l.Date), len("2006-01-02"))]
+}
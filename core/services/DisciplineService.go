@@ -8,11 +8,12 @@ import (
 )
 
 type disciplineService struct {
-	repo repositories.DisciplineRepository
+	repo      repositories.DisciplineRepository
+	classRepo repositories.ClassRepository
 }
 
-func NewDisciplineService(repo repositories.DisciplineRepository) interfaces.DisciplineService {
-	return &disciplineService{repo: repo}
+func NewDisciplineService(repo repositories.DisciplineRepository, classRepo repositories.ClassRepository) interfaces.DisciplineService {
+	return &disciplineService{repo: repo, classRepo: classRepo}
 }
 
 func (s *disciplineService) CreateDiscipline(discipline *domain.Discipline) (*domain.Discipline, error) {
@@ -22,8 +23,8 @@ func (s *disciplineService) CreateDiscipline(discipline *domain.Discipline) (*do
 	return discipline, nil
 }
 
-func (s *disciplineService) GetDisciplines() ([]domain.Discipline, error) {
-	return s.repo.FindAll()
+func (s *disciplineService) GetDisciplines(page domain.Page) ([]domain.Discipline, error) {
+	return s.repo.FindAll(page)
 }
 
 func (s *disciplineService) GetDisciplineByID(id uint) (*domain.Discipline, error) {
@@ -47,3 +48,10 @@ func (s *disciplineService) UpdateDiscipline(id uint, updated *domain.Discipline
 func (s *disciplineService) DeleteDiscipline(id uint) error {
 	return s.repo.Delete(id)
 }
+
+func (s *disciplineService) GetDisciplineClasses(id uint, page domain.Page) ([]domain.Class, error) {
+	if _, err := s.GetDisciplineByID(id); err != nil {
+		return nil, err
+	}
+	return s.classRepo.FindByDiscipline(id, page)
+}
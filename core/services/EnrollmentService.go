@@ -0,0 +1,186 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"sarc/core/domain"
+	interfaces "sarc/core/services/interfaces"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+var (
+	enrollmentRoles    = []domain.EnrollmentRole{domain.EnrollmentRoleStudent, domain.EnrollmentRoleTeacher, domain.EnrollmentRoleAssistant}
+	enrollmentStatuses = []domain.EnrollmentStatus{domain.EnrollmentActive, domain.EnrollmentDropped, domain.EnrollmentCompleted}
+)
+
+type enrollmentService struct {
+	repo        repositories.EnrollmentRepository
+	classRepo   repositories.ClassRepository
+	userRepo    repositories.UserRepository
+	profileRepo repositories.ProfileRepository
+	lectureRepo repositories.LectureRepository
+	conflicts   domain.ConflictPolicy
+	now         func() time.Time
+}
+
+func NewEnrollmentService(repo repositories.EnrollmentRepository, classRepo repositories.ClassRepository, userRepo repositories.UserRepository, profileRepo repositories.ProfileRepository, lectureRepo repositories.LectureRepository, conflicts domain.ConflictPolicy) interfaces.EnrollmentService {
+	return &enrollmentService{
+		repo:        repo,
+		classRepo:   classRepo,
+		userRepo:    userRepo,
+		profileRepo: profileRepo,
+		lectureRepo: lectureRepo,
+		conflicts:   conflicts,
+		now:         time.Now,
+	}
+}
+
+func (s *enrollmentService) Enroll(classID uint, userID uint, role domain.EnrollmentRole) (*domain.Enrollment, bool, error) {
+	if role == "" {
+		role = domain.EnrollmentRoleStudent
+	}
+	if !slices.Contains(enrollmentRoles, role) {
+		return nil, false, fmt.Errorf("%w: role must be one of %v, got %q", domain.ErrInvalidEnrollment, enrollmentRoles, role)
+	}
+	if _, err := s.classRepo.FindByID(classID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, domain.ErrClassNotFound
+		}
+		return nil, false, err
+	}
+	user, err := s.userRepo.FindByID(userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if err := checkCanTeach(s.profileRepo, user, role); err != nil {
+		return nil, false, err
+	}
+
+	current, err := s.repo.Find(classID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		warnings, err := checkEnrollmentConflicts(s.lectureRepo, s.repo, classID, userID, role, s.conflicts)
+		if err != nil {
+			return nil, false, err
+		}
+		enrollment := &domain.Enrollment{ClassID: classID, UserID: userID, Role: role, Status: domain.EnrollmentActive, EnrolledAt: s.now()}
+		if err := s.repo.Create(enrollment); err != nil {
+			return nil, false, err
+		}
+		enrollment.Warnings = warnings
+		return enrollment, true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if current.Status == domain.EnrollmentActive && current.Role == role {
+		return current, false, nil
+	}
+	var warnings []string
+	if current.Status != domain.EnrollmentActive {
+		if warnings, err = checkEnrollmentConflicts(s.lectureRepo, s.repo, classID, userID, role, s.conflicts); err != nil {
+			return nil, false, err
+		}
+		current.Status = domain.EnrollmentActive
+		current.EnrolledAt = s.now()
+		current.DroppedAt = nil
+	}
+	current.Role = role
+	if err := s.repo.Update(current); err != nil {
+		return nil, false, err
+	}
+	current.Warnings = warnings
+	return current, false, nil
+}
+
+func (s *enrollmentService) Drop(classID uint, userID uint) error {
+	enrollment, err := s.repo.Find(classID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrNotEnrolled
+	}
+	if err != nil {
+		return err
+	}
+	if enrollment.Status == domain.EnrollmentDropped {
+		return nil
+	}
+	now := s.now()
+	enrollment.Status = domain.EnrollmentDropped
+	enrollment.DroppedAt = &now
+	return s.repo.Update(enrollment)
+}
+
+func (s *enrollmentService) GetRoster(classID uint, status domain.EnrollmentStatus, role domain.EnrollmentRole) ([]domain.Enrollment, error) {
+	if status != "" && !slices.Contains(enrollmentStatuses, status) {
+		return nil, fmt.Errorf("%w: status must be one of %v, got %q", domain.ErrInvalidEnrollment, enrollmentStatuses, status)
+	}
+	if role != "" && !slices.Contains(enrollmentRoles, role) {
+		return nil, fmt.Errorf("%w: role must be one of %v, got %q", domain.ErrInvalidEnrollment, enrollmentRoles, role)
+	}
+	if _, err := s.classRepo.FindByID(classID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrClassNotFound
+		}
+		return nil, err
+	}
+	all, err := s.repo.FindByClass(classID)
+	if err != nil {
+		return nil, err
+	}
+	var roster []domain.Enrollment
+	for _, e := range all {
+		if (status == "" || e.Status == status) && (role == "" || e.Role == role) {
+			roster = append(roster, e)
+		}
+	}
+	return roster, nil
+}
+
+func (s *enrollmentService) GetUserEnrollments(userID uint) ([]domain.Enrollment, error) {
+	if _, err := s.userRepo.FindByID(userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return s.repo.FindByUser(userID)
+}
+
+// checkEnrollmentConflicts rejects enrolling userID in a class whose lectures
+// overlap the user's other classes, or only warns under a soft limit.
+func checkEnrollmentConflicts(lectureRepo repositories.LectureRepository, enrollmentRepo repositories.EnrollmentRepository, classID uint, userID uint, role domain.EnrollmentRole, policy domain.ConflictPolicy) ([]string, error) {
+	lectures, err := lectureRepo.FindByClass(classID, domain.Page{})
+	if err != nil {
+		return nil, err
+	}
+	conflicts, err := userConflicts(lectureRepo, enrollmentRepo, userID, role, lectures)
+	if err != nil {
+		return nil, err
+	}
+	return resolveConflicts(conflicts, policy)
+}
+
+// checkCanTeach rejects enrolling user as a teacher unless their profile
+// allows it.
+func checkCanTeach(profiles repositories.ProfileRepository, user *domain.User, role domain.EnrollmentRole) error {
+	if role != domain.EnrollmentRoleTeacher {
+		return nil
+	}
+	profile, err := profiles.FindByID(user.ProfileID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrCannotTeach
+	}
+	if err != nil {
+		return err
+	}
+	if !profile.CanTeach {
+		return fmt.Errorf("%w: profile %q", domain.ErrCannotTeach, profile.Role)
+	}
+	return nil
+}
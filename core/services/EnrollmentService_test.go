@@ -0,0 +1,148 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"sarc/core/domain"
+	"sarc/core/services"
+	memimpl "sarc/infrastructure/repositories/MEMimpl"
+)
+
+func TestEnrollDropAndReactivate(t *testing.T) {
+	repos := memimpl.NewRepositories(memimpl.NewStore())
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	student := domain.Profile{Role: "student"}
+	must(repos.Profile.Create(&student))
+	teacher := domain.Profile{Role: "teacher", CanTeach: true}
+	must(repos.Profile.Create(&teacher))
+	bia := domain.User{Email: "bia@example.com", Nome: "Bia", ProfileID: student.ID}
+	must(repos.User.Create(&bia))
+	ana := domain.User{Email: "ana@example.com", Nome: "Ana", ProfileID: teacher.ID}
+	must(repos.User.Create(&ana))
+	discipline := domain.Discipline{Name: "Cálculo I", Credits: 4}
+	must(repos.Discipline.Create(&discipline))
+	class := domain.Class{Name: "Turma 10", DisciplineID: discipline.ID}
+	must(repos.Class.Create(&class))
+
+	svc := services.NewEnrollmentService(repos.Enrollment, repos.Class, repos.User, repos.Profile, repos.Lecture, domain.ConflictPolicy{})
+	if _, _, err := svc.Enroll(class.ClassID, bia.ID, domain.EnrollmentRoleTeacher); !errors.Is(err, domain.ErrCannotTeach) {
+		t.Errorf("enrolling a student as teacher: got %v, want %v", err, domain.ErrCannotTeach)
+	}
+	if _, created, err := svc.Enroll(class.ClassID, ana.ID, domain.EnrollmentRoleTeacher); err != nil || !created {
+		t.Errorf("enrolling a teacher: created=%v err=%v", created, err)
+	}
+
+	first, created, err := svc.Enroll(class.ClassID, bia.ID, "")
+	must(err)
+	if !created || first.Role != domain.EnrollmentRoleStudent || first.Status != domain.EnrollmentActive {
+		t.Errorf("unexpected enrollment: created=%v %+v", created, first)
+	}
+	must(svc.Drop(class.ClassID, bia.ID))
+	roster, err := svc.GetRoster(class.ClassID, domain.EnrollmentDropped, "")
+	must(err)
+	if len(roster) != 1 || roster[0].UserID != bia.ID || roster[0].DroppedAt == nil {
+		t.Fatalf("unexpected dropped roster: %+v", roster)
+	}
+
+	again, created, err := svc.Enroll(class.ClassID, bia.ID, "")
+	must(err)
+	if created || again.EnrollmentID != first.EnrollmentID || again.Status != domain.EnrollmentActive || again.DroppedAt != nil {
+		t.Errorf("re-enrolling did not reactivate: created=%v %+v", created, again)
+	}
+	if err := svc.Drop(class.ClassID, 999); !errors.Is(err, domain.ErrNotEnrolled) {
+		t.Errorf("dropping a stranger: got %v, want %v", err, domain.ErrNotEnrolled)
+	}
+}
+
+func TestImportEnrollmentsChecksTeachers(t *testing.T) {
+	store := memimpl.NewStore()
+	repos := memimpl.NewRepositories(store)
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	student := domain.Profile{Role: "student"}
+	must(repos.Profile.Create(&student))
+	bia := domain.User{Email: "bia@example.com", Nome: "Bia", ProfileID: student.ID}
+	must(repos.User.Create(&bia))
+	caio := domain.User{Email: "caio@example.com", Nome: "Caio", ProfileID: student.ID}
+	must(repos.User.Create(&caio))
+	discipline := domain.Discipline{Name: "Cálculo I", Credits: 4}
+	must(repos.Discipline.Create(&discipline))
+	class := domain.Class{Name: "Turma 10", DisciplineID: discipline.ID}
+	must(repos.Class.Create(&class))
+
+	svc := services.NewImportService(memimpl.NewTransactor(store), domain.ConflictPolicy{})
+	report, err := svc.Import("enrollments", readCSV(t, "class,email,role\n"+
+		"turma 10,BIA@example.com,\n"+
+		"Turma 10,caio@example.com,teacher\n"), domain.ImportOptions{})
+	must(err)
+	if report.Committed || len(report.Errors) != 1 || report.Errors[0].Column != "role" {
+		t.Fatalf("expected the teacher row to be rejected: %+v", report)
+	}
+
+	report, err = svc.Import("enrollments", readCSV(t, "class,email,role\n"+
+		"turma 10,BIA@example.com,\n"+
+		"Turma 10,caio@example.com,assistant\n"), domain.ImportOptions{})
+	must(err)
+	if !report.Committed || report.Inserted != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	roster, err := repos.Enrollment.FindByClass(class.ClassID)
+	must(err)
+	if len(roster) != 2 || roster[0].Role != domain.EnrollmentRoleStudent || roster[1].Role != domain.EnrollmentRoleAssistant {
+		t.Errorf("unexpected roster: %+v", roster)
+	}
+}
+
+func TestImportEnrollmentsChecksConflictsBetweenRows(t *testing.T) {
+	store := memimpl.NewStore()
+	repos := memimpl.NewRepositories(store)
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	student := domain.Profile{Role: "student"}
+	must(repos.Profile.Create(&student))
+	bia := domain.User{Email: "bia@example.com", Nome: "Bia", ProfileID: student.ID}
+	must(repos.User.Create(&bia))
+	building := domain.Building{BuildingName: "Prédio 32"}
+	must(repos.Building.Create(&building))
+	room := domain.Room{RoomNumber: "101", BuildingID: building.BuildingID}
+	must(repos.Room.Create(&room))
+	discipline := domain.Discipline{Name: "Cálculo I", Credits: 4}
+	must(repos.Discipline.Create(&discipline))
+	for i, name := range []string{"Turma 10", "Turma 20"} {
+		class := domain.Class{Name: name, DisciplineID: discipline.ID}
+		must(repos.Class.Create(&class))
+		start := []string{"08:00", "09:00"}[i]
+		must(repos.Lecture.Create(&domain.Lecture{ClassID: class.ClassID, RoomID: room.RoomID, Date: "2030-03-04", StartTime: start, EndTime: "09:40"}))
+	}
+	roster := "class,email\n" +
+		"Turma 10,bia@example.com\n" +
+		"Turma 20,bia@example.com\n"
+
+	strict := services.NewImportService(memimpl.NewTransactor(store), domain.ConflictPolicy{})
+	report, err := strict.Import("enrollments", readCSV(t, roster), domain.ImportOptions{})
+	must(err)
+	if report.Committed || len(report.Errors) != 1 || report.Errors[0].Row != 3 || report.Errors[0].Column != "class" {
+		t.Fatalf("expected the second row to conflict with the first: %+v", report)
+	}
+
+	soft := services.NewImportService(memimpl.NewTransactor(store), domain.ConflictPolicy{SoftLimit: true})
+	report, err = soft.Import("enrollments", readCSV(t, roster), domain.ImportOptions{})
+	must(err)
+	if !report.Committed || report.Inserted != 2 || len(report.Warnings) != 1 || report.Warnings[0].Row != 3 {
+		t.Fatalf("soft limit: expected both rows with a warning: %+v", report)
+	}
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"sarc/core/domain"
+	interfaces "sarc/core/services/interfaces"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type equivalenceService struct {
+	repo           repositories.EquivalenceRepository
+	disciplineRepo repositories.DisciplineRepository
+	curriculumRepo repositories.CurriculumRepository
+	tx             repositories.Transactor
+}
+
+func NewEquivalenceService(repo repositories.EquivalenceRepository, disciplineRepo repositories.DisciplineRepository, curriculumRepo repositories.CurriculumRepository, tx repositories.Transactor) interfaces.EquivalenceService {
+	return &equivalenceService{repo: repo, disciplineRepo: disciplineRepo, curriculumRepo: curriculumRepo, tx: tx}
+}
+
+func (s *equivalenceService) CreateEquivalence(equivalence *domain.Equivalence) (*domain.Equivalence, error) {
+	sources := slices.Clone(equivalence.SourceIDs)
+	slices.Sort(sources)
+	switch {
+	case equivalence.TargetID == 0 || len(sources) == 0:
+		return nil, fmt.Errorf("%w: a target and at least one source are required", domain.ErrInvalidEquivalence)
+	case slices.Contains(sources, 0):
+		return nil, fmt.Errorf("%w: source IDs must not be 0", domain.ErrInvalidEquivalence)
+	case slices.Contains(sources, equivalence.TargetID):
+		return nil, fmt.Errorf("%w: discipline %d cannot be equivalent to itself", domain.ErrInvalidEquivalence, equivalence.TargetID)
+	case len(slices.Compact(slices.Clone(sources))) != len(sources):
+		return nil, fmt.Errorf("%w: sources must not repeat", domain.ErrInvalidEquivalence)
+	}
+	if _, err := s.findDisciplines(append([]uint{equivalence.TargetID}, sources...)); err != nil {
+		return nil, err
+	}
+
+	created := domain.Equivalence{TargetID: equivalence.TargetID, SourceIDs: sources}
+	err := s.tx.WithinTransaction(func(repos repositories.Repositories) error {
+		existing, err := repos.Equivalence.FindByTargets([]uint{created.TargetID})
+		if err != nil {
+			return err
+		}
+		for _, e := range existing {
+			if slices.Equal(e.SourceIDs, sources) {
+				return fmt.Errorf("%w: equivalence %d", domain.ErrEquivalenceExists, e.ID)
+			}
+		}
+		return repos.Equivalence.Create(&created)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (s *equivalenceService) GetEquivalenceByID(id uint) (*domain.Equivalence, error) {
+	equivalence, err := s.repo.FindByID(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrEquivalenceNotFound
+	}
+	return equivalence, err
+}
+
+func (s *equivalenceService) GetEquivalences(disciplineID uint, page domain.Page) ([]domain.Equivalence, error) {
+	if disciplineID == 0 {
+		return s.repo.FindAll(page)
+	}
+	return s.repo.FindByDiscipline(disciplineID, page)
+}
+
+func (s *equivalenceService) DeleteEquivalence(id uint) error {
+	if _, err := s.GetEquivalenceByID(id); err != nil {
+		return err
+	}
+	return s.tx.WithinTransaction(func(repos repositories.Repositories) error {
+		return repos.Equivalence.Delete(id)
+	})
+}
+
+// TransferCredits credits a discipline of the curriculum when it was
+// completed itself or, failing that, through the first equivalence, by ID,
+// whose sources were all completed. Each completed discipline credits one
+// discipline at most, so its credits are not counted twice.
+func (s *equivalenceService) TransferCredits(curriculumID uint, completed []uint) (*domain.CreditTransfer, error) {
+	curriculum, err := findCurriculum(s.curriculumRepo, curriculumID)
+	if err != nil {
+		return nil, err
+	}
+	done, err := s.findDisciplines(completed)
+	if err != nil {
+		return nil, err
+	}
+	passed := make(map[uint]domain.Discipline, len(done))
+	for _, d := range done {
+		passed[d.ID] = d
+	}
+
+	used := make(map[uint]bool)
+	credited := make(map[uint]domain.CreditedDiscipline)
+	var targets []uint
+	for _, d := range curriculum.Disciplines {
+		if p, ok := passed[d.ID]; ok {
+			credited[d.ID] = domain.CreditedDiscipline{Discipline: d, From: []domain.Discipline{p}}
+			used[d.ID] = true
+		} else {
+			targets = append(targets, d.ID)
+		}
+	}
+	equivalences, err := s.repo.FindByTargets(targets)
+	if err != nil {
+		return nil, err
+	}
+	unavailable := func(id uint) bool { _, ok := passed[id]; return !ok || used[id] }
+	for _, e := range equivalences {
+		if _, ok := credited[e.TargetID]; ok || slices.ContainsFunc(e.SourceIDs, unavailable) {
+			continue
+		}
+		from := make([]domain.Discipline, len(e.SourceIDs))
+		for i, id := range e.SourceIDs {
+			from[i] = passed[id]
+			used[id] = true
+		}
+		credited[e.TargetID] = domain.CreditedDiscipline{EquivalenceID: e.ID, From: from}
+	}
+
+	transfer := &domain.CreditTransfer{
+		CurriculumID: curriculum.ID,
+		Credited:     []domain.CreditedDiscipline{},
+		Pending:      []domain.Discipline{},
+		Unused:       []domain.Discipline{},
+	}
+	for _, d := range curriculum.Disciplines {
+		c, ok := credited[d.ID]
+		if !ok {
+			transfer.Pending = append(transfer.Pending, d)
+			continue
+		}
+		c.Discipline = d
+		transfer.Credited = append(transfer.Credited, c)
+		transfer.Credits += d.Credits
+	}
+	for _, d := range done {
+		if !used[d.ID] {
+			transfer.Unused = append(transfer.Unused, d)
+		}
+	}
+	return transfer, nil
+}
+
+// findDisciplines loads the disciplines with the given IDs, ordered by ID,
+// failing on the first one that does not exist.
+func (s *equivalenceService) findDisciplines(ids []uint) ([]domain.Discipline, error) {
+	disciplines, err := s.disciplineRepo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if !slices.ContainsFunc(disciplines, func(d domain.Discipline) bool { return d.ID == id }) {
+			return nil, fmt.Errorf("%w: %d", domain.ErrDisciplineNotFound, id)
+		}
+	}
+	return disciplines, nil
+}
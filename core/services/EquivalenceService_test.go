@@ -0,0 +1,101 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"sarc/core/domain"
+	"sarc/core/services"
+	memimpl "sarc/infrastructure/repositories/MEMimpl"
+)
+
+func TestTransferCredits(t *testing.T) {
+	store := memimpl.NewStore()
+	repos := memimpl.NewRepositories(store)
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	ids := make(map[string]uint)
+	for _, name := range []string{"Cálculo I", "Álgebra", "Inglês", "Cálculo A", "Matemática Básica", "Física I"} {
+		d := domain.Discipline{Name: name, Credits: 4}
+		must(repos.Discipline.Create(&d))
+		ids[name] = d.ID
+	}
+
+	curriculums := services.NewCurriculumService(repos.Curriculum, repos.Discipline, memimpl.NewTransactor(store), domain.CreditLimits{})
+	target, err := curriculums.CreateCurriculum(&domain.Curriculum{
+		CourseName: "Engenharia 2025",
+		Disciplines: []domain.Discipline{
+			{ID: ids["Cálculo A"], Semester: 1},
+			{ID: ids["Matemática Básica"], Semester: 1},
+			{ID: ids["Inglês"], Semester: 1},
+			{ID: ids["Física I"], Semester: 2},
+		},
+	})
+	must(err)
+
+	svc := services.NewEquivalenceService(repos.Equivalence, repos.Discipline, repos.Curriculum, memimpl.NewTransactor(store))
+	oneToOne, err := svc.CreateEquivalence(&domain.Equivalence{TargetID: ids["Cálculo A"], SourceIDs: []uint{ids["Cálculo I"]}})
+	must(err)
+	manyToOne, err := svc.CreateEquivalence(&domain.Equivalence{TargetID: ids["Matemática Básica"], SourceIDs: []uint{ids["Álgebra"], ids["Cálculo I"]}})
+	must(err)
+	for _, bad := range []domain.Equivalence{
+		{TargetID: ids["Cálculo A"]},
+		{TargetID: ids["Cálculo A"], SourceIDs: []uint{ids["Cálculo A"]}},
+		{TargetID: ids["Cálculo A"], SourceIDs: []uint{ids["Álgebra"], ids["Álgebra"]}},
+	} {
+		if _, err := svc.CreateEquivalence(&bad); !errors.Is(err, domain.ErrInvalidEquivalence) {
+			t.Errorf("%+v: got %v, want %v", bad, err, domain.ErrInvalidEquivalence)
+		}
+	}
+	if _, err := svc.CreateEquivalence(&domain.Equivalence{TargetID: ids["Cálculo A"], SourceIDs: []uint{999}}); !errors.Is(err, domain.ErrDisciplineNotFound) {
+		t.Errorf("unknown source: got %v, want %v", err, domain.ErrDisciplineNotFound)
+	}
+	if _, err := svc.CreateEquivalence(&domain.Equivalence{TargetID: ids["Matemática Básica"], SourceIDs: []uint{ids["Cálculo I"], ids["Álgebra"]}}); !errors.Is(err, domain.ErrEquivalenceExists) {
+		t.Errorf("same sources in another order: got %v, want %v", err, domain.ErrEquivalenceExists)
+	}
+
+	// Cálculo I goes to the first equivalence, so Álgebra alone cannot
+	// credit Matemática Básica.
+	transfer, err := svc.TransferCredits(target.ID, []uint{ids["Cálculo I"], ids["Álgebra"], ids["Inglês"]})
+	must(err)
+	if len(transfer.Credited) != 2 || transfer.Credits != 8 || len(transfer.Pending) != 2 || len(transfer.Unused) != 1 {
+		t.Fatalf("unexpected transfer: %+v", transfer)
+	}
+	for _, c := range transfer.Credited {
+		switch c.Discipline.ID {
+		case ids["Cálculo A"]:
+			if c.EquivalenceID != oneToOne.ID || len(c.From) != 1 || c.From[0].ID != ids["Cálculo I"] {
+				t.Errorf("Cálculo A credited as %+v", c)
+			}
+		case ids["Inglês"]:
+			if c.EquivalenceID != 0 || c.Discipline.Semester != 1 {
+				t.Errorf("Inglês credited as %+v", c)
+			}
+		default:
+			t.Errorf("unexpected credited discipline %+v", c)
+		}
+	}
+	if transfer.Unused[0].ID != ids["Álgebra"] {
+		t.Errorf("unused: got %+v, want Álgebra", transfer.Unused)
+	}
+
+	must(svc.DeleteEquivalence(oneToOne.ID))
+	transfer, err = svc.TransferCredits(target.ID, []uint{ids["Cálculo I"], ids["Álgebra"]})
+	must(err)
+	if len(transfer.Credited) != 1 || transfer.Credited[0].EquivalenceID != manyToOne.ID || len(transfer.Unused) != 0 {
+		t.Errorf("unexpected transfer through the many-to-one equivalence: %+v", transfer)
+	}
+	if err := svc.DeleteEquivalence(oneToOne.ID); !errors.Is(err, domain.ErrEquivalenceNotFound) {
+		t.Errorf("deleting twice: got %v, want %v", err, domain.ErrEquivalenceNotFound)
+	}
+	if _, err := svc.TransferCredits(999, nil); !errors.Is(err, domain.ErrCurriculumNotFound) {
+		t.Errorf("unknown curriculum: got %v, want %v", err, domain.ErrCurriculumNotFound)
+	}
+	if _, err := svc.TransferCredits(target.ID, []uint{999}); !errors.Is(err, domain.ErrDisciplineNotFound) {
+		t.Errorf("unknown completed discipline: got %v, want %v", err, domain.ErrDisciplineNotFound)
+	}
+}
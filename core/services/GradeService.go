@@ -0,0 +1,269 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"sarc/core/domain"
+	interfaces "sarc/core/services/interfaces"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type gradeService struct {
+	repo           repositories.AssessmentRepository
+	recordRepo     repositories.AcademicRecordRepository
+	classRepo      repositories.ClassRepository
+	enrollmentRepo repositories.EnrollmentRepository
+	userRepo       repositories.UserRepository
+	attendance     interfaces.AttendanceService
+	tx             repositories.Transactor
+	policy         domain.GradingPolicy
+	now            func() time.Time
+}
+
+func NewGradeService(repos repositories.Repositories, attendance interfaces.AttendanceService, tx repositories.Transactor, policy domain.GradingPolicy) interfaces.GradeService {
+	return &gradeService{
+		repo:           repos.Assessment,
+		recordRepo:     repos.Record,
+		classRepo:      repos.Class,
+		enrollmentRepo: repos.Enrollment,
+		userRepo:       repos.User,
+		attendance:     attendance,
+		tx:             tx,
+		policy:         policy,
+		now:            time.Now,
+	}
+}
+
+func (s *gradeService) CreateAssessment(assessment *domain.Assessment) (*domain.Assessment, error) {
+	if err := validateAssessment(assessment); err != nil {
+		return nil, err
+	}
+	if _, err := s.findClass(assessment.ClassID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(assessment); err != nil {
+		return nil, err
+	}
+	return assessment, nil
+}
+
+func (s *gradeService) GetAssessments(classID uint) ([]domain.Assessment, error) {
+	if _, err := s.findClass(classID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByClass(classID)
+}
+
+func (s *gradeService) UpdateAssessment(id uint, assessment *domain.Assessment) (*domain.Assessment, error) {
+	current, err := s.findAssessment(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAssessment(assessment); err != nil {
+		return nil, err
+	}
+	current.Name, current.Weight = assessment.Name, assessment.Weight
+	if err := s.repo.Update(current); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+func (s *gradeService) DeleteAssessment(id uint) error {
+	if _, err := s.findAssessment(id); err != nil {
+		return err
+	}
+	return s.tx.WithinTransaction(func(repos repositories.Repositories) error {
+		return repos.Assessment.Delete(id)
+	})
+}
+
+func (s *gradeService) SetGrade(grade *domain.Grade) (*domain.Grade, error) {
+	if grade.Value < 0 || grade.Value > s.policy.MaxGrade {
+		return nil, fmt.Errorf("%w: grades go from 0 to %g, got %g", domain.ErrInvalidGrade, s.policy.MaxGrade, grade.Value)
+	}
+	assessment, err := s.findAssessment(grade.AssessmentID)
+	if err != nil {
+		return nil, err
+	}
+	enrollment, err := s.enrollmentRepo.Find(assessment.ClassID, grade.UserID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if enrollment == nil || !countsAsStudent(*enrollment) {
+		return nil, fmt.Errorf("%w: user %d is not a student of class %d", domain.ErrNotEnrolled, grade.UserID, assessment.ClassID)
+	}
+	if err := s.repo.SetGrade(grade); err != nil {
+		return nil, err
+	}
+	return grade, nil
+}
+
+func (s *gradeService) GetClassResults(classID uint) (*domain.ClassResults, error) {
+	results, _, _, err := s.results(classID, false)
+	return results, err
+}
+
+func (s *gradeService) CloseClass(classID uint) (*domain.ClassResults, error) {
+	results, class, enrollments, err := s.results(classID, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Assessments) == 0 {
+		return nil, fmt.Errorf("%w: class %d has no assessments to grade", domain.ErrInvalidAssessment, classID)
+	}
+	closedAt := s.now()
+	err = s.tx.WithinTransaction(func(repos repositories.Repositories) error {
+		for _, r := range results.Students {
+			if err := repos.Record.Save(&domain.AcademicRecord{
+				UserID:       r.UserID,
+				ClassID:      classID,
+				DisciplineID: class.DisciplineID,
+				FinalGrade:   r.FinalGrade,
+				Attendance:   r.Attendance,
+				Status:       r.Status,
+				CompletedAt:  closedAt,
+			}); err != nil {
+				return err
+			}
+			e := enrollments[r.UserID]
+			e.Status = domain.EnrollmentCompleted
+			if err := repos.Enrollment.Update(&e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (s *gradeService) GetHistory(userID uint) ([]domain.AcademicRecord, error) {
+	if _, err := s.userRepo.FindByID(userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return s.recordRepo.FindByUser(userID)
+}
+
+// results works out the result of every student of the class, along with
+// the class and the students' enrollments. While the class is open,
+// students with assessments still to be graded are in progress, and only
+// those who can no longer reach the minimum attendance fail by it; once it
+// is final, missing grades count as zero and attendance is judged on the
+// lectures held.
+func (s *gradeService) results(classID uint, final bool) (*domain.ClassResults, *domain.Class, map[uint]domain.Enrollment, error) {
+	class, err := s.findClass(classID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	assessments, err := s.repo.FindByClass(classID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	grades, err := s.repo.FindGradesByClass(classID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	enrollments, err := s.enrollmentRepo.FindByClass(classID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	report, err := s.attendance.GetClassAttendance(classID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	attendance := make(map[uint]domain.StudentAttendance, len(report.Students))
+	for _, a := range report.Students {
+		attendance[a.UserID] = a
+	}
+	byUser := make(map[uint][]domain.Grade)
+	for _, g := range grades {
+		byUser[g.UserID] = append(byUser[g.UserID], g)
+	}
+	weights := make(map[uint]float64, len(assessments))
+	var totalWeight float64
+	for _, a := range assessments {
+		weights[a.ID] = a.Weight
+		totalWeight += a.Weight
+	}
+
+	results := &domain.ClassResults{
+		ClassID:      classID,
+		Assessments:  assessments,
+		PassingGrade: s.policy.PassingGrade,
+		Students:     []domain.StudentResult{},
+	}
+	if results.Assessments == nil {
+		results.Assessments = []domain.Assessment{}
+	}
+	students := make(map[uint]domain.Enrollment)
+	for _, e := range enrollments {
+		if !countsAsStudent(e) {
+			continue
+		}
+		students[e.UserID] = e
+		r := domain.StudentResult{ClassID: classID, UserID: e.UserID, User: e.User, Grades: []domain.Grade{}}
+		var sum float64
+		for _, g := range byUser[e.UserID] {
+			r.Grades = append(r.Grades, g)
+			sum += weights[g.AssessmentID] * g.Value
+		}
+		if totalWeight > 0 {
+			r.FinalGrade = math.Round(100*sum/totalWeight) / 100
+		}
+		a, ok := attendance[e.UserID]
+		if !ok {
+			a = domain.StudentAttendance{Percentage: 100, Status: domain.AttendanceOK}
+		}
+		r.Attendance = a.Percentage
+		switch {
+		case a.Status == domain.AttendanceFailed || (final && a.Percentage < report.MinimumPercentage):
+			r.Status = domain.ResultFailedAttendance
+		case !final && (len(assessments) == 0 || len(r.Grades) < len(assessments)):
+			r.Status = domain.ResultInProgress
+		case r.FinalGrade >= s.policy.PassingGrade:
+			r.Status = domain.ResultApproved
+		default:
+			r.Status = domain.ResultFailed
+		}
+		results.Students = append(results.Students, r)
+	}
+	return results, class, students, nil
+}
+
+func (s *gradeService) findClass(id uint) (*domain.Class, error) {
+	class, err := s.classRepo.FindByID(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrClassNotFound
+	}
+	return class, err
+}
+
+func (s *gradeService) findAssessment(id uint) (*domain.Assessment, error) {
+	assessment, err := s.repo.FindByID(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAssessmentNotFound
+	}
+	return assessment, err
+}
+
+func validateAssessment(assessment *domain.Assessment) error {
+	assessment.Name = strings.TrimSpace(assessment.Name)
+	if assessment.Name == "" {
+		return fmt.Errorf("%w: name is required", domain.ErrInvalidAssessment)
+	}
+	if assessment.Weight <= 0 {
+		return fmt.Errorf("%w: weight must be above 0, got %g", domain.ErrInvalidAssessment, assessment.Weight)
+	}
+	return nil
+}
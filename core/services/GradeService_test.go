@@ -0,0 +1,130 @@
+package services_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"sarc/core/domain"
+	"sarc/core/services"
+	memimpl "sarc/infrastructure/repositories/MEMimpl"
+)
+
+func TestClassResultsAndHistory(t *testing.T) {
+	store := memimpl.NewStore()
+	repos := memimpl.NewRepositories(store)
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	profile := domain.Profile{Role: "student"}
+	must(repos.Profile.Create(&profile))
+	building := domain.Building{BuildingName: "Prédio 32"}
+	must(repos.Building.Create(&building))
+	room := domain.Room{RoomNumber: "101", BuildingID: building.BuildingID}
+	must(repos.Room.Create(&room))
+	discipline := domain.Discipline{Name: "Cálculo I", Credits: 4}
+	must(repos.Discipline.Create(&discipline))
+	class := domain.Class{Name: "Turma 10", DisciplineID: discipline.ID}
+	must(repos.Class.Create(&class))
+
+	// Four lectures already held: with a 75% minimum a student may miss one.
+	var lectures []uint
+	for i := 0; i < 4; i++ {
+		lecture := domain.Lecture{ClassID: class.ClassID, RoomID: room.RoomID, Date: fmt.Sprintf("2020-03-%02d", i+1)}
+		must(repos.Lecture.Create(&lecture))
+		lectures = append(lectures, lecture.LectureID)
+	}
+	attended := []int{4, 4, 1, 3}
+	students := make([]domain.User, len(attended))
+	for i, n := range attended {
+		students[i] = domain.User{Email: fmt.Sprintf("s%d@example.com", i), Nome: fmt.Sprintf("S%d", i), ProfileID: profile.ID}
+		must(repos.User.Create(&students[i]))
+		for _, id := range lectures[:n] {
+			_, err := repos.Presence.Mark(id, []uint{students[i].ID}, domain.PresenceMethodManual, time.Now())
+			must(err)
+		}
+		must(repos.Enrollment.Create(&domain.Enrollment{ClassID: class.ClassID, UserID: students[i].ID, Role: domain.EnrollmentRoleStudent, Status: domain.EnrollmentActive, EnrolledAt: time.Now()}))
+	}
+	teacher := domain.User{Email: "t@example.com", Nome: "T", ProfileID: profile.ID}
+	must(repos.User.Create(&teacher))
+	must(repos.Enrollment.Create(&domain.Enrollment{ClassID: class.ClassID, UserID: teacher.ID, Role: domain.EnrollmentRoleTeacher, Status: domain.EnrollmentActive, EnrolledAt: time.Now()}))
+
+	attendance := services.NewAttendanceService(repos, &recordingNotifier{}, domain.AttendancePolicy{MinimumPercentage: 75}, time.UTC)
+	svc := services.NewGradeService(repos, attendance, memimpl.NewTransactor(store), domain.GradingPolicy{MaxGrade: 10, PassingGrade: 6})
+	if _, err := svc.CreateAssessment(&domain.Assessment{ClassID: class.ClassID, Name: "Prova", Weight: 0}); !errors.Is(err, domain.ErrInvalidAssessment) {
+		t.Errorf("zero weight: got %v, want %v", err, domain.ErrInvalidAssessment)
+	}
+	exam, err := svc.CreateAssessment(&domain.Assessment{ClassID: class.ClassID, Name: "Prova", Weight: 2})
+	must(err)
+	project, err := svc.CreateAssessment(&domain.Assessment{ClassID: class.ClassID, Name: "Trabalho", Weight: 1})
+	must(err)
+
+	grade := func(a *domain.Assessment, u domain.User, value float64) {
+		t.Helper()
+		_, err := svc.SetGrade(&domain.Grade{AssessmentID: a.ID, UserID: u.ID, Value: value})
+		must(err)
+	}
+	grade(exam, students[0], 8)
+	grade(project, students[0], 6)
+	grade(exam, students[1], 4)
+	grade(project, students[1], 5)
+	grade(exam, students[2], 10)
+	grade(project, students[2], 10)
+	grade(exam, students[3], 9)
+	if _, err := svc.SetGrade(&domain.Grade{AssessmentID: exam.ID, UserID: teacher.ID, Value: 5}); !errors.Is(err, domain.ErrNotEnrolled) {
+		t.Errorf("grading a teacher: got %v, want %v", err, domain.ErrNotEnrolled)
+	}
+	if _, err := svc.SetGrade(&domain.Grade{AssessmentID: exam.ID, UserID: students[0].ID, Value: 11}); !errors.Is(err, domain.ErrInvalidGrade) {
+		t.Errorf("grade over the maximum: got %v, want %v", err, domain.ErrInvalidGrade)
+	}
+
+	statuses := func(results *domain.ClassResults) []domain.ResultStatus {
+		var out []domain.ResultStatus
+		for _, r := range results.Students {
+			out = append(out, r.Status)
+		}
+		return out
+	}
+	results, err := svc.GetClassResults(class.ClassID)
+	must(err)
+	want := []domain.ResultStatus{domain.ResultApproved, domain.ResultFailed, domain.ResultFailedAttendance, domain.ResultInProgress}
+	if got := statuses(results); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("statuses: got %v, want %v", got, want)
+	}
+	if results.Students[0].FinalGrade != 7.33 || results.Students[3].FinalGrade != 6 || results.Students[3].Attendance != 75 {
+		t.Errorf("unexpected results: %+v", results.Students)
+	}
+
+	// Once closed the missing grade counts as zero: 9 with weight 2 out of 3
+	// is still 6.
+	closed, err := svc.CloseClass(class.ClassID)
+	must(err)
+	want[3] = domain.ResultApproved
+	if got := statuses(closed); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("statuses after closing: got %v, want %v", got, want)
+	}
+	history, err := svc.GetHistory(students[0].ID)
+	must(err)
+	if len(history) != 1 || history[0].Status != domain.ResultApproved || history[0].Discipline == nil || history[0].Discipline.ID != discipline.ID {
+		t.Fatalf("unexpected history: %+v", history)
+	}
+	enrollment, err := repos.Enrollment.Find(class.ClassID, students[0].ID)
+	must(err)
+	if enrollment.Status != domain.EnrollmentCompleted {
+		t.Errorf("enrollment status: got %q, want %q", enrollment.Status, domain.EnrollmentCompleted)
+	}
+	if _, err := svc.GetHistory(999); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("unknown user: got %v, want %v", err, domain.ErrUserNotFound)
+	}
+
+	must(svc.DeleteAssessment(project.ID))
+	results, err = svc.GetClassResults(class.ClassID)
+	must(err)
+	if len(results.Assessments) != 1 || len(results.Students[0].Grades) != 1 || results.Students[0].FinalGrade != 8 {
+		t.Errorf("unexpected results after deleting an assessment: %+v", results)
+	}
+}
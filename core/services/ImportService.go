@@ -0,0 +1,696 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sarc/core/domain"
+	interfaces "sarc/core/services/interfaces"
+	repositories "sarc/infrastructure/repositories/interfaces"
+	"sarc/pkg/tabular"
+)
+
+// errImportRejected rolls back an import whose rows failed while being written.
+var errImportRejected = errors.New("import rejected")
+
+type importService struct {
+	tx       repositories.Transactor
+	settings importSettings
+}
+
+func NewImportService(tx repositories.Transactor, conflicts domain.ConflictPolicy) interfaces.ImportService {
+	return &importService{tx: tx, settings: importSettings{conflicts: conflicts}}
+}
+
+// importSettings are the policies some importers apply to each row.
+type importSettings struct {
+	conflicts domain.ConflictPolicy
+}
+
+// importSpec describes one importable entity: its fields, the columns that
+// must be present, and how rows are turned into inserts and updates.
+type importSpec struct {
+	fields   []string
+	required []string
+	plan     func(repos repositories.Repositories, rows []*importRow, settings importSettings) ([]importOp, error)
+}
+
+// importOp is the write planned for one valid row.
+type importOp struct {
+	row    *importRow
+	update bool
+	apply  func() error
+}
+
+var importSpecs = map[string]importSpec{
+	"buildings": {
+		fields:   []string{"buildingName", "address"},
+		required: []string{"buildingName"},
+		plan:     planBuildings,
+	},
+	"rooms": {
+		fields:   []string{"buildingName", "roomNumber", "roomCapacity", "floor"},
+		required: []string{"buildingName", "roomNumber"},
+		plan:     planRooms,
+	},
+	"users": {
+		fields:   []string{"email", "nome", "birthDate", "sex", "telephone", "profile"},
+		required: []string{"email"},
+		plan:     planUsers,
+	},
+	"resources": {
+		fields:   []string{"description", "resourceType", "status", "characteristics"},
+		required: []string{"description", "resourceType"},
+		plan:     planResources,
+	},
+	"enrollments": {
+		fields:   []string{"class", "email", "role", "status"},
+		required: []string{"class", "email"},
+		plan:     planEnrollments,
+	},
+}
+
+func (s *importService) ImportEntities() []string {
+	entities := make([]string, 0, len(importSpecs))
+	for name := range importSpecs {
+		entities = append(entities, name)
+	}
+	sort.Strings(entities)
+	return entities
+}
+
+func (s *importService) Import(entity string, table *tabular.Table, opts domain.ImportOptions) (*domain.ImportReport, error) {
+	spec, ok := importSpecs[entity]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", domain.ErrUnknownImportEntity, entity)
+	}
+	report := &domain.ImportReport{Entity: entity, Rows: len(table.Rows), DryRun: opts.DryRun}
+
+	columns, headerErrors := resolveColumns(table, spec, opts.Mapping)
+	if len(headerErrors) > 0 {
+		report.Errors = headerErrors
+		return report, nil
+	}
+	rows := make([]*importRow, len(table.Rows))
+	for i, r := range table.Rows {
+		rows[i] = &importRow{line: r.Line, values: r.Values, columns: columns}
+	}
+
+	err := s.tx.WithinTransaction(func(repos repositories.Repositories) error {
+		ops, err := spec.plan(repos, rows, s.settings)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			report.Errors = append(report.Errors, row.errors...)
+			report.Warnings = append(report.Warnings, row.warnings...)
+		}
+		for _, op := range ops {
+			if op.update {
+				report.Updated++
+			} else {
+				report.Inserted++
+			}
+		}
+		if len(report.Errors) > 0 || opts.DryRun {
+			return nil
+		}
+
+		for _, op := range ops {
+			if err := op.apply(); err != nil {
+				report.Errors = append(report.Errors, domain.ImportRowError{Row: op.row.line, Message: err.Error()})
+				return errImportRejected
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errImportRejected) {
+		return report, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	report.Committed = len(report.Errors) == 0 && !opts.DryRun
+	return report, nil
+}
+
+// resolveColumns finds the column of every field, following the mapping
+// where one is given and otherwise matching the field name itself.
+// Header names are compared case-insensitively.
+func resolveColumns(table *tabular.Table, spec importSpec, mapping map[string]string) (map[string]int, []domain.ImportRowError) {
+	var errs []domain.ImportRowError
+	known := make(map[string]bool, len(spec.fields))
+	for _, field := range spec.fields {
+		known[field] = true
+	}
+	mapped := make([]string, 0, len(mapping))
+	for field := range mapping {
+		mapped = append(mapped, field)
+	}
+	sort.Strings(mapped)
+	for _, field := range mapped {
+		if !known[field] {
+			errs = append(errs, domain.ImportRowError{
+				Row:     table.HeaderLine,
+				Message: fmt.Sprintf("unknown field %q in column mapping, expected one of: %s", field, strings.Join(spec.fields, ", ")),
+			})
+		}
+	}
+
+	required := make(map[string]bool, len(spec.required))
+	for _, field := range spec.required {
+		required[field] = true
+	}
+	columns := make(map[string]int)
+	for _, field := range spec.fields {
+		header, mapped := mapping[field]
+		if !mapped {
+			header = field
+		}
+		index := -1
+		for i, h := range table.Header {
+			if strings.EqualFold(h, header) {
+				index = i
+				break
+			}
+		}
+		switch {
+		case index >= 0:
+			columns[field] = index
+		case mapped || required[field]:
+			errs = append(errs, domain.ImportRowError{
+				Row:     table.HeaderLine,
+				Column:  header,
+				Message: fmt.Sprintf("missing column %q for field %s", header, field),
+			})
+		}
+	}
+	return columns, errs
+}
+
+// importRow reads the fields of one row and collects its validation errors
+// and warnings.
+type importRow struct {
+	line     int
+	values   []string
+	columns  map[string]int
+	errors   []domain.ImportRowError
+	warnings []domain.ImportRowError
+}
+
+func (r *importRow) has(field string) bool {
+	_, ok := r.columns[field]
+	return ok
+}
+
+func (r *importRow) str(field string) string {
+	i, ok := r.columns[field]
+	if !ok || i >= len(r.values) {
+		return ""
+	}
+	return strings.TrimSpace(r.values[i])
+}
+
+func (r *importRow) fail(field, format string, args ...any) {
+	r.errors = append(r.errors, domain.ImportRowError{Row: r.line, Column: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (r *importRow) warn(field, format string, args ...any) {
+	r.warnings = append(r.warnings, domain.ImportRowError{Row: r.line, Column: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (r *importRow) valid() bool {
+	return len(r.errors) == 0
+}
+
+func (r *importRow) required(field string) string {
+	v := r.str(field)
+	if v == "" {
+		r.fail(field, "%s is required", field)
+	}
+	return v
+}
+
+// setString overwrites dst when the field's column is present, so updates
+// keep the values of columns left out of the file.
+func (r *importRow) setString(dst *string, field string) {
+	if r.has(field) {
+		*dst = r.str(field)
+	}
+}
+
+// setInt is setString for non-negative integers. Blank cells are left alone.
+func (r *importRow) setInt(dst *int, field string) {
+	v := r.str(field)
+	if v == "" {
+		return
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		r.fail(field, "%s must be a non-negative integer, got %q", field, v)
+		return
+	}
+	*dst = n
+}
+
+// naturalKey normalizes the parts of a natural key so that case and
+// surrounding or repeated spaces don't create duplicates.
+func naturalKey(parts ...string) string {
+	for i, p := range parts {
+		parts[i] = strings.ToLower(strings.Join(strings.Fields(p), " "))
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// naturalKeys tells inserts from updates by looking rows up by natural key
+// among the existing rows and the rows seen earlier in the file.
+type naturalKeys[T any] struct {
+	existing map[string][]T
+	seen     map[string]int
+}
+
+func newNaturalKeys[T any](rows []T, key func(T) string) *naturalKeys[T] {
+	k := &naturalKeys[T]{existing: make(map[string][]T), seen: make(map[string]int)}
+	for _, row := range rows {
+		k.existing[key(row)] = append(k.existing[key(row)], row)
+	}
+	return k
+}
+
+// lookup returns the existing row with key, if any. A key repeated in the
+// file, or shared by several existing rows, is reported on row.
+func (k *naturalKeys[T]) lookup(row *importRow, field, key string) (T, bool) {
+	var zero T
+	if first, ok := k.seen[key]; ok {
+		row.fail(field, "duplicates row %d", first)
+		return zero, false
+	}
+	k.seen[key] = row.line
+
+	matches := k.existing[key]
+	switch len(matches) {
+	case 0:
+		return zero, false
+	case 1:
+		return matches[0], true
+	}
+	row.fail(field, "matches %d existing records, fix the duplicates first", len(matches))
+	return zero, false
+}
+
+// resolveByName finds the single item whose name matches value, or whose ID
+// does when value is numeric.
+func resolveByName[T any](row *importRow, field, value string, items []T, name func(T) string, id func(T) uint) (T, bool) {
+	var zero T
+	var matches []T
+	n, numeric := strconv.ParseUint(value, 10, 64)
+	for _, item := range items {
+		if (numeric == nil && id(item) == uint(n)) || naturalKey(name(item)) == naturalKey(value) {
+			matches = append(matches, item)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		row.fail(field, "%q does not exist", value)
+	case 1:
+		return matches[0], true
+	default:
+		row.fail(field, "%q is ambiguous, %d records match", value, len(matches))
+	}
+	return zero, false
+}
+
+func planBuildings(repos repositories.Repositories, rows []*importRow, _ importSettings) ([]importOp, error) {
+	existing, err := repos.Building.FindAll(domain.Page{})
+	if err != nil {
+		return nil, err
+	}
+	keys := newNaturalKeys(existing, func(b domain.Building) string { return naturalKey(b.BuildingName) })
+
+	var ops []importOp
+	for _, row := range rows {
+		name := row.required("buildingName")
+		if name == "" {
+			continue
+		}
+		current, found := keys.lookup(row, "buildingName", naturalKey(name))
+		if !row.valid() {
+			continue
+		}
+
+		building := current
+		building.BuildingName = name
+		row.setString(&building.Address, "address")
+		if !row.valid() {
+			continue
+		}
+		ops = append(ops, importOp{row: row, update: found, apply: func() error {
+			if found {
+				return repos.Building.Update(current.BuildingID, &building)
+			}
+			return repos.Building.Create(&building)
+		}})
+	}
+	return ops, nil
+}
+
+func planRooms(repos repositories.Repositories, rows []*importRow, _ importSettings) ([]importOp, error) {
+	buildings, err := repos.Building.FindAll(domain.Page{})
+	if err != nil {
+		return nil, err
+	}
+	existing, err := repos.Room.FindAll(domain.Page{})
+	if err != nil {
+		return nil, err
+	}
+	roomKey := func(buildingID uint, number string) string {
+		return naturalKey(strconv.FormatUint(uint64(buildingID), 10), number)
+	}
+	keys := newNaturalKeys(existing, func(r domain.Room) string { return roomKey(r.BuildingID, r.RoomNumber) })
+
+	var ops []importOp
+	for _, row := range rows {
+		buildingName := row.required("buildingName")
+		number := row.required("roomNumber")
+		if buildingName == "" || number == "" {
+			continue
+		}
+		building, ok := resolveByName(row, "buildingName", buildingName, buildings,
+			func(b domain.Building) string { return b.BuildingName },
+			func(b domain.Building) uint { return b.BuildingID })
+		if !ok {
+			continue
+		}
+		current, found := keys.lookup(row, "roomNumber", roomKey(building.BuildingID, number))
+		if !row.valid() {
+			continue
+		}
+
+		room := current
+		room.BuildingID = building.BuildingID
+		room.RoomNumber = number
+		row.setInt(&room.RoomCapacity, "roomCapacity")
+		row.setInt(&room.Floor, "floor")
+		if !row.valid() {
+			continue
+		}
+		ops = append(ops, importOp{row: row, update: found, apply: func() error {
+			if found {
+				return repos.Room.Update(current.RoomID, &room)
+			}
+			return repos.Room.Create(&room)
+		}})
+	}
+	return ops, nil
+}
+
+// importDateLayouts are the birth date formats accepted on import; dates are
+// stored as YYYY-MM-DD.
+var importDateLayouts = []string{"2006-01-02", "02/01/2006"}
+
+func planUsers(repos repositories.Repositories, rows []*importRow, _ importSettings) ([]importOp, error) {
+	profiles, err := repos.Profile.FindAll(domain.Page{})
+	if err != nil {
+		return nil, err
+	}
+	existing, err := repos.User.FindAll(domain.Page{})
+	if err != nil {
+		return nil, err
+	}
+	keys := newNaturalKeys(existing, func(u domain.User) string { return naturalKey(u.Email) })
+
+	var ops []importOp
+	for _, row := range rows {
+		email := row.required("email")
+		if email == "" {
+			continue
+		}
+		if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+			row.fail("email", "%q is not a valid email address", email)
+			continue
+		}
+		current, found := keys.lookup(row, "email", naturalKey(email))
+		if !row.valid() {
+			continue
+		}
+
+		user := current
+		user.Email = email
+		row.setString(&user.Nome, "nome")
+		row.setString(&user.Sex, "sex")
+		row.setString(&user.Telephone, "telephone")
+		if user.Nome == "" {
+			row.fail("nome", "nome is required")
+		}
+		if v := row.str("birthDate"); v != "" {
+			user.BirthDate = ""
+			for _, layout := range importDateLayouts {
+				if d, err := time.Parse(layout, v); err == nil {
+					user.BirthDate = d.Format("2006-01-02")
+					break
+				}
+			}
+			if user.BirthDate == "" {
+				row.fail("birthDate", "birthDate must be YYYY-MM-DD or DD/MM/YYYY, got %q", v)
+			}
+		}
+		if v := row.str("profile"); v != "" {
+			profile, ok := resolveByName(row, "profile", v, profiles,
+				func(p domain.Profile) string { return p.Role },
+				func(p domain.Profile) uint { return p.ID })
+			if ok {
+				user.ProfileID = profile.ID
+			}
+		} else if !found {
+			row.fail("profile", "profile is required for new users")
+		}
+		if !row.valid() {
+			continue
+		}
+		ops = append(ops, importOp{row: row, update: found, apply: func() error {
+			if found {
+				return repos.User.Update(current.ID, &user)
+			}
+			return repos.User.Create(&user)
+		}})
+	}
+	return ops, nil
+}
+
+var resourceStatuses = []domain.ResourceStatus{
+	domain.ResourceStatusAvailable,
+	domain.ResourceStatusUnavailable,
+	domain.ResourceStatusReserved,
+}
+
+func planResources(repos repositories.Repositories, rows []*importRow, _ importSettings) ([]importOp, error) {
+	types, err := repos.ResourceType.FindAll(domain.Page{})
+	if err != nil {
+		return nil, err
+	}
+	existing, err := repos.Resource.FindAll(domain.Page{})
+	if err != nil {
+		return nil, err
+	}
+	resourceKey := func(typeID uint, description string) string {
+		return naturalKey(strconv.FormatUint(uint64(typeID), 10), description)
+	}
+	keys := newNaturalKeys(existing, func(r domain.Resource) string { return resourceKey(r.ResourceTypeID, r.Description) })
+
+	var ops []importOp
+	for _, row := range rows {
+		description := row.required("description")
+		typeName := row.required("resourceType")
+		if description == "" || typeName == "" {
+			continue
+		}
+		resourceType, ok := resolveByName(row, "resourceType", typeName, types,
+			func(t domain.ResourceType) string { return t.Name },
+			func(t domain.ResourceType) uint { return t.ResourceTypeID })
+		if !ok {
+			continue
+		}
+		current, found := keys.lookup(row, "description", resourceKey(resourceType.ResourceTypeID, description))
+		if !row.valid() {
+			continue
+		}
+
+		resource := current
+		resource.ResourceType = nil
+		resource.ResourceTypeID = resourceType.ResourceTypeID
+		resource.Description = description
+		if v := row.str("status"); v != "" {
+			resource.Status = ""
+			for _, status := range resourceStatuses {
+				if strings.EqualFold(v, string(status)) {
+					resource.Status = status
+				}
+			}
+			if resource.Status == "" {
+				row.fail("status", "status must be one of available, unavailable, reserved, got %q", v)
+			}
+		} else if !found {
+			resource.Status = domain.ResourceStatusAvailable
+		}
+		if row.has("characteristics") {
+			resource.Characteristics = tabular.SplitList(row.str("characteristics"))
+		}
+		if !row.valid() {
+			continue
+		}
+		ops = append(ops, importOp{row: row, update: found, apply: func() error {
+			if found {
+				return repos.Resource.Update(current.ResourceID, &resource)
+			}
+			return repos.Resource.Create(&resource)
+		}})
+	}
+	return ops, nil
+}
+
+// planEnrollments imports class rosters. Classes are referred to by name or ID
+// and users by email or ID, and both must already exist; a row for an
+// existing enrollment updates its role and status. Rows that make a user
+// active in a class are checked for schedule conflicts with the user's
+// other classes, both those in the database and those in earlier rows.
+func planEnrollments(repos repositories.Repositories, rows []*importRow, settings importSettings) ([]importOp, error) {
+	classes, err := repos.Class.FindAll(domain.Page{})
+	if err != nil {
+		return nil, err
+	}
+	users, err := repos.User.FindAll(domain.Page{})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	keys := newNaturalKeys[domain.Enrollment](nil, nil)
+	// planned holds, by user, the lectures of the classes earlier rows make
+	// them active in.
+	planned := make(map[uint][]scheduledLecture)
+
+	var ops []importOp
+	for _, row := range rows {
+		className := row.required("class")
+		email := row.required("email")
+		if className == "" || email == "" {
+			continue
+		}
+		class, ok := resolveByName(row, "class", className, classes,
+			func(c domain.Class) string { return c.Name },
+			func(c domain.Class) uint { return c.ClassID })
+		if !ok {
+			continue
+		}
+		user, ok := resolveByName(row, "email", email, users,
+			func(u domain.User) string { return u.Email },
+			func(u domain.User) uint { return u.ID })
+		if !ok {
+			continue
+		}
+		keys.lookup(row, "email", naturalKey(strconv.FormatUint(uint64(class.ClassID), 10), email))
+		if !row.valid() {
+			continue
+		}
+
+		current, err := repos.Enrollment.Find(class.ClassID, user.ID)
+		found := err == nil
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		enrollment := domain.Enrollment{ClassID: class.ClassID, UserID: user.ID, Role: domain.EnrollmentRoleStudent, Status: domain.EnrollmentActive, EnrolledAt: now}
+		if found {
+			enrollment = *current
+		}
+		previous := enrollment.Status
+		if v := row.str("role"); v != "" {
+			enrollment.Role = domain.EnrollmentRole(strings.ToLower(v))
+			if !slices.Contains(enrollmentRoles, enrollment.Role) {
+				row.fail("role", "must be one of %v, got %q", enrollmentRoles, v)
+			}
+		}
+		if v := row.str("status"); v != "" {
+			enrollment.Status = domain.EnrollmentStatus(strings.ToLower(v))
+			if !slices.Contains(enrollmentStatuses, enrollment.Status) {
+				row.fail("status", "must be one of %v, got %q", enrollmentStatuses, v)
+			}
+		}
+		if !row.valid() {
+			continue
+		}
+		if err := checkCanTeach(repos.Profile, &user, enrollment.Role); errors.Is(err, domain.ErrCannotTeach) {
+			row.fail("role", "%v", err)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if enrollment.Status == domain.EnrollmentActive && (!found || previous != domain.EnrollmentActive) {
+			conflicts, scheduled, err := rosterConflicts(repos, &enrollment, planned[user.ID])
+			if err != nil {
+				return nil, err
+			}
+			warnings, err := resolveConflicts(conflicts, settings.conflicts)
+			if errors.Is(err, domain.ErrScheduleConflict) {
+				row.fail("class", "%v", err)
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			for _, w := range warnings {
+				row.warn("class", "%s", w)
+			}
+			planned[user.ID] = append(planned[user.ID], scheduled...)
+		}
+		switch {
+		case enrollment.Status == domain.EnrollmentDropped && previous != domain.EnrollmentDropped:
+			enrollment.DroppedAt = &now
+		case enrollment.Status != domain.EnrollmentDropped && previous == domain.EnrollmentDropped:
+			enrollment.EnrolledAt = now
+			enrollment.DroppedAt = nil
+		}
+		ops = append(ops, importOp{row: row, update: found, apply: func() error {
+			if found {
+				return repos.Enrollment.Update(&enrollment)
+			}
+			return repos.Enrollment.Create(&enrollment)
+		}})
+	}
+	return ops, nil
+}
+
+// rosterConflicts returns the conflicts of the enrollment with the user's
+// classes in the database and with planned, along with the class's lectures
+// that have times.
+func rosterConflicts(repos repositories.Repositories, enrollment *domain.Enrollment, planned []scheduledLecture) ([]domain.ScheduleConflict, []scheduledLecture, error) {
+	lectures, err := repos.Lecture.FindByClass(enrollment.ClassID, domain.Page{})
+	if err != nil {
+		return nil, nil, err
+	}
+	conflicts, err := userConflicts(repos.Lecture, repos.Enrollment, enrollment.UserID, enrollment.Role, lectures)
+	if err != nil {
+		return nil, nil, err
+	}
+	var scheduled []scheduledLecture
+	for _, l := range lectures {
+		sl, ok, err := schedule(l, enrollment.Role)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			continue
+		}
+		scheduled = append(scheduled, sl)
+		for _, p := range planned {
+			if sl.overlaps(p) {
+				conflicts = append(conflicts, scheduleConflict(enrollment.UserID, sl, p))
+			}
+		}
+	}
+	return conflicts, scheduled, nil
+}
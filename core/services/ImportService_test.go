@@ -0,0 +1,106 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"sarc/core/domain"
+	"sarc/core/services"
+	memimpl "sarc/infrastructure/repositories/MEMimpl"
+	"sarc/pkg/tabular"
+)
+
+func readCSV(t *testing.T, data string) *tabular.Table {
+	t.Helper()
+	table, err := tabular.Read(strings.NewReader(data), tabular.FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return table
+}
+
+func TestImportRoomsUpsertsByNaturalKey(t *testing.T) {
+	store := memimpl.NewStore()
+	repos := memimpl.NewRepositories(store)
+	building := domain.Building{BuildingName: "Prédio 32", Address: "Av. Ipiranga"}
+	if err := repos.Building.Create(&building); err != nil {
+		t.Fatal(err)
+	}
+	existing := domain.Room{BuildingID: building.BuildingID, RoomNumber: "101", RoomCapacity: 30, Floor: 1}
+	if err := repos.Room.Create(&existing); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := services.NewImportService(memimpl.NewTransactor(store), domain.ConflictPolicy{})
+	table := readCSV(t, "Prédio;Sala;Capacidade\n"+
+		"prédio 32;101;45\n"+
+		"Prédio 32;102;20\n")
+	report, err := svc.Import("rooms", table, domain.ImportOptions{
+		Mapping: map[string]string{"buildingName": "Prédio", "roomNumber": "Sala", "roomCapacity": "Capacidade"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Errors) > 0 || !report.Committed || report.Inserted != 1 || report.Updated != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	rooms, err := repos.Room.FindAll(domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rooms) != 2 {
+		t.Fatalf("got %d rooms, want 2", len(rooms))
+	}
+	if rooms[0].RoomCapacity != 45 || rooms[0].Floor != 1 {
+		t.Errorf("room 101 not updated in place: %+v", rooms[0])
+	}
+	if rooms[1].RoomNumber != "102" || rooms[1].BuildingID != building.BuildingID {
+		t.Errorf("unexpected new room: %+v", rooms[1])
+	}
+}
+
+func TestImportRejectsWholeFileOnInvalidRow(t *testing.T) {
+	store := memimpl.NewStore()
+	repos := memimpl.NewRepositories(store)
+	profile := domain.Profile{Role: "Aluno"}
+	if err := repos.Profile.Create(&profile); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := services.NewImportService(memimpl.NewTransactor(store), domain.ConflictPolicy{})
+	table := readCSV(t, "email,nome,birthDate,profile\n"+
+		"ana@example.com,Ana,2001-05-04,aluno\n"+
+		"\n"+
+		"not-an-email,Bruno,2000-01-01,Aluno\n"+
+		"carla@example.com,Carla,31/12/1999,Professor\n"+
+		"ANA@example.com,Ana Maria,,\n")
+	report, err := svc.Import("users", table, domain.ImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Committed {
+		t.Error("report says committed")
+	}
+	want := []domain.ImportRowError{
+		{Row: 4, Column: "email", Message: `"not-an-email" is not a valid email address`},
+		{Row: 5, Column: "profile", Message: `"Professor" does not exist`},
+		{Row: 6, Column: "email", Message: "duplicates row 2"},
+	}
+	if len(report.Errors) != len(want) {
+		t.Fatalf("got errors %+v, want %+v", report.Errors, want)
+	}
+	for i := range want {
+		if report.Errors[i] != want[i] {
+			t.Errorf("error %d = %+v, want %+v", i, report.Errors[i], want[i])
+		}
+	}
+
+	users, err := repos.User.FindAll(domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 0 {
+		t.Errorf("rows were written despite errors: %+v", users)
+	}
+}
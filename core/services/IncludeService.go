@@ -0,0 +1,217 @@
+package services
+
+import (
+	"sarc/core/domain"
+	interfaces "sarc/core/services/interfaces"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+// The relations each entity can include, nested as they may be requested.
+var (
+	roomIncludes        = domain.Includes{"building": {}}
+	classIncludes       = domain.Includes{"discipline": {}}
+	lectureIncludes     = domain.Includes{"class": classIncludes, "room": roomIncludes, "presence": {}}
+	resourceIncludes    = domain.Includes{"resourceType": {}}
+	reservationIncludes = domain.Includes{"lecture": lectureIncludes, "resources": resourceIncludes}
+)
+
+type includeService struct {
+	repos repositories.Repositories
+}
+
+func NewIncludeService(repos repositories.Repositories) interfaces.IncludeService {
+	return &includeService{repos: repos}
+}
+
+func (s *includeService) IncludeRooms(rooms []domain.Room, include domain.Includes) error {
+	if err := include.Check(roomIncludes); err != nil {
+		return err
+	}
+	return s.rooms(pointers(rooms), include)
+}
+
+func (s *includeService) IncludeClasses(classes []domain.Class, include domain.Includes) error {
+	if err := include.Check(classIncludes); err != nil {
+		return err
+	}
+	return s.classes(pointers(classes), include)
+}
+
+func (s *includeService) IncludeLectures(lectures []domain.Lecture, include domain.Includes) error {
+	if err := include.Check(lectureIncludes); err != nil {
+		return err
+	}
+	return s.lectures(pointers(lectures), include)
+}
+
+func (s *includeService) IncludeReservations(reservations []domain.Reservation, include domain.Includes) error {
+	if err := include.Check(reservationIncludes); err != nil {
+		return err
+	}
+	return s.reservations(pointers(reservations), include)
+}
+
+func (s *includeService) IncludeResources(resources []domain.Resource, include domain.Includes) error {
+	if err := include.Check(resourceIncludes); err != nil {
+		return err
+	}
+	return s.resources(pointers(resources), include)
+}
+
+func (s *includeService) rooms(rooms []*domain.Room, include domain.Includes) error {
+	if _, ok := include["building"]; ok {
+		buildings, err := load(rooms, func(r *domain.Room) uint { return r.BuildingID }, s.repos.Building.FindByIDs)
+		if err != nil {
+			return err
+		}
+		byID := index(buildings, func(b *domain.Building) uint { return b.BuildingID })
+		for _, r := range rooms {
+			r.Building = byID[r.BuildingID]
+		}
+	}
+	return nil
+}
+
+func (s *includeService) classes(classes []*domain.Class, include domain.Includes) error {
+	if _, ok := include["discipline"]; ok {
+		disciplines, err := load(classes, func(c *domain.Class) uint { return c.DisciplineID }, s.repos.Discipline.FindByIDs)
+		if err != nil {
+			return err
+		}
+		byID := index(disciplines, func(d *domain.Discipline) uint { return d.ID })
+		for _, c := range classes {
+			c.Discipline = byID[c.DisciplineID]
+		}
+	}
+	return nil
+}
+
+func (s *includeService) lectures(lectures []*domain.Lecture, include domain.Includes) error {
+	if sub, ok := include["class"]; ok {
+		classes, err := load(lectures, func(l *domain.Lecture) uint { return l.ClassID }, s.repos.Class.FindByIDs)
+		if err != nil {
+			return err
+		}
+		if err := s.classes(classes, sub); err != nil {
+			return err
+		}
+		byID := index(classes, func(c *domain.Class) uint { return c.ClassID })
+		for _, l := range lectures {
+			l.Class = byID[l.ClassID]
+		}
+	}
+	if sub, ok := include["room"]; ok {
+		rooms, err := load(lectures, func(l *domain.Lecture) uint { return l.RoomID }, s.repos.Room.FindByIDs)
+		if err != nil {
+			return err
+		}
+		if err := s.rooms(rooms, sub); err != nil {
+			return err
+		}
+		byID := index(rooms, func(r *domain.Room) uint { return r.RoomID })
+		for _, l := range lectures {
+			l.Room = byID[l.RoomID]
+		}
+	}
+	if _, ok := include["presence"]; ok && len(lectures) > 0 {
+		ids := make([]uint, len(lectures))
+		for i, l := range lectures {
+			ids[i] = l.LectureID
+		}
+		presence, err := s.repos.Presence.FindByLectures(ids)
+		if err != nil {
+			return err
+		}
+		byLecture := make(map[uint][]domain.User)
+		for _, p := range presence {
+			byLecture[p.LectureID] = append(byLecture[p.LectureID], *p.User)
+		}
+		for _, l := range lectures {
+			l.Presence = byLecture[l.LectureID]
+		}
+	}
+	return nil
+}
+
+func (s *includeService) reservations(reservations []*domain.Reservation, include domain.Includes) error {
+	if sub, ok := include["lecture"]; ok {
+		lectures, err := load(reservations, func(r *domain.Reservation) uint { return r.LectureID }, s.repos.Lecture.FindByIDs)
+		if err != nil {
+			return err
+		}
+		if err := s.lectures(lectures, sub); err != nil {
+			return err
+		}
+		byID := index(lectures, func(l *domain.Lecture) uint { return l.LectureID })
+		for _, r := range reservations {
+			r.Lecture = byID[r.LectureID]
+		}
+	}
+	// Resources always come with a reservation; only their relations need loading
+	if sub, ok := include["resources"]; ok {
+		var resources []*domain.Resource
+		for _, r := range reservations {
+			resources = append(resources, pointers(r.Resources)...)
+		}
+		return s.resources(resources, sub)
+	}
+	return nil
+}
+
+func (s *includeService) resources(resources []*domain.Resource, include domain.Includes) error {
+	if _, ok := include["resourceType"]; ok {
+		var missing []*domain.Resource
+		for _, r := range resources {
+			if r.ResourceType == nil {
+				missing = append(missing, r)
+			}
+		}
+		types, err := load(missing, func(r *domain.Resource) uint { return r.ResourceTypeID }, s.repos.ResourceType.FindByIDs)
+		if err != nil {
+			return err
+		}
+		byID := index(types, func(t *domain.ResourceType) uint { return t.ResourceTypeID })
+		for _, r := range missing {
+			r.ResourceType = byID[r.ResourceTypeID]
+		}
+	}
+	return nil
+}
+
+// load collects the distinct non-zero keys of rows and fetches the records
+// they reference with a single find call.
+func load[R, T any](rows []*R, key func(*R) uint, find func([]uint) ([]T, error)) ([]*T, error) {
+	seen := make(map[uint]bool)
+	var ids []uint
+	for _, row := range rows {
+		if k := key(row); k != 0 && !seen[k] {
+			seen[k] = true
+			ids = append(ids, k)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	found, err := find(ids)
+	if err != nil {
+		return nil, err
+	}
+	return pointers(found), nil
+}
+
+func index[T any](rows []*T, id func(*T) uint) map[uint]*T {
+	out := make(map[uint]*T, len(rows))
+	for _, row := range rows {
+		out[id(row)] = row
+	}
+	return out
+}
+
+// pointers returns pointers to the elements of s, so they can be filled in place.
+func pointers[T any](s []T) []*T {
+	out := make([]*T, len(s))
+	for i := range s {
+		out[i] = &s[i]
+	}
+	return out
+}
@@ -1,29 +1,67 @@
 package services
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"math"
 	"sarc/core/domain"
 	interfaces "sarc/core/services/interfaces"
 	repositories "sarc/infrastructure/repositories/interfaces"
+	"sort"
+	"time"
 )
 
 type lectureService struct {
-	repo repositories.LectureRepository
+	repo            repositories.LectureRepository
+	reservationRepo repositories.ReservationRepository
+	roomRepo        repositories.RoomRepository
+	enrollmentRepo  repositories.EnrollmentRepository
+	capacity        domain.CapacityPolicy
+	conflicts       domain.ConflictPolicy
+	loc             *time.Location
+	now             func() time.Time
 }
 
-func NewLectureService(repo repositories.LectureRepository) interfaces.LectureService {
-	return &lectureService{repo: repo}
+func NewLectureService(repo repositories.LectureRepository, reservationRepo repositories.ReservationRepository, roomRepo repositories.RoomRepository, enrollmentRepo repositories.EnrollmentRepository, capacity domain.CapacityPolicy, conflicts domain.ConflictPolicy, loc *time.Location) interfaces.LectureService {
+	return &lectureService{
+		repo:            repo,
+		reservationRepo: reservationRepo,
+		roomRepo:        roomRepo,
+		enrollmentRepo:  enrollmentRepo,
+		capacity:        capacity,
+		conflicts:       conflicts,
+		loc:             loc,
+		now:             time.Now,
+	}
 }
 
 func (s *lectureService) CreateLecture(lecture *domain.Lecture) (*domain.Lecture, error) {
+	if _, _, err := lecture.Interval(time.UTC); err != nil {
+		return nil, err
+	}
+	warnings, err := s.checkCapacity(lecture)
+	if err != nil {
+		return nil, err
+	}
+	conflicts, err := s.checkTeachers(lecture)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, conflicts...)
 	if err := s.repo.Create(lecture); err != nil {
 		return nil, err
 	}
+	lecture.Warnings = warnings
 	return lecture, nil
 }
 
-func (s *lectureService) GetLectures() ([]domain.Lecture, error) {
-	return s.repo.FindAll()
+func (s *lectureService) GetLectures(page domain.Page) ([]domain.Lecture, error) {
+	return s.repo.FindAll(page)
+}
+
+func (s *lectureService) StreamLectures(fn func(domain.Lecture) error) error {
+	return s.repo.StreamAll(fn)
 }
 
 func (s *lectureService) GetLectureByID(id uint) (*domain.Lecture, error) {
@@ -38,12 +76,166 @@ func (s *lectureService) GetLectureByID(id uint) (*domain.Lecture, error) {
 }
 
 func (s *lectureService) UpdateLecture(id uint, updated *domain.Lecture) (*domain.Lecture, error) {
+	if _, _, err := updated.Interval(time.UTC); err != nil {
+		return nil, err
+	}
+	// Only moving a lecture to another room, class or time is checked, so
+	// lectures already over capacity or in conflict can still be edited.
+	var warnings []string
+	current, err := s.repo.FindByID(id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if current == nil || current.RoomID != updated.RoomID || current.ClassID != updated.ClassID {
+		if warnings, err = s.checkCapacity(updated); err != nil {
+			return nil, err
+		}
+	}
+	if current == nil || current.ClassID != updated.ClassID || current.Date != updated.Date ||
+		current.StartTime != updated.StartTime || current.EndTime != updated.EndTime {
+		moved := *updated
+		moved.LectureID = id
+		conflicts, err := s.checkTeachers(&moved)
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, conflicts...)
+	}
 	if err := s.repo.Update(id, updated); err != nil {
 		return nil, err
 	}
-	return s.repo.FindByID(id)
+	lecture, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	lecture.Warnings = warnings
+	return lecture, nil
 }
 
 func (s *lectureService) DeleteLecture(id uint) error {
 	return s.repo.Delete(id)
 }
+
+func (s *lectureService) GetLectureReservations(id uint, page domain.Page) ([]domain.Reservation, error) {
+	if _, err := s.GetLectureByID(id); err != nil {
+		return nil, err
+	}
+	return s.reservationRepo.FindByLecture(id, page)
+}
+
+func (s *lectureService) GetCapacityReport(from, to string) ([]domain.LectureCapacity, error) {
+	now := s.now()
+	first, last, err := dateRange(from, to, now.In(s.loc), termDays)
+	if err != nil {
+		return nil, err
+	}
+	lectures, err := s.repo.FindBetween(first, last)
+	if err != nil {
+		return nil, err
+	}
+	var roomIDs, classIDs []uint
+	for _, l := range lectures {
+		roomIDs = append(roomIDs, l.RoomID)
+		classIDs = append(classIDs, l.ClassID)
+	}
+	rooms, err := s.roomRepo.FindByIDs(uniqueIDs(roomIDs))
+	if err != nil {
+		return nil, err
+	}
+	seats := make(map[uint]int, len(rooms))
+	for _, r := range rooms {
+		seats[r.RoomID] = r.RoomCapacity
+	}
+	headcounts, err := s.enrollmentRepo.CountStudents(uniqueIDs(classIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	starts := make(map[uint]time.Time)
+	report := []domain.LectureCapacity{}
+	for _, l := range lectures {
+		start, end, err := l.Interval(s.loc)
+		if err != nil {
+			return nil, fmt.Errorf("lecture %d: %w", l.LectureID, err)
+		}
+		if !end.After(now) {
+			continue
+		}
+		c := lectureCapacity(l, seats[l.RoomID], headcounts[l.ClassID])
+		if c.RoomCapacity == 0 || c.Enrolled == 0 {
+			continue
+		}
+		switch {
+		case c.Enrolled > c.RoomCapacity:
+			c.Issue = domain.CapacityOver
+		case c.Occupancy < s.capacity.UnderusedPercentage:
+			c.Issue = domain.CapacityUnderused
+		default:
+			continue
+		}
+		starts[l.LectureID] = start
+		report = append(report, c)
+	}
+	sort.SliceStable(report, func(i, j int) bool {
+		return starts[report[i].Lecture.LectureID].Before(starts[report[j].Lecture.LectureID])
+	})
+	return report, nil
+}
+
+// checkCapacity rejects putting lecture in a room with fewer seats than its
+// class has students, or only warns under a soft limit. Rooms without a
+// capacity set are not checked.
+func (s *lectureService) checkCapacity(lecture *domain.Lecture) ([]string, error) {
+	room, err := s.roomRepo.FindByID(lecture.RoomID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if room.RoomCapacity == 0 {
+		return nil, nil
+	}
+	headcounts, err := s.enrollmentRepo.CountStudents([]uint{lecture.ClassID})
+	if err != nil {
+		return nil, err
+	}
+	enrolled := headcounts[lecture.ClassID]
+	if enrolled <= room.RoomCapacity {
+		return nil, nil
+	}
+	err = fmt.Errorf("%w: room %s seats %d, class %d has %d", domain.ErrRoomOverCapacity, room.RoomNumber, room.RoomCapacity, lecture.ClassID, enrolled)
+	if !s.capacity.SoftLimit {
+		return nil, err
+	}
+	return []string{err.Error()}, nil
+}
+
+// checkTeachers rejects a lecture that puts one of its class's teachers in
+// two lectures at once, or only warns under a soft limit.
+func (s *lectureService) checkTeachers(lecture *domain.Lecture) ([]string, error) {
+	enrollments, err := s.enrollmentRepo.FindByClass(lecture.ClassID)
+	if err != nil {
+		return nil, err
+	}
+	var conflicts []domain.ScheduleConflict
+	for _, e := range enrollments {
+		if e.Role != domain.EnrollmentRoleTeacher || e.Status != domain.EnrollmentActive {
+			continue
+		}
+		found, err := userConflicts(s.repo, s.enrollmentRepo, e.UserID, e.Role, []domain.Lecture{*lecture})
+		if err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, found...)
+	}
+	return resolveConflicts(conflicts, s.conflicts)
+}
+
+func lectureCapacity(lecture domain.Lecture, seats, enrolled int) domain.LectureCapacity {
+	c := domain.LectureCapacity{Lecture: lecture, RoomCapacity: seats, Enrolled: enrolled}
+	if seats > 0 {
+		c.Occupancy = math.Round(1000*float64(enrolled)/float64(seats)) / 10
+	}
+	return c
+}
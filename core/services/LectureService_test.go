@@ -0,0 +1,73 @@
+package services_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"sarc/core/domain"
+	"sarc/core/services"
+	memimpl "sarc/infrastructure/repositories/MEMimpl"
+)
+
+func TestLectureCapacity(t *testing.T) {
+	repos := memimpl.NewRepositories(memimpl.NewStore())
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	profile := domain.Profile{Role: "student"}
+	must(repos.Profile.Create(&profile))
+	building := domain.Building{BuildingName: "Prédio 32"}
+	must(repos.Building.Create(&building))
+	small := domain.Room{RoomNumber: "101", BuildingID: building.BuildingID, RoomCapacity: 2}
+	must(repos.Room.Create(&small))
+	hall := domain.Room{RoomNumber: "Auditório", BuildingID: building.BuildingID, RoomCapacity: 100}
+	must(repos.Room.Create(&hall))
+	discipline := domain.Discipline{Name: "Cálculo I", Credits: 4}
+	must(repos.Discipline.Create(&discipline))
+	class := domain.Class{Name: "Turma 10", DisciplineID: discipline.ID}
+	must(repos.Class.Create(&class))
+	// Three active students; a dropped one does not take a seat.
+	for i, status := range []domain.EnrollmentStatus{domain.EnrollmentActive, domain.EnrollmentActive, domain.EnrollmentActive, domain.EnrollmentDropped} {
+		user := domain.User{Email: fmt.Sprintf("s%d@example.com", i), Nome: fmt.Sprintf("S%d", i), ProfileID: profile.ID}
+		must(repos.User.Create(&user))
+		must(repos.Enrollment.Create(&domain.Enrollment{ClassID: class.ClassID, UserID: user.ID, Role: domain.EnrollmentRoleStudent, Status: status, EnrolledAt: time.Now()}))
+	}
+
+	strict := services.NewLectureService(repos.Lecture, repos.Reservation, repos.Room, repos.Enrollment, domain.CapacityPolicy{UnderusedPercentage: 40}, domain.ConflictPolicy{}, time.UTC)
+	if _, err := strict.CreateLecture(&domain.Lecture{ClassID: class.ClassID, RoomID: small.RoomID, Date: "2999-03-01"}); !errors.Is(err, domain.ErrRoomOverCapacity) {
+		t.Fatalf("strict limit: got %v, want %v", err, domain.ErrRoomOverCapacity)
+	}
+	inHall, err := strict.CreateLecture(&domain.Lecture{ClassID: class.ClassID, RoomID: hall.RoomID, Date: "2999-03-02"})
+	must(err)
+	moved := *inHall
+	moved.RoomID = small.RoomID
+	if _, err := strict.UpdateLecture(inHall.LectureID, &moved); !errors.Is(err, domain.ErrRoomOverCapacity) {
+		t.Errorf("moving to a small room: got %v, want %v", err, domain.ErrRoomOverCapacity)
+	}
+
+	soft := services.NewLectureService(repos.Lecture, repos.Reservation, repos.Room, repos.Enrollment, domain.CapacityPolicy{SoftLimit: true, UnderusedPercentage: 40}, domain.ConflictPolicy{}, time.UTC)
+	crowded, err := soft.CreateLecture(&domain.Lecture{ClassID: class.ClassID, RoomID: small.RoomID, Date: "2999-03-01"})
+	must(err)
+	if len(crowded.Warnings) != 1 {
+		t.Errorf("soft limit: expected a warning, got %v", crowded.Warnings)
+	}
+	_, err = soft.CreateLecture(&domain.Lecture{ClassID: class.ClassID, RoomID: small.RoomID, Date: "2020-03-01"})
+	must(err)
+
+	report, err := soft.GetCapacityReport("2999-01-01", "2999-12-31")
+	must(err)
+	if len(report) != 2 {
+		t.Fatalf("expected the two upcoming lectures, got %+v", report)
+	}
+	if report[0].Lecture.LectureID != crowded.LectureID || report[0].Issue != domain.CapacityOver || report[0].Enrolled != 3 || report[0].Occupancy != 150 {
+		t.Errorf("unexpected first entry: %+v", report[0])
+	}
+	if report[1].Lecture.LectureID != inHall.LectureID || report[1].Issue != domain.CapacityUnderused || report[1].Occupancy != 3 {
+		t.Errorf("unexpected second entry: %+v", report[1])
+	}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"sarc/core/domain"
+	interfaces "sarc/core/services/interfaces"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type presenceService struct {
+	repo        repositories.PresenceRepository
+	lectureRepo repositories.LectureRepository
+	userRepo    repositories.UserRepository
+	now         func() time.Time
+}
+
+func NewPresenceService(repo repositories.PresenceRepository, lectureRepo repositories.LectureRepository, userRepo repositories.UserRepository) interfaces.PresenceService {
+	return &presenceService{repo: repo, lectureRepo: lectureRepo, userRepo: userRepo, now: time.Now}
+}
+
+func (s *presenceService) GetPresence(lectureID uint) ([]domain.Presence, error) {
+	if err := s.checkLecture(lectureID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByLectures([]uint{lectureID})
+}
+
+func (s *presenceService) MarkPresence(lectureID uint, userID uint) (*domain.Presence, bool, error) {
+	return s.markOne(lectureID, userID, domain.PresenceMethodManual)
+}
+
+func (s *presenceService) MarkPresenceBulk(lectureID uint, userIDs []uint) (*domain.PresenceMarkResult, error) {
+	unique := uniqueIDs(userIDs)
+	if len(unique) == 0 {
+		return nil, errors.New("userIds is empty")
+	}
+	added, err := s.mark(lectureID, unique, domain.PresenceMethodRollCall)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PresenceMarkResult{Marked: added, AlreadyPresent: len(unique) - added}, nil
+}
+
+func (s *presenceService) UnmarkPresence(lectureID uint, userID uint) error {
+	if err := s.checkLecture(lectureID); err != nil {
+		return err
+	}
+	return s.repo.Unmark(lectureID, userID)
+}
+
+// markOne marks a single user and returns their presence record.
+func (s *presenceService) markOne(lectureID uint, userID uint, method domain.PresenceMethod) (*domain.Presence, bool, error) {
+	added, err := s.mark(lectureID, []uint{userID}, method)
+	if err != nil {
+		return nil, false, err
+	}
+	presence, err := s.repo.FindByLectures([]uint{lectureID})
+	if err != nil {
+		return nil, false, err
+	}
+	for _, p := range presence {
+		if p.UserID == userID {
+			return &p, added == 1, nil
+		}
+	}
+	return nil, false, fmt.Errorf("presence of user %d was not recorded", userID)
+}
+
+// mark checks the lecture and users exist, so callers get a clear error
+// instead of a foreign key violation, then records them.
+func (s *presenceService) mark(lectureID uint, userIDs []uint, method domain.PresenceMethod) (int, error) {
+	if err := s.checkLecture(lectureID); err != nil {
+		return 0, err
+	}
+	users, err := s.userRepo.FindByIDs(userIDs)
+	if err != nil {
+		return 0, err
+	}
+	if len(users) != len(userIDs) {
+		found := make(map[uint]bool, len(users))
+		for _, u := range users {
+			found[u.ID] = true
+		}
+		var missing []uint
+		for _, id := range userIDs {
+			if !found[id] {
+				missing = append(missing, id)
+			}
+		}
+		return 0, fmt.Errorf("%w: %v", domain.ErrUserNotFound, missing)
+	}
+	return s.repo.Mark(lectureID, userIDs, method, s.now())
+}
+
+func (s *presenceService) checkLecture(lectureID uint) error {
+	_, err := s.lectureRepo.FindByID(lectureID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrLectureNotFound
+	}
+	return err
+}
+
+// uniqueIDs drops zero and repeated IDs, keeping the first occurrence order.
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	var out []uint
+	for _, id := range ids {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
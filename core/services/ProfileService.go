@@ -22,8 +22,8 @@ func (s *profileService) CreateProfile(profile *domain.Profile) (*domain.Profile
 	return profile, nil
 }
 
-func (s *profileService) GetProfiles() ([]domain.Profile, error) {
-	return s.repo.FindAll()
+func (s *profileService) GetProfiles(page domain.Page) ([]domain.Profile, error) {
+	return s.repo.FindAll(page)
 }
 
 func (s *profileService) GetProfileByID(id uint) (*domain.Profile, error) {
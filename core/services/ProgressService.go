@@ -0,0 +1,351 @@
+package services
+
+import (
+	"cmp"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"sarc/core/domain"
+	interfaces "sarc/core/services/interfaces"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type progressService struct {
+	repos        repositories.Repositories
+	equivalences interfaces.EquivalenceService
+	maxCredits   int
+	loc          *time.Location
+	now          func() time.Time
+}
+
+// NewProgressService caps study plans at maxCredits when the caller does not
+// pick a cap; 0 means no cap.
+func NewProgressService(repos repositories.Repositories, equivalences interfaces.EquivalenceService, maxCredits int, loc *time.Location) interfaces.ProgressService {
+	return &progressService{repos: repos, equivalences: equivalences, maxCredits: maxCredits, loc: loc, now: time.Now}
+}
+
+func (s *progressService) GetProgress(userID uint, curriculumID uint) (*domain.CurriculumProgress, error) {
+	progress, _, err := s.progress(userID, curriculumID)
+	return progress, err
+}
+
+// GetStudyPlan goes through the unlocked disciplines from the earliest
+// semester, mandatory ones first, and plans the first class of each that
+// fits under the cap and does not overlap the classes planned so far.
+// Disciplines linked by co-requisites are planned together or not at all.
+func (s *progressService) GetStudyPlan(userID uint, curriculumID uint, requested *int) (*domain.StudyPlan, error) {
+	maxCredits := s.maxCredits
+	if requested != nil {
+		maxCredits = *requested
+	}
+	if maxCredits < 0 {
+		return nil, fmt.Errorf("%w: maxCredits cannot be negative, got %d", domain.ErrInvalidStudyPlan, maxCredits)
+	}
+	progress, requisites, err := s.progress(userID, curriculumID)
+	if err != nil {
+		return nil, err
+	}
+	plan := &domain.StudyPlan{
+		UserID:       userID,
+		CurriculumID: curriculumID,
+		MaxCredits:   maxCredits,
+		Classes:      []domain.PlannedClass{},
+		Skipped:      []domain.SkippedDiscipline{},
+	}
+	// Co-requisites outside a group must be completed or being taken.
+	ready := make(map[uint]bool)
+	for _, c := range progress.Completed {
+		ready[c.Discipline.ID] = true
+	}
+	for _, d := range progress.InProgress {
+		ready[d.ID] = true
+	}
+	names := make(map[uint]string)
+	for _, l := range progress.Locked {
+		names[l.Discipline.ID] = l.Discipline.Name
+	}
+	candidates := make(map[uint]domain.Discipline, len(progress.Unlocked))
+	for _, d := range progress.Unlocked {
+		candidates[d.ID] = d
+		names[d.ID] = d.Name
+	}
+	linked := make(map[uint][]uint)
+	for _, q := range requisites {
+		_, a := candidates[q.DisciplineID]
+		_, b := candidates[q.RequisiteID]
+		if q.Kind == domain.RequisiteCorequisite && a && b {
+			linked[q.DisciplineID] = append(linked[q.DisciplineID], q.RequisiteID)
+			linked[q.RequisiteID] = append(linked[q.RequisiteID], q.DisciplineID)
+		}
+	}
+
+	ordered := slices.Clone(progress.Unlocked)
+	slices.SortStableFunc(ordered, planOrder)
+	today := s.now().In(s.loc).Format("2006-01-02")
+	var busy []scheduledLecture
+	grouped := make(map[uint]bool)
+	for _, first := range ordered {
+		if grouped[first.ID] {
+			continue
+		}
+		group := []domain.Discipline{first}
+		grouped[first.ID] = true
+		for i := 0; i < len(group); i++ {
+			for _, id := range linked[group[i].ID] {
+				if !grouped[id] {
+					grouped[id] = true
+					group = append(group, candidates[id])
+				}
+			}
+		}
+		slices.SortStableFunc(group, planOrder)
+		skip := func(reason func(d domain.Discipline) string) {
+			for _, d := range group {
+				plan.Skipped = append(plan.Skipped, domain.SkippedDiscipline{Discipline: d, Reason: reason(d)})
+			}
+		}
+
+		if r := missingCorequisite(group, requisites, ready); r != 0 {
+			skip(func(domain.Discipline) string { return fmt.Sprintf("%s must be taken in the same semester", names[r]) })
+			continue
+		}
+		credits := 0
+		for _, d := range group {
+			credits += d.Credits
+		}
+		if maxCredits > 0 && plan.Credits+credits > maxCredits {
+			skip(func(d domain.Discipline) string {
+				if len(group) == 1 {
+					return fmt.Sprintf("its %d credits would take the plan over %d", credits, maxCredits)
+				}
+				return fmt.Sprintf("with its co-requisites, %d credits would take the plan over %d", credits, maxCredits)
+			})
+			continue
+		}
+		picked, lectures, failed, reason, err := s.pickClasses(group, busy, today)
+		if err != nil {
+			return nil, err
+		}
+		if picked == nil {
+			skip(func(d domain.Discipline) string {
+				if d.ID == failed.ID {
+					return reason
+				}
+				return fmt.Sprintf("must be taken with %s: %s", failed.Name, reason)
+			})
+			continue
+		}
+		plan.Classes = append(plan.Classes, picked...)
+		plan.Credits += credits
+		busy = append(busy, lectures...)
+	}
+	return plan, nil
+}
+
+// pickClasses picks a class for each discipline of group in turn, none
+// overlapping busy or each other. When one has no class to pick it returns
+// that discipline and why instead.
+func (s *progressService) pickClasses(group []domain.Discipline, busy []scheduledLecture, today string) ([]domain.PlannedClass, []scheduledLecture, domain.Discipline, string, error) {
+	var picked []domain.PlannedClass
+	var added []scheduledLecture
+	for _, d := range group {
+		class, lectures, reason, err := s.pickClass(d.ID, append(slices.Clip(busy), added...), today)
+		if err != nil || class == nil {
+			return nil, nil, d, reason, err
+		}
+		picked = append(picked, domain.PlannedClass{Discipline: d, Class: *class})
+		added = append(added, lectures...)
+	}
+	return picked, added, domain.Discipline{}, "", nil
+}
+
+// progress also returns the curriculum's requisites.
+func (s *progressService) progress(userID uint, curriculumID uint) (*domain.CurriculumProgress, []domain.Requisite, error) {
+	if _, err := s.repos.User.FindByID(userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, domain.ErrUserNotFound
+		}
+		return nil, nil, err
+	}
+	records, err := s.repos.Record.FindByUser(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var approved []uint
+	for _, r := range records {
+		if r.Status == domain.ResultApproved && !slices.Contains(approved, r.DisciplineID) {
+			approved = append(approved, r.DisciplineID)
+		}
+	}
+	transfer, err := s.equivalences.TransferCredits(curriculumID, approved)
+	if err != nil {
+		return nil, nil, err
+	}
+	requisites, err := s.repos.Curriculum.FindRequisites(curriculumID)
+	if err != nil {
+		return nil, nil, err
+	}
+	taking, err := s.taking(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	progress := &domain.CurriculumProgress{
+		UserID:           userID,
+		CurriculumID:     curriculumID,
+		Completed:        transfer.Credited,
+		InProgress:       []domain.Discipline{},
+		Unlocked:         []domain.Discipline{},
+		Locked:           []domain.LockedDiscipline{},
+		CreditsCompleted: transfer.Credits,
+	}
+	credited := make(map[uint]bool)
+	for _, c := range transfer.Credited {
+		credited[c.Discipline.ID] = true
+		progress.CreditsTotal += c.Discipline.Credits
+	}
+	pending := make(map[uint]domain.Discipline)
+	for _, d := range transfer.Pending {
+		pending[d.ID] = d
+	}
+	for _, d := range transfer.Pending {
+		progress.CreditsTotal += d.Credits
+		progress.CreditsRemaining += d.Credits
+		if !d.Elective {
+			progress.MandatoryCreditsRemaining += d.Credits
+		}
+		if taking[d.ID] {
+			progress.InProgress = append(progress.InProgress, d)
+			continue
+		}
+		missing := []domain.Discipline{}
+		for _, q := range requisites {
+			if q.DisciplineID == d.ID && q.Kind == domain.RequisitePrerequisite && !credited[q.RequisiteID] {
+				missing = append(missing, pending[q.RequisiteID])
+			}
+		}
+		if len(missing) == 0 {
+			progress.Unlocked = append(progress.Unlocked, d)
+		} else {
+			progress.Locked = append(progress.Locked, domain.LockedDiscipline{Discipline: d, Missing: missing})
+		}
+	}
+	return progress, requisites, nil
+}
+
+// taking returns the disciplines of the classes the user is an active
+// student of.
+func (s *progressService) taking(userID uint) (map[uint]bool, error) {
+	enrollments, err := s.repos.Enrollment.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	var classIDs []uint
+	for _, e := range enrollments {
+		if e.Role == domain.EnrollmentRoleStudent && e.Status == domain.EnrollmentActive {
+			classIDs = append(classIDs, e.ClassID)
+		}
+	}
+	classes, err := s.repos.Class.FindByIDs(classIDs)
+	if err != nil {
+		return nil, err
+	}
+	taking := make(map[uint]bool, len(classes))
+	for _, c := range classes {
+		taking[c.DisciplineID] = true
+	}
+	return taking, nil
+}
+
+// pickClass returns the first class of the discipline that is offered, with
+// lectures still to come or none scheduled yet, and whose upcoming lectures
+// do not overlap busy. Otherwise it says why none was picked.
+func (s *progressService) pickClass(disciplineID uint, busy []scheduledLecture, today string) (*domain.Class, []scheduledLecture, string, error) {
+	classes, err := s.repos.Class.FindByDiscipline(disciplineID, domain.Page{})
+	if err != nil {
+		return nil, nil, "", err
+	}
+	classIDs := make([]uint, len(classes))
+	for i, c := range classes {
+		classIDs[i] = c.ClassID
+	}
+	all, err := s.repos.Lecture.FindByClasses(classIDs, "", "")
+	if err != nil {
+		return nil, nil, "", err
+	}
+	byClass := make(map[uint][]domain.Lecture)
+	for _, l := range all {
+		byClass[l.ClassID] = append(byClass[l.ClassID], l)
+	}
+	offered := 0
+	for i := range classes {
+		lectures := byClass[classes[i].ClassID]
+		var upcoming []scheduledLecture
+		future := len(lectures) == 0
+		for _, l := range lectures {
+			if lectureDay(l) < today {
+				continue
+			}
+			future = true
+			sl, ok, err := schedule(l, domain.EnrollmentRoleStudent)
+			if err != nil {
+				return nil, nil, "", err
+			}
+			if ok {
+				upcoming = append(upcoming, sl)
+			}
+		}
+		if !future {
+			continue
+		}
+		offered++
+		conflicts := slices.ContainsFunc(upcoming, func(l scheduledLecture) bool { return slices.ContainsFunc(busy, l.overlaps) })
+		if !conflicts {
+			return &classes[i], upcoming, "", nil
+		}
+	}
+	if offered == 0 {
+		return nil, nil, "no class is offered", nil
+	}
+	return nil, nil, fmt.Sprintf("every class offered (%d) overlaps the classes planned", offered), nil
+}
+
+// missingCorequisite returns a co-requisite of a discipline in group that is
+// neither in the group nor ready, or 0 when there is none.
+func missingCorequisite(group []domain.Discipline, requisites []domain.Requisite, ready map[uint]bool) uint {
+	for _, d := range group {
+		for _, q := range requisites {
+			if q.DisciplineID != d.ID || q.Kind != domain.RequisiteCorequisite || ready[q.RequisiteID] {
+				continue
+			}
+			if !slices.ContainsFunc(group, func(g domain.Discipline) bool { return g.ID == q.RequisiteID }) {
+				return q.RequisiteID
+			}
+		}
+	}
+	return 0
+}
+
+// planOrder sorts disciplines by semester, mandatory ones first.
+func planOrder(a, b domain.Discipline) int {
+	return cmp.Or(cmp.Compare(semesterOrder(a), semesterOrder(b)), cmp.Compare(boolOrder(a.Elective), boolOrder(b.Elective)))
+}
+
+// semesterOrder puts disciplines not placed in a semester last.
+func semesterOrder(d domain.Discipline) int {
+	if d.Semester == 0 {
+		return math.MaxInt
+	}
+	return d.Semester
+}
+
+func boolOrder(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,193 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"sarc/core/domain"
+	"sarc/core/services"
+	memimpl "sarc/infrastructure/repositories/MEMimpl"
+)
+
+func TestProgressAndStudyPlan(t *testing.T) {
+	store := memimpl.NewStore()
+	repos := memimpl.NewRepositories(store)
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	ids := make(map[string]uint)
+	for _, d := range []domain.Discipline{
+		{Name: "Cálculo I", Credits: 4},
+		{Name: "Algoritmos", Credits: 4},
+		{Name: "Inglês", Credits: 2},
+		{Name: "Cálculo II", Credits: 4},
+		{Name: "Laboratório de Física", Credits: 2},
+		{Name: "Física I", Credits: 4},
+		{Name: "Estruturas de Dados", Credits: 4},
+	} {
+		must(repos.Discipline.Create(&d))
+		ids[d.Name] = d.ID
+	}
+	curriculums := services.NewCurriculumService(repos.Curriculum, repos.Discipline, memimpl.NewTransactor(store), domain.CreditLimits{})
+	curriculum, err := curriculums.CreateCurriculum(&domain.Curriculum{
+		CourseName: "Engenharia",
+		Disciplines: []domain.Discipline{
+			{ID: ids["Cálculo I"], Semester: 1},
+			{ID: ids["Algoritmos"], Semester: 1},
+			{ID: ids["Inglês"], Semester: 1, Elective: true},
+			{ID: ids["Cálculo II"], Semester: 2},
+			{ID: ids["Laboratório de Física"], Semester: 2},
+			{ID: ids["Física I"], Semester: 2},
+			{ID: ids["Estruturas de Dados"], Semester: 2},
+		},
+	})
+	must(err)
+	for _, q := range []domain.Requisite{
+		{DisciplineID: ids["Cálculo II"], RequisiteID: ids["Cálculo I"], Kind: domain.RequisitePrerequisite},
+		{DisciplineID: ids["Física I"], RequisiteID: ids["Cálculo I"], Kind: domain.RequisitePrerequisite},
+		{DisciplineID: ids["Laboratório de Física"], RequisiteID: ids["Física I"], Kind: domain.RequisiteCorequisite},
+		{DisciplineID: ids["Estruturas de Dados"], RequisiteID: ids["Algoritmos"], Kind: domain.RequisitePrerequisite},
+	} {
+		q.CurriculumID = curriculum.ID
+		_, err := curriculums.AddRequisite(&q)
+		must(err)
+	}
+
+	profile := domain.Profile{Role: "student"}
+	must(repos.Profile.Create(&profile))
+	building := domain.Building{BuildingName: "Prédio 32"}
+	must(repos.Building.Create(&building))
+	room := domain.Room{RoomNumber: "101", BuildingID: building.BuildingID}
+	must(repos.Room.Create(&room))
+	student := domain.User{Email: "s@example.com", Nome: "S", ProfileID: profile.ID}
+	must(repos.User.Create(&student))
+
+	// class creates a class of the discipline with one lecture per window.
+	class := func(discipline string, windows ...[3]string) domain.Class {
+		t.Helper()
+		c := domain.Class{Name: discipline, DisciplineID: ids[discipline]}
+		must(repos.Class.Create(&c))
+		for _, w := range windows {
+			must(repos.Lecture.Create(&domain.Lecture{ClassID: c.ClassID, RoomID: room.RoomID, Date: w[0], StartTime: w[1], EndTime: w[2]}))
+		}
+		return c
+	}
+	passed := class("Cálculo I", [3]string{"2000-03-01", "08:00", "10:00"})
+	must(repos.Record.Save(&domain.AcademicRecord{UserID: student.ID, ClassID: passed.ClassID, DisciplineID: ids["Cálculo I"], FinalGrade: 8, Attendance: 100, Status: domain.ResultApproved, CompletedAt: time.Now()}))
+	taking := class("Algoritmos", [3]string{"2099-03-01", "08:00", "10:00"})
+	must(repos.Enrollment.Create(&domain.Enrollment{ClassID: taking.ClassID, UserID: student.ID, Role: domain.EnrollmentRoleStudent, Status: domain.EnrollmentActive, EnrolledAt: time.Now()}))
+	class("Inglês", [3]string{"2000-03-01", "14:00", "16:00"})
+	class("Cálculo II", [3]string{"2099-03-02", "08:00", "10:00"})
+	class("Física I", [3]string{"2099-03-02", "09:00", "11:00"})
+	physics := class("Física I", [3]string{"2099-03-03", "08:00", "10:00"})
+	class("Laboratório de Física", [3]string{"2099-03-03", "10:00", "12:00"})
+
+	svc := services.NewProgressService(repos, services.NewEquivalenceService(repos.Equivalence, repos.Discipline, repos.Curriculum, memimpl.NewTransactor(store)), 12, time.UTC)
+	progress, err := svc.GetProgress(student.ID, curriculum.ID)
+	must(err)
+	if len(progress.Completed) != 1 || len(progress.InProgress) != 1 || progress.InProgress[0].ID != ids["Algoritmos"] || len(progress.Unlocked) != 4 {
+		t.Fatalf("unexpected progress: %+v", progress)
+	}
+	if len(progress.Locked) != 1 || progress.Locked[0].Discipline.ID != ids["Estruturas de Dados"] || len(progress.Locked[0].Missing) != 1 || progress.Locked[0].Missing[0].ID != ids["Algoritmos"] {
+		t.Errorf("unexpected locked disciplines: %+v", progress.Locked)
+	}
+	if progress.CreditsTotal != 24 || progress.CreditsCompleted != 4 || progress.CreditsRemaining != 20 || progress.MandatoryCreditsRemaining != 18 {
+		t.Errorf("unexpected credits: %+v", progress)
+	}
+
+	// Inglês is no longer offered, the lab is planned together with Física I,
+	// its co-requisite, and the first Física I class overlaps Cálculo II.
+	plan, err := svc.GetStudyPlan(student.ID, curriculum.ID, nil)
+	must(err)
+	var planned []uint
+	for _, p := range plan.Classes {
+		planned = append(planned, p.Discipline.ID)
+	}
+	want := []uint{ids["Cálculo II"], ids["Laboratório de Física"], ids["Física I"]}
+	if len(planned) != len(want) || planned[0] != want[0] || planned[1] != want[1] || planned[2] != want[2] {
+		t.Fatalf("planned disciplines: got %v, want %v", planned, want)
+	}
+	if plan.Classes[2].Class.ClassID != physics.ClassID || plan.Credits != 10 || plan.MaxCredits != 12 {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+	if len(plan.Skipped) != 1 || plan.Skipped[0].Discipline.ID != ids["Inglês"] {
+		t.Errorf("unexpected skipped disciplines: %+v", plan.Skipped)
+	}
+
+	// The lab and Física I only fit under the cap together.
+	plan, err = svc.GetStudyPlan(student.ID, curriculum.ID, capAt(8))
+	must(err)
+	if len(plan.Classes) != 1 || plan.Credits != 4 || len(plan.Skipped) != 3 {
+		t.Errorf("unexpected plan under 8 credits: %+v", plan)
+	}
+	plan, err = svc.GetStudyPlan(student.ID, curriculum.ID, capAt(0))
+	must(err)
+	if len(plan.Classes) != 3 || plan.MaxCredits != 0 {
+		t.Errorf("unexpected uncapped plan: %+v", plan)
+	}
+	if _, err := svc.GetStudyPlan(student.ID, curriculum.ID, capAt(-1)); !errors.Is(err, domain.ErrInvalidStudyPlan) {
+		t.Errorf("negative cap: got %v, want %v", err, domain.ErrInvalidStudyPlan)
+	}
+	if _, err := svc.GetProgress(999, curriculum.ID); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("unknown user: got %v, want %v", err, domain.ErrUserNotFound)
+	}
+	if _, err := svc.GetProgress(student.ID, 999); !errors.Is(err, domain.ErrCurriculumNotFound) {
+		t.Errorf("unknown curriculum: got %v, want %v", err, domain.ErrCurriculumNotFound)
+	}
+}
+
+func TestStudyPlanTakesMutualCorequisitesTogether(t *testing.T) {
+	store := memimpl.NewStore()
+	repos := memimpl.NewRepositories(store)
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	theory := domain.Discipline{Name: "Física I", Credits: 4}
+	must(repos.Discipline.Create(&theory))
+	lab := domain.Discipline{Name: "Laboratório de Física", Credits: 2}
+	must(repos.Discipline.Create(&lab))
+	curriculums := services.NewCurriculumService(repos.Curriculum, repos.Discipline, memimpl.NewTransactor(store), domain.CreditLimits{})
+	curriculum, err := curriculums.CreateCurriculum(&domain.Curriculum{
+		CourseName:  "Engenharia",
+		Disciplines: []domain.Discipline{{ID: theory.ID, Semester: 1}, {ID: lab.ID, Semester: 1}},
+	})
+	must(err)
+	for _, q := range []domain.Requisite{
+		{CurriculumID: curriculum.ID, DisciplineID: theory.ID, RequisiteID: lab.ID, Kind: domain.RequisiteCorequisite},
+		{CurriculumID: curriculum.ID, DisciplineID: lab.ID, RequisiteID: theory.ID, Kind: domain.RequisiteCorequisite},
+	} {
+		_, err := curriculums.AddRequisite(&q)
+		must(err)
+	}
+	profile := domain.Profile{Role: "student"}
+	must(repos.Profile.Create(&profile))
+	student := domain.User{Email: "s@example.com", Nome: "S", ProfileID: profile.ID}
+	must(repos.User.Create(&student))
+	for _, d := range []domain.Discipline{theory, lab} {
+		must(repos.Class.Create(&domain.Class{Name: d.Name, DisciplineID: d.ID}))
+	}
+
+	svc := services.NewProgressService(repos, services.NewEquivalenceService(repos.Equivalence, repos.Discipline, repos.Curriculum, memimpl.NewTransactor(store)), 12, time.UTC)
+	plan, err := svc.GetStudyPlan(student.ID, curriculum.ID, nil)
+	must(err)
+	if len(plan.Classes) != 2 || plan.Credits != 6 || len(plan.Skipped) != 0 {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+	plan, err = svc.GetStudyPlan(student.ID, curriculum.ID, capAt(5))
+	must(err)
+	if len(plan.Classes) != 0 || len(plan.Skipped) != 2 {
+		t.Errorf("unexpected plan under 5 credits: %+v", plan)
+	}
+}
+
+// capAt returns a credit cap for GetStudyPlan.
+func capAt(n int) *int {
+	return &n
+}
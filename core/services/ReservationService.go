@@ -29,8 +29,12 @@ func (s *reservationsService) CreateReservation(reservation *domain.Reservation)
 	return reservation, nil
 }
 
-func (s *reservationsService) GetReservations() ([]domain.Reservation, error) {
-	return s.repo.FindAll()
+func (s *reservationsService) GetReservations(page domain.Page) ([]domain.Reservation, error) {
+	return s.repo.FindAll(page)
+}
+
+func (s *reservationsService) StreamReservations(fn func(domain.Reservation) error) error {
+	return s.repo.StreamAll(fn)
 }
 
 func (s *reservationsService) GetReservationByID(id uint) (*domain.Reservation, error) {
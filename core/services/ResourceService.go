@@ -22,8 +22,12 @@ func (s *resourceService) CreateResource(resource *domain.Resource) (*domain.Res
 	return resource, nil
 }
 
-func (s *resourceService) GetResources() ([]domain.Resource, error) {
-	return s.repo.FindAll()
+func (s *resourceService) GetResources(page domain.Page) ([]domain.Resource, error) {
+	return s.repo.FindAll(page)
+}
+
+func (s *resourceService) StreamResources(fn func(domain.Resource) error) error {
+	return s.repo.StreamAll(fn)
 }
 
 func (s *resourceService) GetResourceByID(id uint) (*domain.Resource, error) {
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"errors"
+	"sarc/core/domain"
+	interfaces "sarc/core/services/interfaces"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type resourceTypeService struct {
+	repo         repositories.ResourceTypeRepository
+	resourceRepo repositories.ResourceRepository
+}
+
+func NewResourceTypeService(repo repositories.ResourceTypeRepository, resourceRepo repositories.ResourceRepository) interfaces.ResourceTypeService {
+	return &resourceTypeService{repo: repo, resourceRepo: resourceRepo}
+}
+
+func (s *resourceTypeService) CreateResourceType(resourceType *domain.ResourceType) (*domain.ResourceType, error) {
+	if err := s.repo.Create(resourceType); err != nil {
+		return nil, err
+	}
+	return resourceType, nil
+}
+
+func (s *resourceTypeService) GetResourceTypes(page domain.Page) ([]domain.ResourceType, error) {
+	return s.repo.FindAll(page)
+}
+
+func (s *resourceTypeService) GetResourceTypeByID(id uint) (*domain.ResourceType, error) {
+	resourceType, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if resourceType == nil {
+		return nil, errors.New("resource type not found")
+	}
+	return resourceType, nil
+}
+
+func (s *resourceTypeService) UpdateResourceType(id uint, resourceType *domain.ResourceType) (*domain.ResourceType, error) {
+	if err := s.repo.Update(id, resourceType); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(id)
+}
+
+// DeleteResourceType refuses to delete a type that resources still use, so
+// they are never left pointing at a missing type.
+func (s *resourceTypeService) DeleteResourceType(id uint) error {
+	resources, err := s.resourceRepo.FindByResourceType(id)
+	if err != nil {
+		return err
+	}
+	if len(resources) > 0 {
+		return domain.ErrResourceTypeInUse
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *resourceTypeService) GetResourcesByType(id uint) ([]domain.Resource, error) {
+	if _, err := s.GetResourceTypeByID(id); err != nil {
+		return nil, err
+	}
+	return s.resourceRepo.FindByResourceType(id)
+}
@@ -8,12 +8,13 @@ import (
 )
 
 type roomService struct {
-	repo repositories.RoomRepository
+	repo        repositories.RoomRepository
+	lectureRepo repositories.LectureRepository
 }
 
 // NewRoomService creates a new RoomService using a repository
-func NewRoomService(repo repositories.RoomRepository) interfaces.RoomService {
-	return &roomService{repo: repo}
+func NewRoomService(repo repositories.RoomRepository, lectureRepo repositories.LectureRepository) interfaces.RoomService {
+	return &roomService{repo: repo, lectureRepo: lectureRepo}
 }
 
 func (s *roomService) CreateRoom(room *domain.Room) (*domain.Room, error) {
@@ -23,8 +24,12 @@ func (s *roomService) CreateRoom(room *domain.Room) (*domain.Room, error) {
 	return room, nil
 }
 
-func (s *roomService) GetRooms() ([]domain.Room, error) {
-	return s.repo.FindAll()
+func (s *roomService) GetRooms(page domain.Page) ([]domain.Room, error) {
+	return s.repo.FindAll(page)
+}
+
+func (s *roomService) StreamRooms(fn func(domain.Room) error) error {
+	return s.repo.StreamAll(fn)
 }
 
 func (s *roomService) GetRoomByID(id uint) (*domain.Room, error) {
@@ -48,3 +53,10 @@ func (s *roomService) UpdateRoom(id uint, updated *domain.Room) (*domain.Room, e
 func (s *roomService) DeleteRoom(id uint) error {
 	return s.repo.Delete(id)
 }
+
+func (s *roomService) GetRoomLectures(id uint, page domain.Page) ([]domain.Lecture, error) {
+	if _, err := s.GetRoomByID(id); err != nil {
+		return nil, err
+	}
+	return s.lectureRepo.FindByRoom(id, page)
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+// scheduledLecture is a lecture with times, as seen by one of its users.
+type scheduledLecture struct {
+	lecture    domain.Lecture
+	role       domain.EnrollmentRole
+	start, end time.Time
+}
+
+// schedule returns l's time window, and false for lectures without times,
+// which are never in conflict. Only comparisons are made, so the zone does
+// not matter.
+func schedule(l domain.Lecture, role domain.EnrollmentRole) (scheduledLecture, bool, error) {
+	if l.StartTime == "" {
+		return scheduledLecture{}, false, nil
+	}
+	start, end, err := l.Interval(time.UTC)
+	if err != nil {
+		return scheduledLecture{}, false, fmt.Errorf("lecture %d: %w", l.LectureID, err)
+	}
+	return scheduledLecture{lecture: l, role: role, start: start, end: end}, true, nil
+}
+
+func (a scheduledLecture) overlaps(b scheduledLecture) bool {
+	return a.lecture.LectureID != b.lecture.LectureID && a.lecture.ClassID != b.lecture.ClassID &&
+		a.start.Before(b.end) && b.start.Before(a.end)
+}
+
+// userConflicts checks lectures, which userID would attend as role, against
+// the lectures of every other class the user is enrolled in and not dropped.
+func userConflicts(lectureRepo repositories.LectureRepository, enrollmentRepo repositories.EnrollmentRepository, userID uint, role domain.EnrollmentRole, lectures []domain.Lecture) ([]domain.ScheduleConflict, error) {
+	var candidates []scheduledLecture
+	classes := make(map[uint]bool)
+	for _, l := range lectures {
+		s, ok, err := schedule(l, role)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			candidates = append(candidates, s)
+			classes[l.ClassID] = true
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	enrollments, err := enrollmentRepo.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []domain.ScheduleConflict
+	for _, e := range enrollments {
+		if e.Status == domain.EnrollmentDropped || classes[e.ClassID] {
+			continue
+		}
+		others, err := lectureRepo.FindByClass(e.ClassID, domain.Page{})
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range others {
+			other, ok, err := schedule(o, e.Role)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			for _, c := range candidates {
+				if c.overlaps(other) {
+					conflicts = append(conflicts, scheduleConflict(userID, c, other))
+				}
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+func scheduleConflict(userID uint, a, b scheduledLecture) domain.ScheduleConflict {
+	return domain.ScheduleConflict{UserID: userID, Role: a.role, Lecture: a.lecture, OtherRole: b.role, Other: b.lecture}
+}
+
+// conflictMessage describes c from the point of view of its first lecture.
+func conflictMessage(c domain.ScheduleConflict) string {
+	return fmt.Sprintf("%s %d is already in lecture %d of class %d on %s from %s to %s",
+		c.Role, c.UserID, c.Other.LectureID, c.Other.ClassID, c.Other.Date, c.Other.StartTime, c.Other.EndTime)
+}
+
+// resolveConflicts turns conflicts into ErrScheduleConflict, or into warnings
+// under a soft limit.
+func resolveConflicts(conflicts []domain.ScheduleConflict, policy domain.ConflictPolicy) ([]string, error) {
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+	messages := make([]string, len(conflicts))
+	for i, c := range conflicts {
+		messages[i] = conflictMessage(c)
+	}
+	if !policy.SoftLimit {
+		return nil, fmt.Errorf("%w: %s", domain.ErrScheduleConflict, strings.Join(messages, "; "))
+	}
+	return messages, nil
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"errors"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"sarc/core/domain"
+	interfaces "sarc/core/services/interfaces"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+	// headlineContext is roughly how many bytes of text are kept on each
+	// side of the first match in a fallback headline.
+	headlineContext = 60
+)
+
+type searchService struct {
+	repo repositories.SearchRepository
+}
+
+func NewSearchService(repo repositories.SearchRepository) interfaces.SearchService {
+	return &searchService{repo: repo}
+}
+
+func (s *searchService) Search(query string, limit int) (*domain.SearchResults, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("search query is empty")
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	terms := searchTerms(query)
+	hits, err := s.repo.Search(domain.SearchQuery{Text: query, Terms: terms, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	// Backends without full-text search leave ranking and highlighting to us
+	for i := range hits {
+		if hits[i].Headline == "" {
+			hits[i].Rank = termRank(hits[i], terms)
+			hits[i].Headline = highlight(hits[i].Text, terms)
+		}
+	}
+
+	byType := make(map[string][]domain.SearchHit)
+	for _, hit := range hits {
+		byType[hit.Type] = append(byType[hit.Type], hit)
+	}
+	results := &domain.SearchResults{Query: query, Groups: []domain.SearchGroup{}}
+	for typ, group := range byType {
+		sort.SliceStable(group, func(i, j int) bool {
+			if group[i].Rank != group[j].Rank {
+				return group[i].Rank > group[j].Rank
+			}
+			return group[i].ID < group[j].ID
+		})
+		if len(group) > limit {
+			group = group[:limit]
+		}
+		results.Groups = append(results.Groups, domain.SearchGroup{Type: typ, Hits: group})
+		results.Total += len(group)
+	}
+	sort.Slice(results.Groups, func(i, j int) bool {
+		a, b := results.Groups[i], results.Groups[j]
+		if a.Hits[0].Rank != b.Hits[0].Rank {
+			return a.Hits[0].Rank > b.Hits[0].Rank
+		}
+		return a.Type < b.Type
+	})
+	return results, nil
+}
+
+// searchTerms splits a query into lowercased words. Words shorter than three
+// letters are dropped, as they match nearly everything as substrings, but
+// numbers are kept so "prédio 32" or "sala 5" still work.
+func searchTerms(query string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		if seen[w] || (utf8.RuneCountInString(w) < 3 && !isNumber(w)) {
+			continue
+		}
+		seen[w] = true
+		terms = append(terms, w)
+	}
+	return terms
+}
+
+func isNumber(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// termRank scores a hit by how many terms it contains and how often, with
+// matches in the title counting double.
+func termRank(hit domain.SearchHit, terms []string) float64 {
+	text := strings.ToLower(hit.Text)
+	title := strings.ToLower(hit.Title)
+	var rank float64
+	for _, term := range terms {
+		if n := strings.Count(text, term); n > 0 {
+			rank += 1 + 0.1*float64(n-1)
+		}
+		if strings.Contains(title, term) {
+			rank++
+		}
+	}
+	return rank
+}
+
+// highlight wraps the terms found in text in <b></b>, keeping an excerpt
+// around the first match when the text is long, like ts_headline does. The
+// text itself is HTML-escaped, so only the tags added here are markup.
+func highlight(text string, terms []string) string {
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// Lowercasing changed byte offsets; an unmarked excerpt is better
+		// than misplaced tags.
+		return excerpt(text)
+	}
+
+	type span struct{ start, end int }
+	var spans []span
+	for _, term := range terms {
+		for from := 0; ; {
+			i := strings.Index(lower[from:], term)
+			if i < 0 {
+				break
+			}
+			spans = append(spans, span{from + i, from + i + len(term)})
+			from += i + len(term)
+		}
+	}
+	if len(spans) == 0 {
+		return excerpt(text)
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	merged := spans[:1]
+	for _, sp := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if sp.start <= last.end {
+			last.end = max(last.end, sp.end)
+		} else {
+			merged = append(merged, sp)
+		}
+	}
+
+	start := max(merged[0].start-headlineContext, 0)
+	end := min(merged[0].end+2*headlineContext, len(text))
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+	var b strings.Builder
+	pos := start
+	for _, sp := range merged {
+		if sp.start < start || sp.end > end {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:sp.start]))
+		b.WriteString("<b>")
+		b.WriteString(html.EscapeString(text[sp.start:sp.end]))
+		b.WriteString("</b>")
+		pos = sp.end
+	}
+	b.WriteString(html.EscapeString(text[pos:end]))
+	return ellipsis(start > 0, strings.TrimSpace(b.String()), end < len(text))
+}
+
+// excerpt cuts text to at most 3*headlineContext bytes on a rune boundary
+// and HTML-escapes it.
+func excerpt(text string) string {
+	end := min(3*headlineContext, len(text))
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	return ellipsis(false, html.EscapeString(strings.TrimSpace(text[:end])), end < len(text))
+}
+
+func ellipsis(before bool, s string, after bool) string {
+	if before {
+		s = "… " + s
+	}
+	if after {
+		s += " …"
+	}
+	return s
+}
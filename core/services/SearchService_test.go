@@ -0,0 +1,33 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"sarc/core/domain"
+	"sarc/core/services"
+	memimpl "sarc/infrastructure/repositories/MEMimpl"
+)
+
+func TestSearchHeadlinesEscapeText(t *testing.T) {
+	repos := memimpl.NewRepositories(memimpl.NewStore())
+	building := domain.Building{BuildingName: `Prédio <img src=x onerror="alert(1)">`, Address: "Av. Ipiranga & Rua B"}
+	if err := repos.Building.Create(&building); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := services.NewSearchService(repos.Search).Search("ipiranga", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Groups) != 1 || results.Groups[0].Type != domain.SearchTypeBuilding || len(results.Groups[0].Hits) != 1 {
+		t.Fatalf("expected one building, got %+v", results)
+	}
+	headline := results.Groups[0].Hits[0].Headline
+	if strings.Contains(headline, "<img") || !strings.Contains(headline, "&lt;img") {
+		t.Errorf("text not escaped: %s", headline)
+	}
+	if !strings.Contains(headline, "<b>Ipiranga</b> &amp; Rua B") {
+		t.Errorf("match not highlighted: %s", headline)
+	}
+}
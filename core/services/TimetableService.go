@@ -0,0 +1,115 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"sarc/core/domain"
+	interfaces "sarc/core/services/interfaces"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type timetableService struct {
+	repos    repositories.Repositories
+	includes interfaces.IncludeService
+	loc      *time.Location
+	now      func() time.Time
+}
+
+func NewTimetableService(repos repositories.Repositories, includes interfaces.IncludeService, loc *time.Location) interfaces.TimetableService {
+	return &timetableService{repos: repos, includes: includes, loc: loc, now: time.Now}
+}
+
+func (s *timetableService) GetTimetable(userID uint, from, to string) (*domain.Timetable, error) {
+	first, last, err := dateRange(from, to, s.now().In(s.loc), 7)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repos.User.FindByID(userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	enrollments, err := s.repos.Enrollment.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	timetable := &domain.Timetable{UserID: userID, From: first, To: last, Entries: []domain.TimetableEntry{}}
+	roles := make(map[uint]domain.EnrollmentRole)
+	var classIDs []uint
+	for _, e := range enrollments {
+		if e.Status == domain.EnrollmentDropped {
+			continue
+		}
+		roles[e.ClassID] = e.Role
+		classIDs = append(classIDs, e.ClassID)
+	}
+	lectures, err := s.repos.Lecture.FindByClasses(classIDs, first, last)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.includes.IncludeLectures(lectures, domain.ParseIncludes("class,room.building")); err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(lectures))
+	for i, l := range lectures {
+		ids[i] = l.LectureID
+	}
+	reservations, err := s.repos.Reservation.FindByLectures(ids)
+	if err != nil {
+		return nil, err
+	}
+	byLecture := make(map[uint][]domain.Reservation)
+	for _, r := range reservations {
+		byLecture[r.LectureID] = append(byLecture[r.LectureID], r)
+	}
+
+	for _, l := range lectures {
+		start, end, err := l.Interval(s.loc)
+		if err != nil {
+			return nil, fmt.Errorf("lecture %d: %w", l.LectureID, err)
+		}
+		entry := domain.TimetableEntry{
+			Lecture:      l,
+			Role:         roles[l.ClassID],
+			Start:        start,
+			End:          end,
+			Reservations: byLecture[l.LectureID],
+		}
+		if entry.Reservations == nil {
+			entry.Reservations = []domain.Reservation{}
+		}
+		timetable.Entries = append(timetable.Entries, entry)
+	}
+	sort.SliceStable(timetable.Entries, func(i, j int) bool {
+		return timetable.Entries[i].Start.Before(timetable.Entries[j].Start)
+	})
+	timetable.HasConflicts = markConflicts(timetable.Entries)
+	return timetable, nil
+}
+
+// markConflicts records on each entry the lectures overlapping it, and
+// reports whether there were any. entries must be sorted by start.
+func markConflicts(entries []domain.TimetableEntry) bool {
+	found := false
+	for i := range entries {
+		if entries[i].Lecture.StartTime == "" {
+			continue
+		}
+		for j := i + 1; j < len(entries) && entries[j].Start.Before(entries[i].End); j++ {
+			if entries[j].Lecture.StartTime == "" {
+				continue
+			}
+			entries[i].Conflicts = append(entries[i].Conflicts, entries[j].Lecture.LectureID)
+			entries[j].Conflicts = append(entries[j].Conflicts, entries[i].Lecture.LectureID)
+			found = true
+		}
+	}
+	return found
+}
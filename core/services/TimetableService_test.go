@@ -0,0 +1,72 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"sarc/core/domain"
+	"sarc/core/services"
+	memimpl "sarc/infrastructure/repositories/MEMimpl"
+)
+
+func TestTimetableMergesClassesAndFindsConflicts(t *testing.T) {
+	repos := memimpl.NewRepositories(memimpl.NewStore())
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	profile := domain.Profile{Role: "student"}
+	must(repos.Profile.Create(&profile))
+	user := domain.User{Email: "bia@example.com", Nome: "Bia", ProfileID: profile.ID}
+	must(repos.User.Create(&user))
+	building := domain.Building{BuildingName: "Prédio 32"}
+	must(repos.Building.Create(&building))
+	room := domain.Room{RoomNumber: "101", BuildingID: building.BuildingID}
+	must(repos.Room.Create(&room))
+	discipline := domain.Discipline{Name: "Cálculo I", Credits: 4}
+	must(repos.Discipline.Create(&discipline))
+
+	classes := make([]domain.Class, 3)
+	for i, status := range []domain.EnrollmentStatus{domain.EnrollmentActive, domain.EnrollmentActive, domain.EnrollmentDropped} {
+		classes[i] = domain.Class{Name: "Turma", DisciplineID: discipline.ID}
+		must(repos.Class.Create(&classes[i]))
+		must(repos.Enrollment.Create(&domain.Enrollment{ClassID: classes[i].ClassID, UserID: user.ID, Role: domain.EnrollmentRoleStudent, Status: status, EnrolledAt: time.Now()}))
+	}
+	lecture := func(class domain.Class, date, start, end string) domain.Lecture {
+		t.Helper()
+		l := domain.Lecture{ClassID: class.ClassID, RoomID: room.RoomID, Date: date, StartTime: start, EndTime: end}
+		must(repos.Lecture.Create(&l))
+		return l
+	}
+	first := lecture(classes[0], "2030-03-04", "08:00", "09:40")
+	overlapping := lecture(classes[1], "2030-03-04", "09:00", "10:40")
+	later := lecture(classes[1], "2030-03-05", "08:00", "09:40")
+	lecture(classes[0], "2030-03-20", "08:00", "09:40") // out of range
+	lecture(classes[2], "2030-03-04", "08:00", "09:40") // dropped class
+	reservation := domain.Reservation{LectureID: later.LectureID, Observation: "projetor"}
+	must(repos.Reservation.Create(&reservation))
+
+	svc := services.NewTimetableService(repos, services.NewIncludeService(repos), time.UTC)
+	timetable, err := svc.GetTimetable(user.ID, "2030-03-04", "")
+	must(err)
+	if timetable.To != "2030-03-10" || len(timetable.Entries) != 3 || !timetable.HasConflicts {
+		t.Fatalf("unexpected timetable: %+v", timetable)
+	}
+	entries := timetable.Entries
+	if entries[0].Lecture.LectureID != first.LectureID || len(entries[0].Conflicts) != 1 || entries[0].Conflicts[0] != overlapping.LectureID {
+		t.Errorf("unexpected first entry: %+v", entries[0])
+	}
+	if entries[0].Lecture.Room == nil || entries[0].Lecture.Room.Building == nil || entries[0].Lecture.Class == nil {
+		t.Errorf("room, building and class not loaded: %+v", entries[0].Lecture)
+	}
+	if entries[2].Lecture.LectureID != later.LectureID || len(entries[2].Conflicts) != 0 || len(entries[2].Reservations) != 1 {
+		t.Errorf("unexpected last entry: %+v", entries[2])
+	}
+
+	if _, err := svc.GetTimetable(user.ID, "2030-03-10", "2030-03-04"); !errors.Is(err, domain.ErrInvalidDateRange) {
+		t.Errorf("reversed range: got %v, want %v", err, domain.ErrInvalidDateRange)
+	}
+}
@@ -22,8 +22,12 @@ func (s *userService) CreateUser(user *domain.User) (*domain.User, error) {
 	return user, nil
 }
 
-func (s *userService) GetUsers() ([]domain.User, error) {
-	return s.repo.FindAll()
+func (s *userService) GetUsers(page domain.Page) ([]domain.User, error) {
+	return s.repo.FindAll(page)
+}
+
+func (s *userService) StreamUsers(fn func(domain.User) error) error {
+	return s.repo.StreamAll(fn)
 }
 
 func (s *userService) GetUserByID(id uint) (*domain.User, error) {
@@ -0,0 +1,18 @@
+package interfaces
+
+import "sarc/core/domain"
+
+type AttendanceService interface {
+	// GetClassAttendance reports every student enrolled in the class, and
+	// anyone else who attended it, except dropped students and staff.
+	GetClassAttendance(classID uint) (*domain.ClassAttendanceReport, error)
+	// GetStudentAttendance reports the user's attendance in each class they
+	// are a student of or attended.
+	GetStudentAttendance(userID uint) ([]domain.StudentAttendance, error)
+	// NotifyClassAlerts sends an alert for every student of the class whose
+	// attendance is not ok, unless they were already notified of that status,
+	// and returns the alerts sent.
+	NotifyClassAlerts(classID uint) ([]domain.AttendanceAlert, error)
+	// NotifyAllAlerts does the same for every class.
+	NotifyAllAlerts() ([]domain.AttendanceAlert, error)
+}
@@ -6,8 +6,9 @@ import (
 
 type BuildingService interface {
 	CreateBuilding(building *domain.Building) (*domain.Building, error)
-	GetBuildings() ([]domain.Building, error)
+	GetBuildings(page domain.Page) ([]domain.Building, error)
 	GetBuildingByID(id uint) (*domain.Building, error)
 	UpdateBuilding(id uint, building *domain.Building) (*domain.Building, error)
 	DeleteBuilding(id uint) error
+	GetBuildingRooms(id uint, page domain.Page) ([]domain.Room, error)
 }
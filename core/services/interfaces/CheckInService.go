@@ -0,0 +1,12 @@
+package interfaces
+
+import "sarc/core/domain"
+
+type CheckInService interface {
+	// IssueToken returns the lecture's current check-in token to userID, who
+	// must be an active teacher or assistant of the class.
+	IssueToken(lectureID uint, userID uint) (*domain.CheckInToken, error)
+	// CheckIn marks userID present with a token scanned from the lecture's QR
+	// code. created is false when they were already marked.
+	CheckIn(lectureID uint, userID uint, token string) (presence *domain.Presence, created bool, err error)
+}
@@ -0,0 +1,10 @@
+package interfaces
+
+import "sarc/core/domain"
+
+type ConflictService interface {
+	// GetConflicts lists every user expected at two overlapping lectures
+	// between from and to, as YYYY-MM-DD dates. An empty from is today and
+	// an empty to is about a term after from.
+	GetConflicts(from, to string) ([]domain.ScheduleConflict, error)
+}
@@ -6,9 +6,19 @@ import (
 
 type CurriculumService interface {
 	CreateCurriculum(curriculum *domain.Curriculum) (*domain.Curriculum, error)
-	GetCurriculums() ([]domain.Curriculum, error)
+	GetCurriculums(page domain.Page) ([]domain.Curriculum, error)
 	GetCurriculumByID(id uint) (*domain.Curriculum, error)
 	UpdateCurriculum(id uint, curriculum *domain.Curriculum) (*domain.Curriculum, error)
 	DeleteCurriculum(id uint) error
-	AddDisciplineToCurriculum(curriculumID uint, disciplineID uint) error
+	AddDisciplineToCurriculum(curriculumID uint, discipline domain.Discipline) error
+	PlaceDiscipline(curriculumID uint, discipline domain.Discipline) (*domain.Curriculum, error)
+	RemoveDisciplineFromCurriculum(curriculumID uint, disciplineID uint) error
+	SetDisciplines(curriculumID uint, disciplines []domain.Discipline) (*domain.Curriculum, error)
+	GetRequisites(curriculumID uint) ([]domain.Requisite, error)
+	AddRequisite(requisite *domain.Requisite) (*domain.Requisite, error)
+	RemoveRequisite(curriculumID uint, disciplineID uint, requisiteID uint) error
+	GetGraph(curriculumID uint) (*domain.CurriculumGraph, error)
+	CloneCurriculum(id uint, overrides domain.Curriculum) (*domain.Curriculum, error)
+	GetVersions(id uint) ([]domain.Curriculum, error)
+	DiffCurriculums(fromID uint, toID uint) (*domain.CurriculumDiff, error)
 }
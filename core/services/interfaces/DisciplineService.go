@@ -6,8 +6,9 @@ import (
 
 type DisciplineService interface {
 	CreateDiscipline(discipline *domain.Discipline) (*domain.Discipline, error)
-	GetDisciplines() ([]domain.Discipline, error)
+	GetDisciplines(page domain.Page) ([]domain.Discipline, error)
 	GetDisciplineByID(id uint) (*domain.Discipline, error)
 	UpdateDiscipline(id uint, discipline *domain.Discipline) (*domain.Discipline, error)
 	DeleteDiscipline(id uint) error
+	GetDisciplineClasses(id uint, page domain.Page) ([]domain.Class, error)
 }
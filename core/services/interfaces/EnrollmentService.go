@@ -0,0 +1,16 @@
+package interfaces
+
+import "sarc/core/domain"
+
+type EnrollmentService interface {
+	// Enroll adds the user to the class with role, student when empty.
+	// created is false when the user was already enrolled; a dropped or
+	// completed enrollment is reactivated.
+	Enroll(classID uint, userID uint, role domain.EnrollmentRole) (enrollment *domain.Enrollment, created bool, err error)
+	// Drop marks the user's enrollment in the class as dropped.
+	Drop(classID uint, userID uint) error
+	// GetRoster lists the class's enrollments, filtered by status and role
+	// when they are not empty.
+	GetRoster(classID uint, status domain.EnrollmentStatus, role domain.EnrollmentRole) ([]domain.Enrollment, error)
+	GetUserEnrollments(userID uint) ([]domain.Enrollment, error)
+}
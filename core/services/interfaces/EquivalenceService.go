@@ -0,0 +1,17 @@
+package interfaces
+
+import "sarc/core/domain"
+
+type EquivalenceService interface {
+	// CreateEquivalence fails if the target is also a source, or if an
+	// equivalence with the same target and sources already exists.
+	CreateEquivalence(equivalence *domain.Equivalence) (*domain.Equivalence, error)
+	GetEquivalenceByID(id uint) (*domain.Equivalence, error)
+	// GetEquivalences lists every equivalence, or only those involving the
+	// discipline when disciplineID is not 0.
+	GetEquivalences(disciplineID uint, page domain.Page) ([]domain.Equivalence, error)
+	DeleteEquivalence(id uint) error
+	// TransferCredits works out which disciplines of the curriculum the
+	// completed disciplines credit.
+	TransferCredits(curriculumID uint, completed []uint) (*domain.CreditTransfer, error)
+}
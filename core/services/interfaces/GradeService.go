@@ -0,0 +1,24 @@
+package interfaces
+
+import "sarc/core/domain"
+
+type GradeService interface {
+	CreateAssessment(assessment *domain.Assessment) (*domain.Assessment, error)
+	GetAssessments(classID uint) ([]domain.Assessment, error)
+	// UpdateAssessment changes the name and weight of an assessment.
+	UpdateAssessment(id uint, assessment *domain.Assessment) (*domain.Assessment, error)
+	// DeleteAssessment removes the assessment along with its grades.
+	DeleteAssessment(id uint) error
+	// SetGrade grades a student of the assessment's class, replacing any
+	// previous grade.
+	SetGrade(grade *domain.Grade) (*domain.Grade, error)
+	// GetClassResults works out every student's final grade and status so
+	// far.
+	GetClassResults(classID uint) (*domain.ClassResults, error)
+	// CloseClass settles the results, counting missing grades as zero,
+	// records them in each student's history and marks their enrollments
+	// completed. Closing a class again replaces its records.
+	CloseClass(classID uint) (*domain.ClassResults, error)
+	// GetHistory lists the user's academic records.
+	GetHistory(userID uint) ([]domain.AcademicRecord, error)
+}
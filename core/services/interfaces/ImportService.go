@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"sarc/core/domain"
+	"sarc/pkg/tabular"
+)
+
+type ImportService interface {
+	// ImportEntities lists the entities Import accepts, e.g. "rooms".
+	ImportEntities() []string
+	// Import validates every row of table and, when all of them are valid,
+	// inserts or updates them in a single transaction. Invalid rows are
+	// reported in the returned report rather than as an error.
+	Import(entity string, table *tabular.Table, opts domain.ImportOptions) (*domain.ImportReport, error)
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import "sarc/core/domain"
+
+// IncludeService fills the relations requested with ?include= on lists read
+// by the other services. Each call checks include first and returns
+// domain.ErrInvalidInclude for an unknown relation, then loads every level
+// of the tree with one batched query per relation.
+type IncludeService interface {
+	// IncludeRooms supports building.
+	IncludeRooms(rooms []domain.Room, include domain.Includes) error
+	// IncludeClasses supports discipline.
+	IncludeClasses(classes []domain.Class, include domain.Includes) error
+	// IncludeLectures supports class, class.discipline, room, room.building
+	// and presence.
+	IncludeLectures(lectures []domain.Lecture, include domain.Includes) error
+	// IncludeReservations supports lecture with the lecture relations under
+	// it, and resources.resourceType.
+	IncludeReservations(reservations []domain.Reservation, include domain.Includes) error
+	// IncludeResources supports resourceType, which resources always carry.
+	IncludeResources(resources []domain.Resource, include domain.Includes) error
+}
@@ -6,8 +6,15 @@ import (
 
 type LectureService interface {
 	CreateLecture(lecture *domain.Lecture) (*domain.Lecture, error)
-	GetLectures() ([]domain.Lecture, error)
+	GetLectures(page domain.Page) ([]domain.Lecture, error)
+	StreamLectures(fn func(domain.Lecture) error) error
 	GetLectureByID(id uint) (*domain.Lecture, error)
 	UpdateLecture(id uint, lecture *domain.Lecture) (*domain.Lecture, error)
 	DeleteLecture(id uint) error
+	GetLectureReservations(id uint, page domain.Page) ([]domain.Reservation, error)
+	// GetCapacityReport lists the upcoming lectures from from through to,
+	// YYYY-MM-DD, whose class has more students than the room has seats, or
+	// fills too little of it. An empty from is today and an empty to six
+	// months after it.
+	GetCapacityReport(from, to string) ([]domain.LectureCapacity, error)
 }
@@ -0,0 +1,14 @@
+package interfaces
+
+import "sarc/core/domain"
+
+type PresenceService interface {
+	GetPresence(lectureID uint) ([]domain.Presence, error)
+	// MarkPresence marks one user present and returns their presence. created
+	// is false when they were already marked, in which case the original
+	// record is kept.
+	MarkPresence(lectureID uint, userID uint) (presence *domain.Presence, created bool, err error)
+	// MarkPresenceBulk marks a whole roll call at once.
+	MarkPresenceBulk(lectureID uint, userIDs []uint) (*domain.PresenceMarkResult, error)
+	UnmarkPresence(lectureID uint, userID uint) error
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import "sarc/core/domain"
+
+type ProgressService interface {
+	// GetProgress reports the user's completed, unlocked and locked
+	// disciplines in the curriculum, and the credits left.
+	GetProgress(userID uint, curriculumID uint) (*domain.CurriculumProgress, error)
+	// GetStudyPlan suggests one class for as many unlocked disciplines as
+	// fit under maxCredits without lecture conflicts. A nil maxCredits
+	// uses the default cap and 0 means no cap.
+	GetStudyPlan(userID uint, curriculumID uint, maxCredits *int) (*domain.StudyPlan, error)
+}
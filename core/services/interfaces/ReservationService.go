@@ -6,7 +6,8 @@ import (
 
 type ReservationsService interface {
 	CreateReservation(reservation *domain.Reservation) (*domain.Reservation, error)
-	GetReservations() ([]domain.Reservation, error)
+	GetReservations(page domain.Page) ([]domain.Reservation, error)
+	StreamReservations(fn func(domain.Reservation) error) error
 	GetReservationByID(id uint) (*domain.Reservation, error)
 	UpdateReservation(id uint, reservation *domain.Reservation) (*domain.Reservation, error)
 	DeleteReservation(id uint) error
@@ -6,7 +6,8 @@ import (
 
 type ResourceService interface {
 	CreateResource(resource *domain.Resource) (*domain.Resource, error)
-	GetResources() ([]domain.Resource, error)
+	GetResources(page domain.Page) ([]domain.Resource, error)
+	StreamResources(fn func(domain.Resource) error) error
 	GetResourceByID(id uint) (*domain.Resource, error)
 	UpdateResource(id uint, resource *domain.Resource) (*domain.Resource, error)
 	DeleteResource(id uint) error
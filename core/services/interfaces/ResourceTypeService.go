@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"sarc/core/domain"
+)
+
+type ResourceTypeService interface {
+	CreateResourceType(resourceType *domain.ResourceType) (*domain.ResourceType, error)
+	GetResourceTypes(page domain.Page) ([]domain.ResourceType, error)
+	GetResourceTypeByID(id uint) (*domain.ResourceType, error)
+	UpdateResourceType(id uint, resourceType *domain.ResourceType) (*domain.ResourceType, error)
+	DeleteResourceType(id uint) error
+	GetResourcesByType(id uint) ([]domain.Resource, error)
+}
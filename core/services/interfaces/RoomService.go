@@ -6,8 +6,10 @@ import (
 
 type RoomService interface {
 	CreateRoom(room *domain.Room) (*domain.Room, error)
-	GetRooms() ([]domain.Room, error)
+	GetRooms(page domain.Page) ([]domain.Room, error)
+	StreamRooms(fn func(domain.Room) error) error
 	GetRoomByID(id uint) (*domain.Room, error)
 	UpdateRoom(id uint, room *domain.Room) (*domain.Room, error)
 	DeleteRoom(id uint) error
+	GetRoomLectures(id uint, page domain.Page) ([]domain.Lecture, error)
 }
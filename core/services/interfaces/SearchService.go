@@ -0,0 +1,9 @@
+package interfaces
+
+import "sarc/core/domain"
+
+type SearchService interface {
+	// Search finds disciplines, resources, buildings, rooms and classes
+	// matching query, at most limit per type, ranked and grouped by type.
+	Search(query string, limit int) (*domain.SearchResults, error)
+}
@@ -0,0 +1,10 @@
+package interfaces
+
+import "sarc/core/domain"
+
+type TimetableService interface {
+	// GetTimetable lists the user's lectures between from and to, as
+	// YYYY-MM-DD dates. An empty from is today and an empty to is a week
+	// after from.
+	GetTimetable(userID uint, from, to string) (*domain.Timetable, error)
+}
@@ -6,7 +6,8 @@ import (
 
 type UserService interface {
 	CreateUser(user *domain.User) (*domain.User, error)
-	GetUsers() ([]domain.User, error)
+	GetUsers(page domain.Page) ([]domain.User, error)
+	StreamUsers(fn func(domain.User) error) error
 	GetUserByID(id uint) (*domain.User, error)
 	UpdateUser(id uint, user *domain.User) (*domain.User, error)
 	DeleteUser(id uint) error
@@ -0,0 +1,32 @@
+// Package notifications delivers alerts raised by the services to people
+// outside SARC.
+package notifications
+
+import (
+	"log"
+
+	"sarc/core/domain"
+)
+
+// Notifier sends attendance alerts. It may be called from several goroutines
+// at once.
+type Notifier interface {
+	NotifyAttendance(alert domain.AttendanceAlert) error
+}
+
+type logNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogNotifier writes alerts to logger, or to the standard logger when nil.
+func NewLogNotifier(logger *log.Logger) Notifier {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &logNotifier{logger: logger}
+}
+
+func (n *logNotifier) NotifyAttendance(alert domain.AttendanceAlert) error {
+	n.logger.Printf("attendance alert: class %d, user %d, %s: %s", alert.ClassID, alert.UserID, alert.Status, alert.Message)
+	return nil
+}
@@ -0,0 +1,40 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"sarc/core/domain"
+)
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier POSTs each alert as JSON to url.
+func NewWebhookNotifier(url string) Notifier {
+	return &webhookNotifier{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (n *webhookNotifier) NotifyAttendance(alert domain.AttendanceAlert) error {
+	body, err := json.Marshal(struct {
+		Type string `json:"type"`
+		domain.AttendanceAlert
+	}{"attendance", alert})
+	if err != nil {
+		return err
+	}
+	resp, err := n.client.Post(n.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("attendance webhook: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("attendance webhook: unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package memImpl
+
+import (
+	"sort"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type academicRecordRepositoryImpl struct {
+	store *Store
+}
+
+func NewAcademicRecordRepository(store *Store) repositories.AcademicRecordRepository {
+	return &academicRecordRepositoryImpl{store}
+}
+
+func (r *academicRecordRepositoryImpl) Save(record *domain.AcademicRecord) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.users.has(record.UserID) || !r.store.classes.has(record.ClassID) || !r.store.disciplines.has(record.DisciplineID) {
+		return ErrForeignKey
+	}
+	if r.store.academicRecords == nil {
+		r.store.academicRecords = make(map[[2]uint]domain.AcademicRecord)
+	}
+	saved := *record
+	saved.Discipline = nil
+	r.store.academicRecords[[2]uint{record.UserID, record.ClassID}] = saved
+	return nil
+}
+
+func (r *academicRecordRepositoryImpl) FindByUser(userID uint) ([]domain.AcademicRecord, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	var records []domain.AcademicRecord
+	for key, rec := range r.store.academicRecords {
+		if key[0] != userID {
+			continue
+		}
+		d, _ := r.store.disciplines.get(rec.DisciplineID)
+		d = cloneDiscipline(d)
+		rec.Discipline = &d
+		records = append(records, rec)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].CompletedAt.Equal(records[j].CompletedAt) {
+			return records[i].CompletedAt.Before(records[j].CompletedAt)
+		}
+		return records[i].ClassID < records[j].ClassID
+	})
+	return records, nil
+}
@@ -0,0 +1,105 @@
+package memImpl
+
+import (
+	"sort"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type assessmentRepositoryImpl struct {
+	store *Store
+}
+
+func NewAssessmentRepository(store *Store) repositories.AssessmentRepository {
+	return &assessmentRepositoryImpl{store}
+}
+
+func (r *assessmentRepositoryImpl) Create(assessment *domain.Assessment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.classes.has(assessment.ClassID) {
+		return ErrForeignKey
+	}
+	assessment.ID = r.store.assessments.nextID()
+	r.store.assessments.put(assessment.ID, *assessment)
+	return nil
+}
+
+func (r *assessmentRepositoryImpl) Update(assessment *domain.Assessment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	current, ok := r.store.assessments.get(assessment.ID)
+	if !ok {
+		return nil
+	}
+	current.Name = assessment.Name
+	current.Weight = assessment.Weight
+	r.store.assessments.put(current.ID, current)
+	return nil
+}
+
+func (r *assessmentRepositoryImpl) FindByID(id uint) (*domain.Assessment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	a, ok := r.store.assessments.get(id)
+	if !ok {
+		return nil, errNotFound
+	}
+	return &a, nil
+}
+
+func (r *assessmentRepositoryImpl) FindByClass(classID uint) ([]domain.Assessment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	var assessments []domain.Assessment
+	for _, a := range r.store.assessments.all() {
+		if a.ClassID == classID {
+			assessments = append(assessments, a)
+		}
+	}
+	return assessments, nil
+}
+
+func (r *assessmentRepositoryImpl) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for key := range r.store.grades {
+		if key[0] == id {
+			delete(r.store.grades, key)
+		}
+	}
+	r.store.assessments.delete(id)
+	return nil
+}
+
+func (r *assessmentRepositoryImpl) SetGrade(grade *domain.Grade) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.assessments.has(grade.AssessmentID) || !r.store.users.has(grade.UserID) {
+		return ErrForeignKey
+	}
+	if r.store.grades == nil {
+		r.store.grades = make(map[[2]uint]float64)
+	}
+	r.store.grades[[2]uint{grade.AssessmentID, grade.UserID}] = grade.Value
+	return nil
+}
+
+func (r *assessmentRepositoryImpl) FindGradesByClass(classID uint) ([]domain.Grade, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	var grades []domain.Grade
+	for key, value := range r.store.grades {
+		if a, _ := r.store.assessments.get(key[0]); a.ClassID == classID {
+			grades = append(grades, domain.Grade{AssessmentID: key[0], UserID: key[1], Value: value})
+		}
+	}
+	sort.Slice(grades, func(i, j int) bool {
+		if grades[i].AssessmentID != grades[j].AssessmentID {
+			return grades[i].AssessmentID < grades[j].AssessmentID
+		}
+		return grades[i].UserID < grades[j].UserID
+	})
+	return grades, nil
+}
@@ -0,0 +1,38 @@
+package memImpl
+
+import (
+	"time"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type attendanceAlertRepositoryImpl struct {
+	store *Store
+}
+
+func NewAttendanceAlertRepository(store *Store) repositories.AttendanceAlertRepository {
+	return &attendanceAlertRepositoryImpl{store}
+}
+
+func (r *attendanceAlertRepositoryImpl) FindNotifiedStatuses(classID uint) (map[uint]domain.AttendanceStatus, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	statuses := make(map[uint]domain.AttendanceStatus)
+	for key, status := range r.store.notifiedStatuses {
+		if key[0] == classID {
+			statuses[key[1]] = status
+		}
+	}
+	return statuses, nil
+}
+
+func (r *attendanceAlertRepositoryImpl) SaveNotifiedStatus(classID uint, userID uint, status domain.AttendanceStatus, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if r.store.notifiedStatuses == nil {
+		r.store.notifiedStatuses = make(map[[2]uint]domain.AttendanceStatus)
+	}
+	r.store.notifiedStatuses[[2]uint{classID, userID}] = status
+	return nil
+}
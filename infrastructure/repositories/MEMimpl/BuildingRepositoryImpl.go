@@ -0,0 +1,68 @@
+package memImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type buildingRepositoryImpl struct {
+	store *Store
+}
+
+func NewBuildingRepository(store *Store) repositories.BuildingRepository {
+	return &buildingRepositoryImpl{store}
+}
+
+func (r *buildingRepositoryImpl) Create(building *domain.Building) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	building.BuildingID = r.store.buildings.nextID()
+	r.store.buildings.put(building.BuildingID, *building)
+	return nil
+}
+
+func (r *buildingRepositoryImpl) FindAll(page domain.Page) ([]domain.Building, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return paginate(r.store.buildings.all(), page), nil
+}
+
+func (r *buildingRepositoryImpl) FindByID(id uint) (*domain.Building, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	b, ok := r.store.buildings.get(id)
+	if !ok {
+		return nil, errNotFound
+	}
+	return &b, nil
+}
+
+func (r *buildingRepositoryImpl) FindByIDs(ids []uint) ([]domain.Building, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return r.store.buildings.getMany(ids), nil
+}
+
+func (r *buildingRepositoryImpl) Update(id uint, building *domain.Building) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.buildings.has(id) {
+		return nil
+	}
+	b := *building
+	b.BuildingID = id
+	r.store.buildings.put(id, b)
+	return nil
+}
+
+func (r *buildingRepositoryImpl) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, rm := range r.store.rooms.rows {
+		if rm.BuildingID == id {
+			return ErrForeignKey
+		}
+	}
+	r.store.buildings.delete(id)
+	return nil
+}
@@ -0,0 +1,34 @@
+package memImpl
+
+import (
+	"time"
+
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type checkInClaimRepositoryImpl struct {
+	store *Store
+}
+
+func NewCheckInClaimRepository(store *Store) repositories.CheckInClaimRepository {
+	return &checkInClaimRepositoryImpl{store}
+}
+
+func (r *checkInClaimRepositoryImpl) Claim(lectureID uint, userID uint, window int64, expiresAt time.Time, now time.Time) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for claim, expires := range r.store.checkInClaims {
+		if !now.Before(expires) {
+			delete(r.store.checkInClaims, claim)
+		}
+	}
+	claim := checkInClaim{lectureID: lectureID, userID: userID, window: window}
+	if _, ok := r.store.checkInClaims[claim]; ok {
+		return false, nil
+	}
+	if r.store.checkInClaims == nil {
+		r.store.checkInClaims = make(map[checkInClaim]time.Time)
+	}
+	r.store.checkInClaims[claim] = expiresAt
+	return true, nil
+}
@@ -0,0 +1,104 @@
+package memImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type classRepositoryImpl struct {
+	store *Store
+}
+
+func NewClassRepository(store *Store) repositories.ClassRepository {
+	return &classRepositoryImpl{store}
+}
+
+func (r *classRepositoryImpl) Create(class *domain.Class) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.disciplines.has(class.DisciplineID) {
+		return ErrForeignKey
+	}
+	class.ClassID = r.store.classes.nextID()
+	c := *class
+	c.Discipline = nil
+	r.store.classes.put(class.ClassID, c)
+	return nil
+}
+
+func (r *classRepositoryImpl) FindAll(page domain.Page) ([]domain.Class, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return paginate(r.store.classes.all(), page), nil
+}
+
+func (r *classRepositoryImpl) FindByID(id uint) (*domain.Class, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	c, ok := r.store.classes.get(id)
+	if !ok {
+		return nil, errNotFound
+	}
+	return &c, nil
+}
+
+func (r *classRepositoryImpl) FindByIDs(ids []uint) ([]domain.Class, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return r.store.classes.getMany(ids), nil
+}
+
+func (r *classRepositoryImpl) FindByDiscipline(disciplineID uint, page domain.Page) ([]domain.Class, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	var classes []domain.Class
+	for _, c := range r.store.classes.all() {
+		if c.DisciplineID == disciplineID {
+			classes = append(classes, c)
+		}
+	}
+	return paginate(classes, page), nil
+}
+
+func (r *classRepositoryImpl) Update(id uint, class *domain.Class) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.classes.has(id) {
+		return nil
+	}
+	if !r.store.disciplines.has(class.DisciplineID) {
+		return ErrForeignKey
+	}
+	c := *class
+	c.ClassID = id
+	c.Discipline = nil
+	r.store.classes.put(id, c)
+	return nil
+}
+
+func (r *classRepositoryImpl) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, l := range r.store.lectures.rows {
+		if l.ClassID == id {
+			return ErrForeignKey
+		}
+	}
+	for _, e := range r.store.enrollments.rows {
+		if e.ClassID == id {
+			return ErrForeignKey
+		}
+	}
+	for _, a := range r.store.assessments.rows {
+		if a.ClassID == id {
+			return ErrForeignKey
+		}
+	}
+	for key := range r.store.academicRecords {
+		if key[1] == id {
+			return ErrForeignKey
+		}
+	}
+	r.store.classes.delete(id)
+	return nil
+}
@@ -0,0 +1,15 @@
+package memImpl_test
+
+import (
+	"testing"
+
+	memimpl "sarc/infrastructure/repositories/MEMimpl"
+	repositories "sarc/infrastructure/repositories/interfaces"
+	"sarc/infrastructure/repositories/repotest"
+)
+
+func TestContract(t *testing.T) {
+	repotest.RunContract(t, func(t *testing.T) repositories.Repositories {
+		return memimpl.NewRepositories(memimpl.NewStore())
+	})
+}
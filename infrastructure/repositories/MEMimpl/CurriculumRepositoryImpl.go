@@ -0,0 +1,211 @@
+package memImpl
+
+import (
+	"sort"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type curriculumRepositoryImpl struct {
+	store *Store
+}
+
+func NewCurriculumRepository(store *Store) repositories.CurriculumRepository {
+	return &curriculumRepositoryImpl{store}
+}
+
+func (r *curriculumRepositoryImpl) Create(curriculum *domain.Curriculum) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	curriculum.ID = r.store.curriculums.nextID()
+	if curriculum.LineageID == 0 {
+		curriculum.LineageID = curriculum.ID
+	} else if !r.store.curriculums.has(curriculum.LineageID) {
+		return ErrForeignKey
+	}
+	for _, c := range r.store.curriculums.all() {
+		if c.LineageID == curriculum.LineageID && c.Version == curriculum.Version {
+			return ErrDuplicateKey
+		}
+	}
+	c := *curriculum
+	c.Disciplines = nil
+	r.store.curriculums.put(c.ID, c)
+	return nil
+}
+
+func (r *curriculumRepositoryImpl) FindByID(id uint) (*domain.Curriculum, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	c, ok := r.store.curriculums.get(id)
+	if !ok {
+		return nil, errNotFound
+	}
+	c.Disciplines = r.disciplinesOf(id)
+	return &c, nil
+}
+
+func (r *curriculumRepositoryImpl) FindAll(page domain.Page) ([]domain.Curriculum, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	curriculums := paginate(r.store.curriculums.all(), page)
+	for i := range curriculums {
+		curriculums[i].Disciplines = r.disciplinesOf(curriculums[i].ID)
+	}
+	return curriculums, nil
+}
+
+func (r *curriculumRepositoryImpl) FindByLineage(lineageID uint) ([]domain.Curriculum, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	var versions []domain.Curriculum
+	for _, c := range r.store.curriculums.all() {
+		if c.LineageID == lineageID {
+			c.Disciplines = r.disciplinesOf(c.ID)
+			versions = append(versions, c)
+		}
+	}
+	sort.SliceStable(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+	return versions, nil
+}
+
+// LockLineage has nothing to do: transactions are serialized.
+func (r *curriculumRepositoryImpl) LockLineage(id uint) error {
+	return nil
+}
+
+func (r *curriculumRepositoryImpl) Update(id uint, curriculum *domain.Curriculum) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	old, ok := r.store.curriculums.get(id)
+	if !ok {
+		return nil
+	}
+	c := *curriculum
+	c.ID = id
+	c.LineageID, c.Version = old.LineageID, old.Version
+	c.Disciplines = nil
+	r.store.curriculums.put(id, c)
+	return nil
+}
+
+func (r *curriculumRepositoryImpl) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if r.store.curriculumDisciplines.hasLeft(id) {
+		return ErrForeignKey
+	}
+	for _, c := range r.store.curriculums.all() {
+		if c.LineageID == id && c.ID != id {
+			return ErrForeignKey
+		}
+	}
+	r.store.curriculums.delete(id)
+	return nil
+}
+
+func (r *curriculumRepositoryImpl) AddDisciplineToCurriculum(curriculumID uint, discipline domain.Discipline) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.curriculums.has(curriculumID) || !r.store.disciplines.has(discipline.ID) {
+		return ErrForeignKey
+	}
+	if r.store.curriculumDisciplines.has(curriculumID, discipline.ID) {
+		return nil
+	}
+	if err := r.store.curriculumDisciplines.add(curriculumID, discipline.ID); err != nil {
+		return err
+	}
+	r.place(curriculumID, discipline)
+	return nil
+}
+
+func (r *curriculumRepositoryImpl) RemoveDisciplineFromCurriculum(curriculumID uint, disciplineID uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for key := range r.store.curriculumRequisites {
+		if key[0] == curriculumID && (key[1] == disciplineID || key[2] == disciplineID) {
+			delete(r.store.curriculumRequisites, key)
+		}
+	}
+	r.store.curriculumDisciplines.remove(curriculumID, disciplineID)
+	delete(r.store.curriculumPlacements, [2]uint{curriculumID, disciplineID})
+	return nil
+}
+
+func (r *curriculumRepositoryImpl) PlaceDiscipline(curriculumID uint, discipline domain.Discipline) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.curriculumDisciplines.has(curriculumID, discipline.ID) {
+		return errNotFound
+	}
+	r.place(curriculumID, discipline)
+	return nil
+}
+
+// place must be called with the store lock held.
+func (r *curriculumRepositoryImpl) place(curriculumID uint, discipline domain.Discipline) {
+	if r.store.curriculumPlacements == nil {
+		r.store.curriculumPlacements = make(map[[2]uint]placement)
+	}
+	r.store.curriculumPlacements[[2]uint{curriculumID, discipline.ID}] = placement{semester: discipline.Semester, elective: discipline.Elective}
+}
+
+func (r *curriculumRepositoryImpl) FindRequisites(curriculumID uint) ([]domain.Requisite, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	var requisites []domain.Requisite
+	for key, kind := range r.store.curriculumRequisites {
+		if key[0] == curriculumID {
+			requisites = append(requisites, domain.Requisite{CurriculumID: key[0], DisciplineID: key[1], RequisiteID: key[2], Kind: kind})
+		}
+	}
+	sort.Slice(requisites, func(i, j int) bool {
+		if requisites[i].DisciplineID != requisites[j].DisciplineID {
+			return requisites[i].DisciplineID < requisites[j].DisciplineID
+		}
+		return requisites[i].RequisiteID < requisites[j].RequisiteID
+	})
+	return requisites, nil
+}
+
+func (r *curriculumRepositoryImpl) AddRequisite(requisite *domain.Requisite) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.curriculumDisciplines.has(requisite.CurriculumID, requisite.DisciplineID) ||
+		!r.store.curriculumDisciplines.has(requisite.CurriculumID, requisite.RequisiteID) {
+		return ErrForeignKey
+	}
+	key := [3]uint{requisite.CurriculumID, requisite.DisciplineID, requisite.RequisiteID}
+	if _, ok := r.store.curriculumRequisites[key]; ok {
+		return ErrDuplicateKey
+	}
+	if r.store.curriculumRequisites == nil {
+		r.store.curriculumRequisites = make(map[[3]uint]domain.RequisiteKind)
+	}
+	r.store.curriculumRequisites[key] = requisite.Kind
+	return nil
+}
+
+func (r *curriculumRepositoryImpl) RemoveRequisite(curriculumID uint, disciplineID uint, requisiteID uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	delete(r.store.curriculumRequisites, [3]uint{curriculumID, disciplineID, requisiteID})
+	return nil
+}
+
+// disciplinesOf returns the disciplines of a curriculum ordered by semester
+// and ID. It must be called with the store lock held.
+func (r *curriculumRepositoryImpl) disciplinesOf(curriculumID uint) []domain.Discipline {
+	var disciplines []domain.Discipline
+	for _, id := range r.store.curriculumDisciplines.rightOf(curriculumID) {
+		d, _ := r.store.disciplines.get(id)
+		d = cloneDiscipline(d)
+		p := r.store.curriculumPlacements[[2]uint{curriculumID, id}]
+		d.Semester, d.Elective = p.semester, p.elective
+		disciplines = append(disciplines, d)
+	}
+	sort.SliceStable(disciplines, func(i, j int) bool { return disciplines[i].Semester < disciplines[j].Semester })
+	return disciplines
+}
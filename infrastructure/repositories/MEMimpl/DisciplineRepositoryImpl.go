@@ -0,0 +1,99 @@
+package memImpl
+
+import (
+	"slices"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type disciplineRepositoryImpl struct {
+	store *Store
+}
+
+func NewDisciplineRepository(store *Store) repositories.DisciplineRepository {
+	return &disciplineRepositoryImpl{store}
+}
+
+func (r *disciplineRepositoryImpl) Create(discipline *domain.Discipline) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	discipline.ID = r.store.disciplines.nextID()
+	r.store.disciplines.put(discipline.ID, cloneDiscipline(*discipline))
+	return nil
+}
+
+func (r *disciplineRepositoryImpl) FindAll(page domain.Page) ([]domain.Discipline, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	disciplines := paginate(r.store.disciplines.all(), page)
+	for i := range disciplines {
+		disciplines[i] = cloneDiscipline(disciplines[i])
+	}
+	return disciplines, nil
+}
+
+func (r *disciplineRepositoryImpl) FindByID(id uint) (*domain.Discipline, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	d, ok := r.store.disciplines.get(id)
+	if !ok {
+		return nil, errNotFound
+	}
+	d = cloneDiscipline(d)
+	return &d, nil
+}
+
+func (r *disciplineRepositoryImpl) FindByIDs(ids []uint) ([]domain.Discipline, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	disciplines := r.store.disciplines.getMany(ids)
+	for i := range disciplines {
+		disciplines[i] = cloneDiscipline(disciplines[i])
+	}
+	return disciplines, nil
+}
+
+func (r *disciplineRepositoryImpl) Update(id uint, discipline *domain.Discipline) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.disciplines.has(id) {
+		return nil
+	}
+	d := cloneDiscipline(*discipline)
+	d.ID = id
+	r.store.disciplines.put(id, d)
+	return nil
+}
+
+func (r *disciplineRepositoryImpl) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if r.store.curriculumDisciplines.hasRight(id) {
+		return ErrForeignKey
+	}
+	for _, c := range r.store.classes.rows {
+		if c.DisciplineID == id {
+			return ErrForeignKey
+		}
+	}
+	for _, e := range r.store.equivalences.rows {
+		if e.TargetID == id || slices.Contains(e.SourceIDs, id) {
+			return ErrForeignKey
+		}
+	}
+	for _, rec := range r.store.academicRecords {
+		if rec.DisciplineID == id {
+			return ErrForeignKey
+		}
+	}
+	r.store.disciplines.delete(id)
+	return nil
+}
+
+func cloneDiscipline(d domain.Discipline) domain.Discipline {
+	d.Bibliography = cloneStrings(d.Bibliography)
+	d.Semester = 0
+	d.Elective = false
+	return d
+}
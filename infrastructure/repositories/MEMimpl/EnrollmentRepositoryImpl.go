@@ -0,0 +1,122 @@
+package memImpl
+
+import (
+	"slices"
+	"sort"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type enrollmentRepositoryImpl struct {
+	store *Store
+}
+
+func NewEnrollmentRepository(store *Store) repositories.EnrollmentRepository {
+	return &enrollmentRepositoryImpl{store}
+}
+
+func (r *enrollmentRepositoryImpl) Create(enrollment *domain.Enrollment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.classes.has(enrollment.ClassID) || !r.store.users.has(enrollment.UserID) {
+		return ErrForeignKey
+	}
+	for _, e := range r.store.enrollments.rows {
+		if e.ClassID == enrollment.ClassID && e.UserID == enrollment.UserID {
+			return ErrDuplicateKey
+		}
+	}
+	enrollment.EnrollmentID = r.store.enrollments.nextID()
+	r.store.enrollments.put(enrollment.EnrollmentID, cloneEnrollment(*enrollment))
+	return nil
+}
+
+func (r *enrollmentRepositoryImpl) Update(enrollment *domain.Enrollment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	current, ok := r.store.enrollments.get(enrollment.EnrollmentID)
+	if !ok {
+		return nil
+	}
+	current.Role = enrollment.Role
+	current.Status = enrollment.Status
+	current.EnrolledAt = enrollment.EnrolledAt
+	current.DroppedAt = enrollment.DroppedAt
+	r.store.enrollments.put(current.EnrollmentID, cloneEnrollment(current))
+	return nil
+}
+
+func (r *enrollmentRepositoryImpl) Find(classID uint, userID uint) (*domain.Enrollment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	for _, e := range r.store.enrollments.rows {
+		if e.ClassID == classID && e.UserID == userID {
+			e = cloneEnrollment(e)
+			return &e, nil
+		}
+	}
+	return nil, errNotFound
+}
+
+func (r *enrollmentRepositoryImpl) FindByClass(classID uint) ([]domain.Enrollment, error) {
+	return r.FindByClasses([]uint{classID})
+}
+
+func (r *enrollmentRepositoryImpl) FindByClasses(classIDs []uint) ([]domain.Enrollment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	var enrollments []domain.Enrollment
+	for _, e := range r.store.enrollments.rows {
+		if slices.Contains(classIDs, e.ClassID) {
+			e = cloneEnrollment(e)
+			u, _ := r.store.users.get(e.UserID)
+			e.User = &u
+			enrollments = append(enrollments, e)
+		}
+	}
+	sort.Slice(enrollments, func(i, j int) bool {
+		if enrollments[i].ClassID != enrollments[j].ClassID {
+			return enrollments[i].ClassID < enrollments[j].ClassID
+		}
+		return enrollments[i].UserID < enrollments[j].UserID
+	})
+	return enrollments, nil
+}
+
+func (r *enrollmentRepositoryImpl) CountStudents(classIDs []uint) (map[uint]int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	counts := make(map[uint]int)
+	for _, e := range r.store.enrollments.rows {
+		if slices.Contains(classIDs, e.ClassID) && e.Role == domain.EnrollmentRoleStudent && e.Status == domain.EnrollmentActive {
+			counts[e.ClassID]++
+		}
+	}
+	return counts, nil
+}
+
+func (r *enrollmentRepositoryImpl) FindByUser(userID uint) ([]domain.Enrollment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	var enrollments []domain.Enrollment
+	for _, e := range r.store.enrollments.rows {
+		if e.UserID == userID {
+			enrollments = append(enrollments, cloneEnrollment(e))
+		}
+	}
+	sort.Slice(enrollments, func(i, j int) bool { return enrollments[i].ClassID < enrollments[j].ClassID })
+	return enrollments, nil
+}
+
+// cloneEnrollment copies DroppedAt so callers never share it with the store,
+// and drops the user, which is not stored.
+func cloneEnrollment(e domain.Enrollment) domain.Enrollment {
+	if e.DroppedAt != nil {
+		dropped := *e.DroppedAt
+		e.DroppedAt = &dropped
+	}
+	e.User = nil
+	e.Warnings = nil
+	return e
+}
@@ -0,0 +1,85 @@
+package memImpl
+
+import (
+	"slices"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type equivalenceRepositoryImpl struct {
+	store *Store
+}
+
+func NewEquivalenceRepository(store *Store) repositories.EquivalenceRepository {
+	return &equivalenceRepositoryImpl{store}
+}
+
+func (r *equivalenceRepositoryImpl) Create(equivalence *domain.Equivalence) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.disciplines.has(equivalence.TargetID) {
+		return ErrForeignKey
+	}
+	for i, sourceID := range equivalence.SourceIDs {
+		if !r.store.disciplines.has(sourceID) {
+			return ErrForeignKey
+		}
+		if slices.Contains(equivalence.SourceIDs[:i], sourceID) {
+			return ErrDuplicateKey
+		}
+	}
+	equivalence.ID = r.store.equivalences.nextID()
+	r.store.equivalences.put(equivalence.ID, cloneEquivalence(*equivalence))
+	return nil
+}
+
+func (r *equivalenceRepositoryImpl) FindByID(id uint) (*domain.Equivalence, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	e, ok := r.store.equivalences.get(id)
+	if !ok {
+		return nil, errNotFound
+	}
+	e = cloneEquivalence(e)
+	return &e, nil
+}
+
+func (r *equivalenceRepositoryImpl) FindAll(page domain.Page) ([]domain.Equivalence, error) {
+	return paginate(r.filter(func(domain.Equivalence) bool { return true }), page), nil
+}
+
+func (r *equivalenceRepositoryImpl) FindByDiscipline(disciplineID uint, page domain.Page) ([]domain.Equivalence, error) {
+	return paginate(r.filter(func(e domain.Equivalence) bool {
+		return e.TargetID == disciplineID || slices.Contains(e.SourceIDs, disciplineID)
+	}), page), nil
+}
+
+func (r *equivalenceRepositoryImpl) FindByTargets(targetIDs []uint) ([]domain.Equivalence, error) {
+	return r.filter(func(e domain.Equivalence) bool { return slices.Contains(targetIDs, e.TargetID) }), nil
+}
+
+func (r *equivalenceRepositoryImpl) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.equivalences.delete(id)
+	return nil
+}
+
+func (r *equivalenceRepositoryImpl) filter(keep func(domain.Equivalence) bool) []domain.Equivalence {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	var equivalences []domain.Equivalence
+	for _, e := range r.store.equivalences.all() {
+		if keep(e) {
+			equivalences = append(equivalences, cloneEquivalence(e))
+		}
+	}
+	return equivalences
+}
+
+func cloneEquivalence(e domain.Equivalence) domain.Equivalence {
+	e.SourceIDs = slices.Clone(e.SourceIDs)
+	slices.Sort(e.SourceIDs)
+	return e
+}
@@ -0,0 +1,147 @@
+package memImpl
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type lectureRepositoryImpl struct {
+	store *Store
+}
+
+func NewLectureRepository(store *Store) repositories.LectureRepository {
+	return &lectureRepositoryImpl{store}
+}
+
+func (r *lectureRepositoryImpl) Create(lecture *domain.Lecture) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.classes.has(lecture.ClassID) || !r.store.rooms.has(lecture.RoomID) {
+		return ErrForeignKey
+	}
+	lecture.LectureID = r.store.lectures.nextID()
+	r.store.lectures.put(lecture.LectureID, cloneLecture(*lecture))
+	return nil
+}
+
+func (r *lectureRepositoryImpl) FindAll(page domain.Page) ([]domain.Lecture, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	lectures := paginate(r.store.lectures.all(), page)
+	for i := range lectures {
+		lectures[i] = cloneLecture(lectures[i])
+	}
+	return lectures, nil
+}
+
+func (r *lectureRepositoryImpl) StreamAll(fn func(domain.Lecture) error) error {
+	lectures, err := r.FindAll(domain.Page{})
+	if err != nil {
+		return err
+	}
+	return each(lectures, fn)
+}
+
+func (r *lectureRepositoryImpl) FindByID(id uint) (*domain.Lecture, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	l, ok := r.store.lectures.get(id)
+	if !ok {
+		return nil, errNotFound
+	}
+	l = cloneLecture(l)
+	return &l, nil
+}
+
+func (r *lectureRepositoryImpl) FindByIDs(ids []uint) ([]domain.Lecture, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	lectures := r.store.lectures.getMany(ids)
+	for i := range lectures {
+		lectures[i] = cloneLecture(lectures[i])
+	}
+	return lectures, nil
+}
+
+func (r *lectureRepositoryImpl) FindByClass(classID uint, page domain.Page) ([]domain.Lecture, error) {
+	return r.findBy(func(l domain.Lecture) bool { return l.ClassID == classID }, page)
+}
+
+func (r *lectureRepositoryImpl) FindByRoom(roomID uint, page domain.Page) ([]domain.Lecture, error) {
+	return r.findBy(func(l domain.Lecture) bool { return l.RoomID == roomID }, page)
+}
+
+func (r *lectureRepositoryImpl) FindBetween(from, to string) ([]domain.Lecture, error) {
+	return r.findBy(func(l domain.Lecture) bool { return l.Date >= from && l.Date <= to }, domain.Page{})
+}
+
+func (r *lectureRepositoryImpl) FindByClasses(classIDs []uint, from, to string) ([]domain.Lecture, error) {
+	from, to = dateBounds(from, to)
+	return r.findBy(func(l domain.Lecture) bool {
+		return slices.Contains(classIDs, l.ClassID) && l.Date >= from && l.Date <= to
+	}, domain.Page{})
+}
+
+func (r *lectureRepositoryImpl) findBy(match func(domain.Lecture) bool, page domain.Page) ([]domain.Lecture, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	var lectures []domain.Lecture
+	for _, l := range r.store.lectures.all() {
+		if match(l) {
+			lectures = append(lectures, cloneLecture(l))
+		}
+	}
+	sort.SliceStable(lectures, func(i, j int) bool { return lectures[i].Date < lectures[j].Date })
+	return paginate(lectures, page), nil
+}
+
+func (r *lectureRepositoryImpl) Update(id uint, lecture *domain.Lecture) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.lectures.has(id) {
+		return nil
+	}
+	if !r.store.classes.has(lecture.ClassID) || !r.store.rooms.has(lecture.RoomID) {
+		return ErrForeignKey
+	}
+	l := cloneLecture(*lecture)
+	l.LectureID = id
+	r.store.lectures.put(id, l)
+	return nil
+}
+
+func (r *lectureRepositoryImpl) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, rsv := range r.store.reservations.rows {
+		if rsv.LectureID == id {
+			return ErrForeignKey
+		}
+	}
+	if r.store.hasPresence(func(lectureID, _ uint) bool { return lectureID == id }) {
+		return ErrForeignKey
+	}
+	r.store.lectures.delete(id)
+	return nil
+}
+
+// cloneLecture copies the content array and drops the relations the lectures
+// table does not store.
+func cloneLecture(l domain.Lecture) domain.Lecture {
+	l.Content = cloneStrings(l.Content)
+	l.Presence = nil
+	l.Class = nil
+	l.Room = nil
+	l.Warnings = nil
+	return l
+}
+
+// dateBounds fills in the empty ends of a date range with dates no lecture
+// falls outside of.
+func dateBounds(from, to string) (string, string) {
+	return cmp.Or(from, "0001-01-01"), cmp.Or(to, "9999-12-31")
+}
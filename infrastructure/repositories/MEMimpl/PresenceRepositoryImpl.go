@@ -0,0 +1,102 @@
+package memImpl
+
+import (
+	"sort"
+	"time"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type presenceRepositoryImpl struct {
+	store *Store
+}
+
+func NewPresenceRepository(store *Store) repositories.PresenceRepository {
+	return &presenceRepositoryImpl{store}
+}
+
+func (r *presenceRepositoryImpl) Mark(lectureID uint, userIDs []uint, method domain.PresenceMethod, at time.Time) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.lectures.has(lectureID) {
+		return 0, ErrForeignKey
+	}
+	for _, id := range userIDs {
+		if !r.store.users.has(id) {
+			return 0, ErrForeignKey
+		}
+	}
+	if r.store.lecturePresence == nil {
+		r.store.lecturePresence = make(map[[2]uint]domain.Presence)
+	}
+	added := 0
+	for _, id := range userIDs {
+		key := [2]uint{lectureID, id}
+		if _, ok := r.store.lecturePresence[key]; ok {
+			continue
+		}
+		r.store.lecturePresence[key] = domain.Presence{LectureID: lectureID, UserID: id, Method: method, MarkedAt: at}
+		added++
+	}
+	return added, nil
+}
+
+func (r *presenceRepositoryImpl) Unmark(lectureID uint, userID uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	delete(r.store.lecturePresence, [2]uint{lectureID, userID})
+	return nil
+}
+
+func (r *presenceRepositoryImpl) FindByLectures(lectureIDs []uint) ([]domain.Presence, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	wanted := make(map[uint]bool, len(lectureIDs))
+	for _, id := range lectureIDs {
+		wanted[id] = true
+	}
+	var presence []domain.Presence
+	for key, p := range r.store.lecturePresence {
+		if wanted[key[0]] {
+			u, _ := r.store.users.get(p.UserID)
+			p.User = &u
+			presence = append(presence, p)
+		}
+	}
+	sort.Slice(presence, func(i, j int) bool {
+		a, b := presence[i], presence[j]
+		if a.LectureID != b.LectureID {
+			return a.LectureID < b.LectureID
+		}
+		if !a.MarkedAt.Equal(b.MarkedAt) {
+			return a.MarkedAt.Before(b.MarkedAt)
+		}
+		return a.UserID < b.UserID
+	})
+	return presence, nil
+}
+
+func (r *presenceRepositoryImpl) FindByUser(userID uint) ([]domain.Presence, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	var presence []domain.Presence
+	for key, p := range r.store.lecturePresence {
+		if key[1] == userID {
+			presence = append(presence, p)
+		}
+	}
+	sort.Slice(presence, func(i, j int) bool { return presence[i].LectureID < presence[j].LectureID })
+	return presence, nil
+}
+
+// hasPresence reports whether any presence row matches; the store lock must
+// be held.
+func (s *Store) hasPresence(match func(lectureID, userID uint) bool) bool {
+	for key := range s.lecturePresence {
+		if match(key[0], key[1]) {
+			return true
+		}
+	}
+	return false
+}
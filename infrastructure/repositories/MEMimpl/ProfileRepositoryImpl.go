@@ -0,0 +1,62 @@
+package memImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type profileRepositoryImpl struct {
+	store *Store
+}
+
+func NewProfileRepository(store *Store) repositories.ProfileRepository {
+	return &profileRepositoryImpl{store}
+}
+
+func (r *profileRepositoryImpl) Create(profile *domain.Profile) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	profile.ID = r.store.profiles.nextID()
+	r.store.profiles.put(profile.ID, *profile)
+	return nil
+}
+
+func (r *profileRepositoryImpl) FindAll(page domain.Page) ([]domain.Profile, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return paginate(r.store.profiles.all(), page), nil
+}
+
+func (r *profileRepositoryImpl) FindByID(id uint) (*domain.Profile, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	p, ok := r.store.profiles.get(id)
+	if !ok {
+		return nil, errNotFound
+	}
+	return &p, nil
+}
+
+func (r *profileRepositoryImpl) Update(id uint, profile *domain.Profile) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.profiles.has(id) {
+		return nil
+	}
+	p := *profile
+	p.ID = id
+	r.store.profiles.put(id, p)
+	return nil
+}
+
+func (r *profileRepositoryImpl) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, u := range r.store.users.rows {
+		if u.ProfileID == id {
+			return ErrForeignKey
+		}
+	}
+	r.store.profiles.delete(id)
+	return nil
+}
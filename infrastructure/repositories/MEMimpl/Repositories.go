@@ -0,0 +1,31 @@
+package memImpl
+
+import (
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+// NewRepositories returns the in-memory implementation of every repository,
+// all backed by one Store.
+func NewRepositories(store *Store) repositories.Repositories {
+	return repositories.Repositories{
+		Profile:         NewProfileRepository(store),
+		User:            NewUserRepository(store),
+		Building:        NewBuildingRepository(store),
+		Room:            NewRoomRepository(store),
+		Discipline:      NewDisciplineRepository(store),
+		Curriculum:      NewCurriculumRepository(store),
+		Class:           NewClassRepository(store),
+		Lecture:         NewLectureRepository(store),
+		ResourceType:    NewResourceTypeRepository(store),
+		Resource:        NewResourceRepository(store),
+		Reservation:     NewReservationRepository(store),
+		Search:          NewSearchRepository(store),
+		Presence:        NewPresenceRepository(store),
+		CheckInClaim:    NewCheckInClaimRepository(store),
+		AttendanceAlert: NewAttendanceAlertRepository(store),
+		Enrollment:      NewEnrollmentRepository(store),
+		Equivalence:     NewEquivalenceRepository(store),
+		Assessment:      NewAssessmentRepository(store),
+		Record:          NewAcademicRecordRepository(store),
+	}
+}
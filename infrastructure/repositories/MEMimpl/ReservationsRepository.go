@@ -0,0 +1,139 @@
+package memImpl
+
+import (
+	"sort"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type reservationRepositoryImpl struct {
+	store *Store
+}
+
+func NewReservationRepository(store *Store) repositories.ReservationRepository {
+	return &reservationRepositoryImpl{store}
+}
+
+func (r *reservationRepositoryImpl) Create(reservation *domain.Reservation) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.lectures.has(reservation.LectureID) {
+		return ErrForeignKey
+	}
+	reservation.ReservationID = r.store.reservations.nextID()
+	rsv := *reservation
+	rsv.Resources = nil
+	rsv.Lecture = nil
+	r.store.reservations.put(rsv.ReservationID, rsv)
+	return nil
+}
+
+func (r *reservationRepositoryImpl) FindByID(id uint) (*domain.Reservation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	rsv, ok := r.store.reservations.get(id)
+	if !ok {
+		return nil, errNotFound
+	}
+	rsv.Resources = r.resourcesOf(id)
+	return &rsv, nil
+}
+
+func (r *reservationRepositoryImpl) FindAll(page domain.Page) ([]domain.Reservation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	reservations := paginate(r.store.reservations.all(), page)
+	for i := range reservations {
+		reservations[i].Resources = r.resourcesOf(reservations[i].ReservationID)
+	}
+	return reservations, nil
+}
+
+func (r *reservationRepositoryImpl) FindByLecture(lectureID uint, page domain.Page) ([]domain.Reservation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	var reservations []domain.Reservation
+	for _, rsv := range r.store.reservations.all() {
+		if rsv.LectureID == lectureID {
+			reservations = append(reservations, rsv)
+		}
+	}
+	reservations = paginate(reservations, page)
+	for i := range reservations {
+		reservations[i].Resources = r.resourcesOf(reservations[i].ReservationID)
+	}
+	return reservations, nil
+}
+
+func (r *reservationRepositoryImpl) FindByLectures(lectureIDs []uint) ([]domain.Reservation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	wanted := make(map[uint]bool, len(lectureIDs))
+	for _, id := range lectureIDs {
+		wanted[id] = true
+	}
+	var reservations []domain.Reservation
+	for _, rsv := range r.store.reservations.all() {
+		if wanted[rsv.LectureID] {
+			rsv.Resources = r.resourcesOf(rsv.ReservationID)
+			reservations = append(reservations, rsv)
+		}
+	}
+	sort.SliceStable(reservations, func(i, j int) bool { return reservations[i].LectureID < reservations[j].LectureID })
+	return reservations, nil
+}
+
+func (r *reservationRepositoryImpl) StreamAll(fn func(domain.Reservation) error) error {
+	reservations, err := r.FindAll(domain.Page{})
+	if err != nil {
+		return err
+	}
+	return each(reservations, fn)
+}
+
+func (r *reservationRepositoryImpl) Update(id uint, reservation *domain.Reservation) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.reservations.has(id) {
+		return nil
+	}
+	if !r.store.lectures.has(reservation.LectureID) {
+		return ErrForeignKey
+	}
+	rsv := *reservation
+	rsv.ReservationID = id
+	rsv.Resources = nil
+	rsv.Lecture = nil
+	r.store.reservations.put(id, rsv)
+	return nil
+}
+
+func (r *reservationRepositoryImpl) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if r.store.reservationResources.hasLeft(id) {
+		return ErrForeignKey
+	}
+	r.store.reservations.delete(id)
+	return nil
+}
+
+func (r *reservationRepositoryImpl) AddResourceToReservation(reservationID uint, resourceID uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.reservations.has(reservationID) || !r.store.resources.has(resourceID) {
+		return ErrForeignKey
+	}
+	return r.store.reservationResources.add(reservationID, resourceID)
+}
+
+// resourcesOf must be called with the store lock held.
+func (r *reservationRepositoryImpl) resourcesOf(reservationID uint) []domain.Resource {
+	var resources []domain.Resource
+	for _, id := range r.store.reservationResources.rightOf(reservationID) {
+		res, _ := r.store.resources.get(id)
+		resources = append(resources, cloneResource(res))
+	}
+	return resources
+}
@@ -0,0 +1,107 @@
+package memImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type resourceRepositoryImpl struct {
+	store *Store
+}
+
+func NewResourceRepository(store *Store) repositories.ResourceRepository {
+	return &resourceRepositoryImpl{store}
+}
+
+func (r *resourceRepositoryImpl) Create(resource *domain.Resource) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.resourceTypes.has(resource.ResourceTypeID) {
+		return ErrForeignKey
+	}
+	resource.ResourceID = r.store.resources.nextID()
+	r.store.resources.put(resource.ResourceID, cloneResource(*resource))
+	return nil
+}
+
+func (r *resourceRepositoryImpl) FindAll(page domain.Page) ([]domain.Resource, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	resources := paginate(r.store.resources.all(), page)
+	for i := range resources {
+		resources[i] = r.withType(resources[i])
+	}
+	return resources, nil
+}
+
+func (r *resourceRepositoryImpl) StreamAll(fn func(domain.Resource) error) error {
+	resources, err := r.FindAll(domain.Page{})
+	if err != nil {
+		return err
+	}
+	return each(resources, fn)
+}
+
+func (r *resourceRepositoryImpl) FindByResourceType(resourceTypeID uint) ([]domain.Resource, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	var resources []domain.Resource
+	for _, res := range r.store.resources.all() {
+		if res.ResourceTypeID == resourceTypeID {
+			resources = append(resources, r.withType(res))
+		}
+	}
+	return resources, nil
+}
+
+func (r *resourceRepositoryImpl) FindByID(id uint) (*domain.Resource, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	res, ok := r.store.resources.get(id)
+	if !ok {
+		return nil, errNotFound
+	}
+	res = r.withType(res)
+	return &res, nil
+}
+
+func (r *resourceRepositoryImpl) Update(id uint, resource *domain.Resource) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.resources.has(id) {
+		return nil
+	}
+	if !r.store.resourceTypes.has(resource.ResourceTypeID) {
+		return ErrForeignKey
+	}
+	res := cloneResource(*resource)
+	res.ResourceID = id
+	r.store.resources.put(id, res)
+	return nil
+}
+
+func (r *resourceRepositoryImpl) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if r.store.reservationResources.hasRight(id) {
+		return ErrForeignKey
+	}
+	r.store.resources.delete(id)
+	return nil
+}
+
+// withType mirrors the LEFT JOIN on resource_types done by the SQL
+// implementation. It must be called with the store lock held.
+func (r *resourceRepositoryImpl) withType(res domain.Resource) domain.Resource {
+	res = cloneResource(res)
+	t, _ := r.store.resourceTypes.get(res.ResourceTypeID)
+	res.ResourceType = &t
+	return res
+}
+
+// cloneResource copies the characteristics array and drops the joined type.
+func cloneResource(res domain.Resource) domain.Resource {
+	res.Characteristics = cloneStrings(res.Characteristics)
+	res.ResourceType = nil
+	return res
+}
@@ -0,0 +1,68 @@
+package memImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type resourceTypeRepositoryImpl struct {
+	store *Store
+}
+
+func NewResourceTypeRepository(store *Store) repositories.ResourceTypeRepository {
+	return &resourceTypeRepositoryImpl{store}
+}
+
+func (r *resourceTypeRepositoryImpl) Create(resourceType *domain.ResourceType) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	resourceType.ResourceTypeID = r.store.resourceTypes.nextID()
+	r.store.resourceTypes.put(resourceType.ResourceTypeID, *resourceType)
+	return nil
+}
+
+func (r *resourceTypeRepositoryImpl) FindAll(page domain.Page) ([]domain.ResourceType, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return paginate(r.store.resourceTypes.all(), page), nil
+}
+
+func (r *resourceTypeRepositoryImpl) FindByID(id uint) (*domain.ResourceType, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	t, ok := r.store.resourceTypes.get(id)
+	if !ok {
+		return nil, errNotFound
+	}
+	return &t, nil
+}
+
+func (r *resourceTypeRepositoryImpl) FindByIDs(ids []uint) ([]domain.ResourceType, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return r.store.resourceTypes.getMany(ids), nil
+}
+
+func (r *resourceTypeRepositoryImpl) Update(id uint, resourceType *domain.ResourceType) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.resourceTypes.has(id) {
+		return nil
+	}
+	t := *resourceType
+	t.ResourceTypeID = id
+	r.store.resourceTypes.put(id, t)
+	return nil
+}
+
+func (r *resourceTypeRepositoryImpl) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, res := range r.store.resources.rows {
+		if res.ResourceTypeID == id {
+			return ErrForeignKey
+		}
+	}
+	r.store.resourceTypes.delete(id)
+	return nil
+}
@@ -0,0 +1,97 @@
+package memImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type roomRepositoryImpl struct {
+	store *Store
+}
+
+func NewRoomRepository(store *Store) repositories.RoomRepository {
+	return &roomRepositoryImpl{store}
+}
+
+func (r *roomRepositoryImpl) Create(room *domain.Room) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.buildings.has(room.BuildingID) {
+		return ErrForeignKey
+	}
+	room.RoomID = r.store.rooms.nextID()
+	rm := *room
+	rm.Building = nil
+	r.store.rooms.put(room.RoomID, rm)
+	return nil
+}
+
+func (r *roomRepositoryImpl) FindAll(page domain.Page) ([]domain.Room, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return paginate(r.store.rooms.all(), page), nil
+}
+
+func (r *roomRepositoryImpl) StreamAll(fn func(domain.Room) error) error {
+	rooms, err := r.FindAll(domain.Page{})
+	if err != nil {
+		return err
+	}
+	return each(rooms, fn)
+}
+
+func (r *roomRepositoryImpl) FindByID(id uint) (*domain.Room, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	rm, ok := r.store.rooms.get(id)
+	if !ok {
+		return nil, errNotFound
+	}
+	return &rm, nil
+}
+
+func (r *roomRepositoryImpl) FindByIDs(ids []uint) ([]domain.Room, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return r.store.rooms.getMany(ids), nil
+}
+
+func (r *roomRepositoryImpl) FindByBuilding(buildingID uint, page domain.Page) ([]domain.Room, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	var rooms []domain.Room
+	for _, rm := range r.store.rooms.all() {
+		if rm.BuildingID == buildingID {
+			rooms = append(rooms, rm)
+		}
+	}
+	return paginate(rooms, page), nil
+}
+
+func (r *roomRepositoryImpl) Update(id uint, room *domain.Room) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.rooms.has(id) {
+		return nil
+	}
+	if !r.store.buildings.has(room.BuildingID) {
+		return ErrForeignKey
+	}
+	rm := *room
+	rm.RoomID = id
+	rm.Building = nil
+	r.store.rooms.put(id, rm)
+	return nil
+}
+
+func (r *roomRepositoryImpl) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, l := range r.store.lectures.rows {
+		if l.RoomID == id {
+			return ErrForeignKey
+		}
+	}
+	r.store.rooms.delete(id)
+	return nil
+}
@@ -0,0 +1,75 @@
+package memImpl
+
+import (
+	"strings"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type searchRepositoryImpl struct {
+	store *Store
+}
+
+func NewSearchRepository(store *Store) repositories.SearchRepository {
+	return &searchRepositoryImpl{store}
+}
+
+// Search matches each word as a case-insensitive substring and returns every
+// match; ranking and limiting are left to the service.
+func (r *searchRepositoryImpl) Search(q domain.SearchQuery) ([]domain.SearchHit, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var hits []domain.SearchHit
+	add := func(hit domain.SearchHit) {
+		if containsAny(hit.Text, q.Terms) {
+			hits = append(hits, hit)
+		}
+	}
+	for _, d := range r.store.disciplines.all() {
+		add(domain.SearchHit{Type: domain.SearchTypeDiscipline, ID: d.ID, Title: d.Name,
+			Text: domain.SearchText(d.Name, d.Program, strings.Join(d.Bibliography, "; "))})
+	}
+	for _, res := range r.store.resources.all() {
+		add(domain.SearchHit{Type: domain.SearchTypeResource, ID: res.ResourceID, Title: res.Description,
+			Text: domain.SearchText(res.Description, strings.Join(res.Characteristics, "; "))})
+	}
+	for _, b := range r.store.buildings.all() {
+		add(domain.SearchHit{Type: domain.SearchTypeBuilding, ID: b.BuildingID, Title: b.BuildingName,
+			Text: domain.SearchText(b.BuildingName, b.Address)})
+	}
+	for _, rm := range r.store.rooms.all() {
+		b, _ := r.store.buildings.get(rm.BuildingID)
+		hit := domain.SearchHit{Type: domain.SearchTypeRoom, ID: rm.RoomID, Title: strings.TrimSpace(b.BuildingName + " " + rm.RoomNumber),
+			Text: domain.SearchText(b.BuildingName, b.Address, rm.RoomNumber)}
+		if containsAny(domain.SearchText(b.BuildingName, b.Address), q.Terms) || equalsAny(rm.RoomNumber, q.Terms) {
+			hits = append(hits, hit)
+		}
+	}
+	for _, c := range r.store.classes.all() {
+		add(domain.SearchHit{Type: domain.SearchTypeClass, ID: c.ClassID, Title: c.Name,
+			Text: domain.SearchText(c.Name, c.Description)})
+	}
+	return hits, nil
+}
+
+func containsAny(text string, terms []string) bool {
+	text = strings.ToLower(text)
+	for _, term := range terms {
+		if strings.Contains(text, term) {
+			return true
+		}
+	}
+	return false
+}
+
+func equalsAny(s string, terms []string) bool {
+	s = strings.ToLower(s)
+	for _, term := range terms {
+		if s == term {
+			return true
+		}
+	}
+	return false
+}
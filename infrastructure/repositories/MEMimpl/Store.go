@@ -0,0 +1,229 @@
+package memImpl
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"sarc/core/domain"
+)
+
+// Errors returned where Postgres would reject the statement with a
+// constraint violation. Missing rows are reported with sql.ErrNoRows, exactly
+// like the SQL implementations.
+var (
+	ErrForeignKey   = errors.New("foreign key violation")
+	ErrDuplicateKey = errors.New("duplicate key")
+)
+
+var errNotFound = sql.ErrNoRows
+
+// Store holds the rows of every in-memory repository. Repositories created
+// from the same Store see each other's data, like tables in one database, and
+// the same foreign keys are enforced.
+type Store struct {
+	mu sync.RWMutex
+
+	profiles      table[domain.Profile]
+	users         table[domain.User]
+	buildings     table[domain.Building]
+	rooms         table[domain.Room]
+	disciplines   table[domain.Discipline]
+	curriculums   table[domain.Curriculum]
+	classes       table[domain.Class]
+	lectures      table[domain.Lecture]
+	resourceTypes table[domain.ResourceType]
+	resources     table[domain.Resource]
+	reservations  table[domain.Reservation]
+	enrollments   table[domain.Enrollment]
+	equivalences  table[domain.Equivalence]
+	assessments   table[domain.Assessment]
+
+	curriculumDisciplines links
+	reservationResources  links
+
+	// lecturePresence is keyed by (lecture ID, user ID).
+	lecturePresence map[[2]uint]domain.Presence
+	// curriculumPlacements holds the semester and elective flag of each
+	// (curriculum ID, discipline ID) link in curriculumDisciplines.
+	curriculumPlacements map[[2]uint]placement
+	// curriculumRequisites is keyed by (curriculum ID, discipline ID,
+	// requisite ID).
+	curriculumRequisites map[[3]uint]domain.RequisiteKind
+	// grades is keyed by (assessment ID, user ID).
+	grades map[[2]uint]float64
+	// academicRecords is keyed by (user ID, class ID).
+	academicRecords map[[2]uint]domain.AcademicRecord
+	// checkInClaims holds when each claim of a check-in token expires.
+	checkInClaims map[checkInClaim]time.Time
+	// notifiedStatuses is keyed by (class ID, user ID).
+	notifiedStatuses map[[2]uint]domain.AttendanceStatus
+}
+
+type checkInClaim struct {
+	lectureID, userID uint
+	window            int64
+}
+
+func NewStore() *Store {
+	return &Store{}
+}
+
+type placement struct {
+	semester int
+	elective bool
+}
+
+// table is a map of rows keyed by a SERIAL-like ID.
+type table[T any] struct {
+	rows   map[uint]T
+	lastID uint
+}
+
+func (t *table[T]) nextID() uint {
+	t.lastID++
+	return t.lastID
+}
+
+func (t *table[T]) put(id uint, row T) {
+	if t.rows == nil {
+		t.rows = make(map[uint]T)
+	}
+	t.rows[id] = row
+}
+
+func (t *table[T]) get(id uint) (T, bool) {
+	row, ok := t.rows[id]
+	return row, ok
+}
+
+func (t *table[T]) has(id uint) bool {
+	_, ok := t.rows[id]
+	return ok
+}
+
+func (t *table[T]) delete(id uint) {
+	delete(t.rows, id)
+}
+
+// all returns the rows ordered by ID, or nil when the table is empty.
+func (t *table[T]) all() []T {
+	if len(t.rows) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(t.rows))
+	for id := range t.rows {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	out := make([]T, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, t.rows[id])
+	}
+	return out
+}
+
+// getMany returns the rows with the given IDs ordered by ID, skipping
+// missing ones and duplicates.
+func (t *table[T]) getMany(ids []uint) []T {
+	sorted := append([]uint(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var out []T
+	for i, id := range sorted {
+		if i > 0 && id == sorted[i-1] {
+			continue
+		}
+		if row, ok := t.rows[id]; ok {
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+// links is a junction table with a composite primary key (left, right).
+type links struct {
+	pairs map[[2]uint]bool
+}
+
+func (l *links) add(left, right uint) error {
+	if l.pairs == nil {
+		l.pairs = make(map[[2]uint]bool)
+	}
+	key := [2]uint{left, right}
+	if l.pairs[key] {
+		return ErrDuplicateKey
+	}
+	l.pairs[key] = true
+	return nil
+}
+
+func (l *links) has(left, right uint) bool {
+	return l.pairs[[2]uint{left, right}]
+}
+
+func (l *links) remove(left, right uint) {
+	delete(l.pairs, [2]uint{left, right})
+}
+
+// rightOf returns the right-hand IDs linked to left, in ascending order.
+func (l *links) rightOf(left uint) []uint {
+	var out []uint
+	for key := range l.pairs {
+		if key[0] == left {
+			out = append(out, key[1])
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func (l *links) hasLeft(left uint) bool {
+	for key := range l.pairs {
+		if key[0] == left {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *links) hasRight(right uint) bool {
+	for key := range l.pairs {
+		if key[1] == right {
+			return true
+		}
+	}
+	return false
+}
+
+// each calls fn for every row, stopping at the first error.
+func each[T any](rows []T, fn func(T) error) error {
+	for _, row := range rows {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// paginate returns the window of rows selected by page.
+func paginate[T any](rows []T, page domain.Page) []T {
+	if page.Offset >= len(rows) {
+		return nil
+	}
+	rows = rows[page.Offset:]
+	if page.Limit > 0 && page.Limit < len(rows) {
+		rows = rows[:page.Limit]
+	}
+	return rows
+}
+
+func cloneStrings[S ~[]string](s S) S {
+	if s == nil {
+		return nil
+	}
+	out := make(S, len(s))
+	copy(out, s)
+	return out
+}
@@ -0,0 +1,106 @@
+package memImpl
+
+import (
+	"maps"
+	"sync"
+
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type transactor struct {
+	store *Store
+	mu    sync.Mutex
+}
+
+// NewTransactor returns a Transactor for store. Each unit of work runs
+// against a snapshot of the store that replaces it on success and is thrown
+// away on error. Transactions are serialized with each other, but writes made
+// outside a transaction while one is running are lost when it commits.
+func NewTransactor(store *Store) repositories.Transactor {
+	return &transactor{store: store}
+}
+
+func (t *transactor) WithinTransaction(fn func(repos repositories.Repositories) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snapshot := t.store.clone()
+	if err := fn(NewRepositories(snapshot)); err != nil {
+		return err
+	}
+	t.store.restore(snapshot)
+	return nil
+}
+
+// clone copies every table of the store. Rows are stored by value and never
+// modified in place, so copying the maps is enough.
+func (s *Store) clone() *Store {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &Store{
+		profiles:              s.profiles.clone(),
+		users:                 s.users.clone(),
+		buildings:             s.buildings.clone(),
+		rooms:                 s.rooms.clone(),
+		disciplines:           s.disciplines.clone(),
+		curriculums:           s.curriculums.clone(),
+		classes:               s.classes.clone(),
+		lectures:              s.lectures.clone(),
+		resourceTypes:         s.resourceTypes.clone(),
+		resources:             s.resources.clone(),
+		reservations:          s.reservations.clone(),
+		enrollments:           s.enrollments.clone(),
+		equivalences:          s.equivalences.clone(),
+		assessments:           s.assessments.clone(),
+		curriculumDisciplines: s.curriculumDisciplines.clone(),
+		reservationResources:  s.reservationResources.clone(),
+		lecturePresence:       maps.Clone(s.lecturePresence),
+		curriculumPlacements:  maps.Clone(s.curriculumPlacements),
+		curriculumRequisites:  maps.Clone(s.curriculumRequisites),
+		grades:                maps.Clone(s.grades),
+		academicRecords:       maps.Clone(s.academicRecords),
+		checkInClaims:         maps.Clone(s.checkInClaims),
+		notifiedStatuses:      maps.Clone(s.notifiedStatuses),
+	}
+}
+
+// restore replaces the contents of s with those of snapshot.
+func (s *Store) restore(snapshot *Store) {
+	snapshot.mu.RLock()
+	defer snapshot.mu.RUnlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.profiles = snapshot.profiles
+	s.users = snapshot.users
+	s.buildings = snapshot.buildings
+	s.rooms = snapshot.rooms
+	s.disciplines = snapshot.disciplines
+	s.curriculums = snapshot.curriculums
+	s.classes = snapshot.classes
+	s.lectures = snapshot.lectures
+	s.resourceTypes = snapshot.resourceTypes
+	s.resources = snapshot.resources
+	s.reservations = snapshot.reservations
+	s.enrollments = snapshot.enrollments
+	s.equivalences = snapshot.equivalences
+	s.assessments = snapshot.assessments
+	s.curriculumDisciplines = snapshot.curriculumDisciplines
+	s.reservationResources = snapshot.reservationResources
+	s.lecturePresence = snapshot.lecturePresence
+	s.curriculumPlacements = snapshot.curriculumPlacements
+	s.curriculumRequisites = snapshot.curriculumRequisites
+	s.grades = snapshot.grades
+	s.academicRecords = snapshot.academicRecords
+	s.checkInClaims = snapshot.checkInClaims
+	s.notifiedStatuses = snapshot.notifiedStatuses
+}
+
+func (t table[T]) clone() table[T] {
+	return table[T]{rows: maps.Clone(t.rows), lastID: t.lastID}
+}
+
+func (l links) clone() links {
+	return links{pairs: maps.Clone(l.pairs)}
+}
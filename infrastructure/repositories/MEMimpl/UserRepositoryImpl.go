@@ -0,0 +1,95 @@
+package memImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type userRepositoryImpl struct {
+	store *Store
+}
+
+func NewUserRepository(store *Store) repositories.UserRepository {
+	return &userRepositoryImpl{store}
+}
+
+func (r *userRepositoryImpl) Create(user *domain.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.profiles.has(user.ProfileID) {
+		return ErrForeignKey
+	}
+	user.ID = r.store.users.nextID()
+	r.store.users.put(user.ID, *user)
+	return nil
+}
+
+func (r *userRepositoryImpl) FindAll(page domain.Page) ([]domain.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return paginate(r.store.users.all(), page), nil
+}
+
+func (r *userRepositoryImpl) StreamAll(fn func(domain.User) error) error {
+	users, err := r.FindAll(domain.Page{})
+	if err != nil {
+		return err
+	}
+	return each(users, fn)
+}
+
+func (r *userRepositoryImpl) FindByID(id uint) (*domain.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	u, ok := r.store.users.get(id)
+	if !ok {
+		return nil, errNotFound
+	}
+	return &u, nil
+}
+
+func (r *userRepositoryImpl) FindByIDs(ids []uint) ([]domain.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return r.store.users.getMany(ids), nil
+}
+
+func (r *userRepositoryImpl) Update(id uint, user *domain.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.users.has(id) {
+		return nil
+	}
+	if !r.store.profiles.has(user.ProfileID) {
+		return ErrForeignKey
+	}
+	u := *user
+	u.ID = id
+	r.store.users.put(id, u)
+	return nil
+}
+
+func (r *userRepositoryImpl) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if r.store.hasPresence(func(_, userID uint) bool { return userID == id }) {
+		return ErrForeignKey
+	}
+	for _, e := range r.store.enrollments.rows {
+		if e.UserID == id {
+			return ErrForeignKey
+		}
+	}
+	for key := range r.store.grades {
+		if key[1] == id {
+			return ErrForeignKey
+		}
+	}
+	for key := range r.store.academicRecords {
+		if key[0] == id {
+			return ErrForeignKey
+		}
+	}
+	r.store.users.delete(id)
+	return nil
+}
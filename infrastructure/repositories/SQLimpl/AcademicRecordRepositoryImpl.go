@@ -0,0 +1,56 @@
+package repoImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type academicRecordRepositoryImpl struct {
+	db DBTX
+}
+
+func NewAcademicRecordRepository(db DBTX) repositories.AcademicRecordRepository {
+	return &academicRecordRepositoryImpl{db}
+}
+
+func (r *academicRecordRepositoryImpl) Save(record *domain.AcademicRecord) error {
+	_, err := r.db.Exec(`
+        INSERT INTO academic_records (user_id, class_id, discipline_id, final_grade, attendance, status, completed_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (user_id, class_id) DO UPDATE SET
+            discipline_id = EXCLUDED.discipline_id,
+            final_grade = EXCLUDED.final_grade,
+            attendance = EXCLUDED.attendance,
+            status = EXCLUDED.status,
+            completed_at = EXCLUDED.completed_at
+    `, record.UserID, record.ClassID, record.DisciplineID, record.FinalGrade, record.Attendance, record.Status, record.CompletedAt)
+	return err
+}
+
+func (r *academicRecordRepositoryImpl) FindByUser(userID uint) ([]domain.AcademicRecord, error) {
+	rows, err := r.db.Query(`
+        SELECT r.user_id, r.class_id, r.discipline_id, r.final_grade, r.attendance, r.status, r.completed_at,
+               d.discipline_id, d.name, d.credits, d.program, d.bibliography
+        FROM academic_records r
+        JOIN disciplines d ON d.discipline_id = r.discipline_id
+        WHERE r.user_id = $1
+        ORDER BY r.completed_at, r.class_id
+    `, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []domain.AcademicRecord
+	for rows.Next() {
+		var rec domain.AcademicRecord
+		var d domain.Discipline
+		if err := rows.Scan(&rec.UserID, &rec.ClassID, &rec.DisciplineID, &rec.FinalGrade, &rec.Attendance, &rec.Status, &rec.CompletedAt,
+			&d.ID, &d.Name, &d.Credits, &d.Program, &d.Bibliography); err != nil {
+			return nil, err
+		}
+		rec.Discipline = &d
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
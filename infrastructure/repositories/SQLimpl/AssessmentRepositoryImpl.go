@@ -0,0 +1,100 @@
+package repoImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type assessmentRepositoryImpl struct {
+	db DBTX
+}
+
+func NewAssessmentRepository(db DBTX) repositories.AssessmentRepository {
+	return &assessmentRepositoryImpl{db}
+}
+
+func (r *assessmentRepositoryImpl) Create(assessment *domain.Assessment) error {
+	return r.db.QueryRow(
+		"INSERT INTO assessments (class_id, name, weight) VALUES ($1, $2, $3) RETURNING assessment_id",
+		assessment.ClassID, assessment.Name, assessment.Weight,
+	).Scan(&assessment.ID)
+}
+
+func (r *assessmentRepositoryImpl) Update(assessment *domain.Assessment) error {
+	_, err := r.db.Exec(
+		"UPDATE assessments SET name = $1, weight = $2 WHERE assessment_id = $3",
+		assessment.Name, assessment.Weight, assessment.ID,
+	)
+	return err
+}
+
+func (r *assessmentRepositoryImpl) FindByID(id uint) (*domain.Assessment, error) {
+	var a domain.Assessment
+	err := r.db.QueryRow(
+		"SELECT assessment_id, class_id, name, weight FROM assessments WHERE assessment_id = $1", id,
+	).Scan(&a.ID, &a.ClassID, &a.Name, &a.Weight)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *assessmentRepositoryImpl) FindByClass(classID uint) ([]domain.Assessment, error) {
+	rows, err := r.db.Query(
+		"SELECT assessment_id, class_id, name, weight FROM assessments WHERE class_id = $1 ORDER BY assessment_id", classID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assessments []domain.Assessment
+	for rows.Next() {
+		var a domain.Assessment
+		if err := rows.Scan(&a.ID, &a.ClassID, &a.Name, &a.Weight); err != nil {
+			return nil, err
+		}
+		assessments = append(assessments, a)
+	}
+	return assessments, rows.Err()
+}
+
+func (r *assessmentRepositoryImpl) Delete(id uint) error {
+	if _, err := r.db.Exec("DELETE FROM grades WHERE assessment_id = $1", id); err != nil {
+		return err
+	}
+	_, err := r.db.Exec("DELETE FROM assessments WHERE assessment_id = $1", id)
+	return err
+}
+
+func (r *assessmentRepositoryImpl) SetGrade(grade *domain.Grade) error {
+	_, err := r.db.Exec(`
+        INSERT INTO grades (assessment_id, user_id, value) VALUES ($1, $2, $3)
+        ON CONFLICT (assessment_id, user_id) DO UPDATE SET value = EXCLUDED.value
+    `, grade.AssessmentID, grade.UserID, grade.Value)
+	return err
+}
+
+func (r *assessmentRepositoryImpl) FindGradesByClass(classID uint) ([]domain.Grade, error) {
+	rows, err := r.db.Query(`
+        SELECT g.assessment_id, g.user_id, g.value
+        FROM grades g
+        JOIN assessments a ON a.assessment_id = g.assessment_id
+        WHERE a.class_id = $1
+        ORDER BY g.assessment_id, g.user_id
+    `, classID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var grades []domain.Grade
+	for rows.Next() {
+		var g domain.Grade
+		if err := rows.Scan(&g.AssessmentID, &g.UserID, &g.Value); err != nil {
+			return nil, err
+		}
+		grades = append(grades, g)
+	}
+	return grades, rows.Err()
+}
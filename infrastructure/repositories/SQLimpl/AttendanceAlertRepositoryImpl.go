@@ -0,0 +1,44 @@
+package repoImpl
+
+import (
+	"time"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type attendanceAlertRepositoryImpl struct {
+	db DBTX
+}
+
+func NewAttendanceAlertRepository(db DBTX) repositories.AttendanceAlertRepository {
+	return &attendanceAlertRepositoryImpl{db}
+}
+
+func (r *attendanceAlertRepositoryImpl) FindNotifiedStatuses(classID uint) (map[uint]domain.AttendanceStatus, error) {
+	rows, err := r.db.Query("SELECT user_id, status FROM attendance_notifications WHERE class_id = $1", classID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	statuses := make(map[uint]domain.AttendanceStatus)
+	for rows.Next() {
+		var userID uint
+		var status domain.AttendanceStatus
+		if err := rows.Scan(&userID, &status); err != nil {
+			return nil, err
+		}
+		statuses[userID] = status
+	}
+	return statuses, rows.Err()
+}
+
+func (r *attendanceAlertRepositoryImpl) SaveNotifiedStatus(classID uint, userID uint, status domain.AttendanceStatus, at time.Time) error {
+	_, err := r.db.Exec(`
+        INSERT INTO attendance_notifications (class_id, user_id, status, notified_at)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (class_id, user_id) DO UPDATE SET status = EXCLUDED.status, notified_at = EXCLUDED.notified_at
+    `, classID, userID, status, at)
+	return err
+}
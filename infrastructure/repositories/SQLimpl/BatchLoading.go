@@ -0,0 +1,13 @@
+package repoImpl
+
+import "github.com/lib/pq"
+
+// idArray converts IDs into a Postgres integer array so a whole batch can be
+// matched with "= ANY($1)" in a single query.
+func idArray(ids []uint) pq.Int64Array {
+	out := make(pq.Int64Array, len(ids))
+	for i, id := range ids {
+		out[i] = int64(id)
+	}
+	return out
+}
@@ -1,16 +1,15 @@
 package repoImpl
 
 import (
-	"database/sql"
 	"sarc/core/domain"
 	repositories "sarc/infrastructure/repositories/interfaces"
 )
 
 type buildingRepositoryImpl struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewBuildingRepository(db *sql.DB) repositories.BuildingRepository {
+func NewBuildingRepository(db DBTX) repositories.BuildingRepository {
 	return &buildingRepositoryImpl{db}
 }
 
@@ -21,8 +20,9 @@ func (r *buildingRepositoryImpl) Create(building *domain.Building) error {
 	).Scan(&building.BuildingID)
 }
 
-func (r *buildingRepositoryImpl) FindAll() ([]domain.Building, error) {
-	rows, err := r.db.Query("SELECT building_id, building_name, address FROM buildings")
+func (r *buildingRepositoryImpl) FindAll(page domain.Page) ([]domain.Building, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT building_id, building_name, address FROM buildings ORDER BY building_id LIMIT $1 OFFSET $2", limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -48,6 +48,24 @@ func (r *buildingRepositoryImpl) FindByID(id uint) (*domain.Building, error) {
 	return &b, nil
 }
 
+func (r *buildingRepositoryImpl) FindByIDs(ids []uint) ([]domain.Building, error) {
+	rows, err := r.db.Query("SELECT building_id, building_name, address FROM buildings WHERE building_id = ANY($1) ORDER BY building_id", idArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var buildings []domain.Building
+	for rows.Next() {
+		var b domain.Building
+		if err := rows.Scan(&b.BuildingID, &b.BuildingName, &b.Address); err != nil {
+			return nil, err
+		}
+		buildings = append(buildings, b)
+	}
+	return buildings, rows.Err()
+}
+
 func (r *buildingRepositoryImpl) Update(id uint, building *domain.Building) error {
 	_, err := r.db.Exec(
 		"UPDATE buildings SET building_name = $1, address = $2 WHERE building_id = $3",
@@ -1,16 +1,15 @@
 package repoImpl
 
 import (
-	"database/sql"
 	"sarc/core/domain"
 	repositories "sarc/infrastructure/repositories/interfaces"
 )
 
 type classRepositoryImpl struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewClassRepository(db *sql.DB) repositories.ClassRepository {
+func NewClassRepository(db DBTX) repositories.ClassRepository {
 	return &classRepositoryImpl{db}
 }
 
@@ -21,8 +20,9 @@ func (r *classRepositoryImpl) Create(class *domain.Class) error {
 	).Scan(&class.ClassID)
 }
 
-func (r *classRepositoryImpl) FindAll() ([]domain.Class, error) {
-	rows, err := r.db.Query("SELECT class_id, name, description, discipline_id FROM classes")
+func (r *classRepositoryImpl) FindAll(page domain.Page) ([]domain.Class, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT class_id, name, description, discipline_id FROM classes ORDER BY class_id LIMIT $1 OFFSET $2", limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -48,6 +48,46 @@ func (r *classRepositoryImpl) FindByID(id uint) (*domain.Class, error) {
 	return &c, nil
 }
 
+func (r *classRepositoryImpl) FindByIDs(ids []uint) ([]domain.Class, error) {
+	rows, err := r.db.Query("SELECT class_id, name, description, discipline_id FROM classes WHERE class_id = ANY($1) ORDER BY class_id", idArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var classes []domain.Class
+	for rows.Next() {
+		var c domain.Class
+		if err := rows.Scan(&c.ClassID, &c.Name, &c.Description, &c.DisciplineID); err != nil {
+			return nil, err
+		}
+		classes = append(classes, c)
+	}
+	return classes, rows.Err()
+}
+
+func (r *classRepositoryImpl) FindByDiscipline(disciplineID uint, page domain.Page) ([]domain.Class, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query(
+		"SELECT class_id, name, description, discipline_id FROM classes WHERE discipline_id = $1 ORDER BY class_id LIMIT $2 OFFSET $3",
+		disciplineID, limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var classes []domain.Class
+	for rows.Next() {
+		var c domain.Class
+		if err := rows.Scan(&c.ClassID, &c.Name, &c.Description, &c.DisciplineID); err != nil {
+			return nil, err
+		}
+		classes = append(classes, c)
+	}
+	return classes, rows.Err()
+}
+
 func (r *classRepositoryImpl) Update(id uint, class *domain.Class) error {
 	_, err := r.db.Exec(
 		"UPDATE classes SET name = $1, description = $2, discipline_id = $3 WHERE class_id = $4",
@@ -2,96 +2,125 @@ package repoImpl
 
 import (
 	"database/sql"
+
 	"sarc/core/domain"
 	repositories "sarc/infrastructure/repositories/interfaces"
 )
 
 type curriculumRepositoryImpl struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewCurriculumRepository(db *sql.DB) repositories.CurriculumRepository {
+// curriculumColumns reads lineage_id as the curriculum's own ID for first
+// versions, where it is NULL.
+const curriculumColumns = "curriculum_id, course_name, data_inicio, data_fim, COALESCE(lineage_id, curriculum_id), version"
+
+func NewCurriculumRepository(db DBTX) repositories.CurriculumRepository {
 	return &curriculumRepositoryImpl{db}
 }
 
 func (r *curriculumRepositoryImpl) Create(curriculum *domain.Curriculum) error {
-	return r.db.QueryRow(
-		"INSERT INTO curriculums (course_name, data_inicio, data_fim) VALUES ($1, $2, $3) RETURNING curriculum_id",
-		curriculum.CourseName, curriculum.DataInicio, curriculum.DataFim,
+	err := r.db.QueryRow(
+		"INSERT INTO curriculums (course_name, data_inicio, data_fim, lineage_id, version) VALUES ($1, $2, $3, NULLIF($4, 0), $5) RETURNING curriculum_id",
+		curriculum.CourseName, curriculum.DataInicio, curriculum.DataFim, curriculum.LineageID, curriculum.Version,
 	).Scan(&curriculum.ID)
+	if err == nil && curriculum.LineageID == 0 {
+		curriculum.LineageID = curriculum.ID
+	}
+	return err
 }
 
 func (r *curriculumRepositoryImpl) FindByID(id uint) (*domain.Curriculum, error) {
-	row := r.db.QueryRow("SELECT curriculum_id, course_name, data_inicio, data_fim FROM curriculums WHERE curriculum_id = $1", id)
+	row := r.db.QueryRow("SELECT "+curriculumColumns+" FROM curriculums WHERE curriculum_id = $1", id)
 	var c domain.Curriculum
-	if err := row.Scan(&c.ID, &c.CourseName, &c.DataInicio, &c.DataFim); err != nil {
+	if err := row.Scan(&c.ID, &c.CourseName, &c.DataInicio, &c.DataFim, &c.LineageID, &c.Version); err != nil {
 		return nil, err
 	}
 
-	// Fetch disciplines for this curriculum
-	discRows, err := r.db.Query(`
-        SELECT d.discipline_id, d.name, d.credits, d.program, d.bibliography
-        FROM disciplines d
-        JOIN curriculum_disciplines cd ON cd.discipline_id = d.discipline_id
-        WHERE cd.curriculum_id = $1
-    `, id)
+	disciplines, err := r.disciplinesByCurriculum([]uint{id})
 	if err != nil {
 		return nil, err
 	}
-	defer discRows.Close()
-
-	var disciplines []domain.Discipline
-	for discRows.Next() {
-		var d domain.Discipline
-		if err := discRows.Scan(&d.ID, &d.Name, &d.Credits, &d.Program, &d.Bibliography); err != nil {
-			return nil, err
-		}
-		disciplines = append(disciplines, d)
-	}
-	c.Disciplines = disciplines
+	c.Disciplines = disciplines[id]
 
 	return &c, nil
 }
 
-func (r *curriculumRepositoryImpl) FindAll() ([]domain.Curriculum, error) {
-	rows, err := r.db.Query("SELECT curriculum_id, course_name, data_inicio, data_fim FROM curriculums")
+func (r *curriculumRepositoryImpl) FindAll(page domain.Page) ([]domain.Curriculum, error) {
+	limit, offset := pageArgs(page)
+	return r.find("ORDER BY curriculum_id LIMIT $1 OFFSET $2", limit, offset)
+}
+
+func (r *curriculumRepositoryImpl) FindByLineage(lineageID uint) ([]domain.Curriculum, error) {
+	return r.find("WHERE COALESCE(lineage_id, curriculum_id) = $1 ORDER BY version, curriculum_id", lineageID)
+}
+
+func (r *curriculumRepositoryImpl) LockLineage(id uint) error {
+	_, err := r.db.Exec("SELECT curriculum_id FROM curriculums WHERE curriculum_id = (SELECT COALESCE(lineage_id, curriculum_id) FROM curriculums WHERE curriculum_id = $1) FOR UPDATE", id)
+	return err
+}
+
+// find loads the curriculums selected by clause, with their disciplines.
+func (r *curriculumRepositoryImpl) find(clause string, args ...any) ([]domain.Curriculum, error) {
+	rows, err := r.db.Query("SELECT "+curriculumColumns+" FROM curriculums "+clause, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	var curriculums []domain.Curriculum
+	var ids []uint
 	for rows.Next() {
 		var c domain.Curriculum
-		if err := rows.Scan(&c.ID, &c.CourseName, &c.DataInicio, &c.DataFim); err != nil {
+		if err := rows.Scan(&c.ID, &c.CourseName, &c.DataInicio, &c.DataFim, &c.LineageID, &c.Version); err != nil {
 			return nil, err
 		}
+		curriculums = append(curriculums, c)
+		ids = append(ids, c.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Fetch the disciplines of every curriculum in a single query
+	disciplines, err := r.disciplinesByCurriculum(ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range curriculums {
+		curriculums[i].Disciplines = disciplines[curriculums[i].ID]
+	}
+	return curriculums, nil
+}
+
+// disciplinesByCurriculum loads the disciplines of all given curriculums in
+// one round trip, keyed by curriculum ID.
+func (r *curriculumRepositoryImpl) disciplinesByCurriculum(ids []uint) (map[uint][]domain.Discipline, error) {
+	result := make(map[uint][]domain.Discipline, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+	rows, err := r.db.Query(`
+        SELECT cd.curriculum_id, d.discipline_id, d.name, d.credits, d.program, d.bibliography, cd.semester, cd.elective
+        FROM disciplines d
+        JOIN curriculum_disciplines cd ON cd.discipline_id = d.discipline_id
+        WHERE cd.curriculum_id = ANY($1)
+        ORDER BY cd.curriculum_id, cd.semester, d.discipline_id
+    `, idArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-		// Fetch disciplines for each curriculum
-		discRows, err := r.db.Query(`
-            SELECT d.discipline_id, d.name, d.credits, d.program, d.bibliography
-            FROM disciplines d
-            JOIN curriculum_disciplines cd ON cd.discipline_id = d.discipline_id
-            WHERE cd.curriculum_id = $1
-        `, c.ID)
-		if err != nil {
+	for rows.Next() {
+		var curriculumID uint
+		var d domain.Discipline
+		if err := rows.Scan(&curriculumID, &d.ID, &d.Name, &d.Credits, &d.Program, &d.Bibliography, &d.Semester, &d.Elective); err != nil {
 			return nil, err
 		}
-		var disciplines []domain.Discipline
-		for discRows.Next() {
-			var d domain.Discipline
-			if err := discRows.Scan(&d.ID, &d.Name, &d.Credits, &d.Program, &d.Bibliography); err != nil {
-				discRows.Close()
-				return nil, err
-			}
-			disciplines = append(disciplines, d)
-		}
-		discRows.Close()
-		c.Disciplines = disciplines
-
-		curriculums = append(curriculums, c)
+		result[curriculumID] = append(result[curriculumID], d)
 	}
-	return curriculums, nil
+	return result, rows.Err()
 }
 
 func (r *curriculumRepositoryImpl) Update(id uint, curriculum *domain.Curriculum) error {
@@ -107,10 +136,84 @@ func (r *curriculumRepositoryImpl) Delete(id uint) error {
 	return err
 }
 
-func (r *curriculumRepositoryImpl) AddDisciplineToCurriculum(curriculumID uint, disciplineID uint) error {
+func (r *curriculumRepositoryImpl) AddDisciplineToCurriculum(curriculumID uint, discipline domain.Discipline) error {
+	_, err := r.db.Exec(
+		`
+        INSERT INTO curriculum_disciplines (curriculum_id, discipline_id, semester, elective) VALUES ($1, $2, $3, $4)
+        ON CONFLICT (curriculum_id, discipline_id) DO NOTHING
+    `,
+		curriculumID, discipline.ID, discipline.Semester, discipline.Elective,
+	)
+	return err
+}
+
+func (r *curriculumRepositoryImpl) RemoveDisciplineFromCurriculum(curriculumID uint, disciplineID uint) error {
+	if _, err := r.db.Exec(
+		"DELETE FROM curriculum_requisites WHERE curriculum_id = $1 AND (discipline_id = $2 OR requisite_id = $2)",
+		curriculumID, disciplineID,
+	); err != nil {
+		return err
+	}
 	_, err := r.db.Exec(
-		"INSERT INTO curriculum_disciplines (curriculum_id, discipline_id) VALUES ($1, $2)",
+		"DELETE FROM curriculum_disciplines WHERE curriculum_id = $1 AND discipline_id = $2",
 		curriculumID, disciplineID,
 	)
 	return err
 }
+
+func (r *curriculumRepositoryImpl) PlaceDiscipline(curriculumID uint, discipline domain.Discipline) error {
+	res, err := r.db.Exec(
+		"UPDATE curriculum_disciplines SET semester = $1, elective = $2 WHERE curriculum_id = $3 AND discipline_id = $4",
+		discipline.Semester, discipline.Elective, curriculumID, discipline.ID,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *curriculumRepositoryImpl) FindRequisites(curriculumID uint) ([]domain.Requisite, error) {
+	rows, err := r.db.Query(`
+        SELECT curriculum_id, discipline_id, requisite_id, kind
+        FROM curriculum_requisites
+        WHERE curriculum_id = $1
+        ORDER BY discipline_id, requisite_id
+    `, curriculumID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requisites []domain.Requisite
+	for rows.Next() {
+		var q domain.Requisite
+		if err := rows.Scan(&q.CurriculumID, &q.DisciplineID, &q.RequisiteID, &q.Kind); err != nil {
+			return nil, err
+		}
+		requisites = append(requisites, q)
+	}
+	return requisites, rows.Err()
+}
+
+func (r *curriculumRepositoryImpl) AddRequisite(requisite *domain.Requisite) error {
+	_, err := r.db.Exec(
+		"INSERT INTO curriculum_requisites (curriculum_id, discipline_id, requisite_id, kind) VALUES ($1, $2, $3, $4)",
+		requisite.CurriculumID, requisite.DisciplineID, requisite.RequisiteID, requisite.Kind,
+	)
+	return err
+}
+
+func (r *curriculumRepositoryImpl) RemoveRequisite(curriculumID uint, disciplineID uint, requisiteID uint) error {
+	_, err := r.db.Exec(
+		"DELETE FROM curriculum_requisites WHERE curriculum_id = $1 AND discipline_id = $2 AND requisite_id = $3",
+		curriculumID, disciplineID, requisiteID,
+	)
+	return err
+}
@@ -1,16 +1,15 @@
 package repoImpl
 
 import (
-	"database/sql"
 	"sarc/core/domain"
 	repositories "sarc/infrastructure/repositories/interfaces"
 )
 
 type disciplineRepositoryImpl struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewDisciplineRepository(db *sql.DB) repositories.DisciplineRepository {
+func NewDisciplineRepository(db DBTX) repositories.DisciplineRepository {
 	return &disciplineRepositoryImpl{db}
 }
 
@@ -21,8 +20,9 @@ func (r *disciplineRepositoryImpl) Create(discipline *domain.Discipline) error {
 	).Scan(&discipline.ID)
 }
 
-func (r *disciplineRepositoryImpl) FindAll() ([]domain.Discipline, error) {
-	rows, err := r.db.Query("SELECT discipline_id, name, credits, program, bibliography FROM disciplines")
+func (r *disciplineRepositoryImpl) FindAll(page domain.Page) ([]domain.Discipline, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT discipline_id, name, credits, program, bibliography FROM disciplines ORDER BY discipline_id LIMIT $1 OFFSET $2", limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -48,6 +48,24 @@ func (r *disciplineRepositoryImpl) FindByID(id uint) (*domain.Discipline, error)
 	return &d, nil
 }
 
+func (r *disciplineRepositoryImpl) FindByIDs(ids []uint) ([]domain.Discipline, error) {
+	rows, err := r.db.Query("SELECT discipline_id, name, credits, program, bibliography FROM disciplines WHERE discipline_id = ANY($1) ORDER BY discipline_id", idArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var disciplines []domain.Discipline
+	for rows.Next() {
+		var d domain.Discipline
+		if err := rows.Scan(&d.ID, &d.Name, &d.Credits, &d.Program, &d.Bibliography); err != nil {
+			return nil, err
+		}
+		disciplines = append(disciplines, d)
+	}
+	return disciplines, rows.Err()
+}
+
 func (r *disciplineRepositoryImpl) Update(id uint, discipline *domain.Discipline) error {
 	_, err := r.db.Exec(
 		"UPDATE disciplines SET name = $1, credits = $2, program = $3, bibliography = $4 WHERE discipline_id = $5",
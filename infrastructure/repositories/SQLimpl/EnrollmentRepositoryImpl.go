@@ -0,0 +1,121 @@
+package repoImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type enrollmentRepositoryImpl struct {
+	db DBTX
+}
+
+func NewEnrollmentRepository(db DBTX) repositories.EnrollmentRepository {
+	return &enrollmentRepositoryImpl{db}
+}
+
+func (r *enrollmentRepositoryImpl) Create(enrollment *domain.Enrollment) error {
+	return r.db.QueryRow(
+		"INSERT INTO enrollments (class_id, user_id, role, status, enrolled_at, dropped_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING enrollment_id",
+		enrollment.ClassID, enrollment.UserID, enrollment.Role, enrollment.Status, enrollment.EnrolledAt, enrollment.DroppedAt,
+	).Scan(&enrollment.EnrollmentID)
+}
+
+func (r *enrollmentRepositoryImpl) Update(enrollment *domain.Enrollment) error {
+	_, err := r.db.Exec(
+		"UPDATE enrollments SET role = $1, status = $2, enrolled_at = $3, dropped_at = $4 WHERE enrollment_id = $5",
+		enrollment.Role, enrollment.Status, enrollment.EnrolledAt, enrollment.DroppedAt, enrollment.EnrollmentID,
+	)
+	return err
+}
+
+func (r *enrollmentRepositoryImpl) Find(classID uint, userID uint) (*domain.Enrollment, error) {
+	row := r.db.QueryRow(
+		"SELECT enrollment_id, class_id, user_id, role, status, enrolled_at, dropped_at FROM enrollments WHERE class_id = $1 AND user_id = $2",
+		classID, userID,
+	)
+	var e domain.Enrollment
+	if err := row.Scan(&e.EnrollmentID, &e.ClassID, &e.UserID, &e.Role, &e.Status, &e.EnrolledAt, &e.DroppedAt); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *enrollmentRepositoryImpl) FindByClass(classID uint) ([]domain.Enrollment, error) {
+	return r.findRoster("e.class_id = $1", classID)
+}
+
+func (r *enrollmentRepositoryImpl) FindByClasses(classIDs []uint) ([]domain.Enrollment, error) {
+	return r.findRoster("e.class_id = ANY($1)", idArray(classIDs))
+}
+
+// findRoster lists the enrollments matching where, each with its user.
+func (r *enrollmentRepositoryImpl) findRoster(where string, args ...any) ([]domain.Enrollment, error) {
+	rows, err := r.db.Query(`
+        SELECT e.enrollment_id, e.class_id, e.user_id, e.role, e.status, e.enrolled_at, e.dropped_at,
+               u.user_id, u.email, u.nome, u.birth_date, u.sex, u.telephone, u.profile_id
+        FROM enrollments e
+        JOIN users u ON u.user_id = e.user_id
+        WHERE `+where+`
+        ORDER BY e.class_id, e.user_id
+    `, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var enrollments []domain.Enrollment
+	for rows.Next() {
+		var e domain.Enrollment
+		var u domain.User
+		if err := rows.Scan(&e.EnrollmentID, &e.ClassID, &e.UserID, &e.Role, &e.Status, &e.EnrolledAt, &e.DroppedAt,
+			&u.ID, &u.Email, &u.Nome, &u.BirthDate, &u.Sex, &u.Telephone, &u.ProfileID); err != nil {
+			return nil, err
+		}
+		e.User = &u
+		enrollments = append(enrollments, e)
+	}
+	return enrollments, rows.Err()
+}
+
+func (r *enrollmentRepositoryImpl) CountStudents(classIDs []uint) (map[uint]int, error) {
+	rows, err := r.db.Query(
+		"SELECT class_id, COUNT(*) FROM enrollments WHERE class_id = ANY($1) AND role = $2 AND status = $3 GROUP BY class_id",
+		idArray(classIDs), domain.EnrollmentRoleStudent, domain.EnrollmentActive,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[uint]int)
+	for rows.Next() {
+		var classID uint
+		var n int
+		if err := rows.Scan(&classID, &n); err != nil {
+			return nil, err
+		}
+		counts[classID] = n
+	}
+	return counts, rows.Err()
+}
+
+func (r *enrollmentRepositoryImpl) FindByUser(userID uint) ([]domain.Enrollment, error) {
+	rows, err := r.db.Query(
+		"SELECT enrollment_id, class_id, user_id, role, status, enrolled_at, dropped_at FROM enrollments WHERE user_id = $1 ORDER BY class_id",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var enrollments []domain.Enrollment
+	for rows.Next() {
+		var e domain.Enrollment
+		if err := rows.Scan(&e.EnrollmentID, &e.ClassID, &e.UserID, &e.Role, &e.Status, &e.EnrolledAt, &e.DroppedAt); err != nil {
+			return nil, err
+		}
+		enrollments = append(enrollments, e)
+	}
+	return enrollments, rows.Err()
+}
@@ -0,0 +1,105 @@
+package repoImpl
+
+import (
+	"database/sql"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type equivalenceRepositoryImpl struct {
+	db DBTX
+}
+
+func NewEquivalenceRepository(db DBTX) repositories.EquivalenceRepository {
+	return &equivalenceRepositoryImpl{db}
+}
+
+func (r *equivalenceRepositoryImpl) Create(equivalence *domain.Equivalence) error {
+	if err := r.db.QueryRow(
+		"INSERT INTO discipline_equivalences (target_id) VALUES ($1) RETURNING equivalence_id",
+		equivalence.TargetID,
+	).Scan(&equivalence.ID); err != nil {
+		return err
+	}
+	for _, sourceID := range equivalence.SourceIDs {
+		if _, err := r.db.Exec(
+			"INSERT INTO discipline_equivalence_sources (equivalence_id, discipline_id) VALUES ($1, $2)",
+			equivalence.ID, sourceID,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *equivalenceRepositoryImpl) FindByID(id uint) (*domain.Equivalence, error) {
+	equivalences, err := r.find("e.equivalence_id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(equivalences) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return &equivalences[0], nil
+}
+
+func (r *equivalenceRepositoryImpl) FindAll(page domain.Page) ([]domain.Equivalence, error) {
+	// Page over equivalences, not over the joined source rows
+	limit, offset := pageArgs(page)
+	return r.find(`e.equivalence_id IN (
+            SELECT equivalence_id FROM discipline_equivalences ORDER BY equivalence_id LIMIT $1 OFFSET $2
+        )`, limit, offset)
+}
+
+func (r *equivalenceRepositoryImpl) FindByDiscipline(disciplineID uint, page domain.Page) ([]domain.Equivalence, error) {
+	limit, offset := pageArgs(page)
+	return r.find(`e.equivalence_id IN (
+            SELECT equivalence_id FROM discipline_equivalences
+            WHERE target_id = $1 OR equivalence_id IN (
+                SELECT equivalence_id FROM discipline_equivalence_sources WHERE discipline_id = $1
+            )
+            ORDER BY equivalence_id LIMIT $2 OFFSET $3
+        )`, disciplineID, limit, offset)
+}
+
+func (r *equivalenceRepositoryImpl) FindByTargets(targetIDs []uint) ([]domain.Equivalence, error) {
+	return r.find("e.target_id = ANY($1)", idArray(targetIDs))
+}
+
+func (r *equivalenceRepositoryImpl) Delete(id uint) error {
+	if _, err := r.db.Exec("DELETE FROM discipline_equivalence_sources WHERE equivalence_id = $1", id); err != nil {
+		return err
+	}
+	_, err := r.db.Exec("DELETE FROM discipline_equivalences WHERE equivalence_id = $1", id)
+	return err
+}
+
+// find loads the equivalences matching where, one row per source.
+func (r *equivalenceRepositoryImpl) find(where string, args ...any) ([]domain.Equivalence, error) {
+	rows, err := r.db.Query(`
+        SELECT e.equivalence_id, e.target_id, s.discipline_id
+        FROM discipline_equivalences e
+        JOIN discipline_equivalence_sources s ON s.equivalence_id = e.equivalence_id
+        WHERE `+where+`
+        ORDER BY e.equivalence_id, s.discipline_id
+    `, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var equivalences []domain.Equivalence
+	for rows.Next() {
+		var id, targetID, sourceID uint
+		if err := rows.Scan(&id, &targetID, &sourceID); err != nil {
+			return nil, err
+		}
+		if n := len(equivalences); n == 0 || equivalences[n-1].ID != id {
+			equivalences = append(equivalences, domain.Equivalence{ID: id, TargetID: targetID})
+		}
+		last := &equivalences[len(equivalences)-1]
+		last.SourceIDs = append(last.SourceIDs, sourceID)
+	}
+	return equivalences, rows.Err()
+}
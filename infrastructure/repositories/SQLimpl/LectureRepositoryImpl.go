@@ -1,57 +1,137 @@
 package repoImpl
 
 import (
-	"database/sql"
+	"cmp"
+
 	"sarc/core/domain"
 	repositories "sarc/infrastructure/repositories/interfaces"
 )
 
 type lectureRepositoryImpl struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewLectureRepository(db *sql.DB) repositories.LectureRepository {
+func NewLectureRepository(db DBTX) repositories.LectureRepository {
 	return &lectureRepositoryImpl{db}
 }
 
 func (r *lectureRepositoryImpl) Create(lecture *domain.Lecture) error {
 	return r.db.QueryRow(
-		"INSERT INTO lectures (class_id, room_id, date, content) VALUES ($1, $2, $3, $4) RETURNING lecture_id",
-		lecture.ClassID, lecture.RoomID, lecture.Date, lecture.Content,
+		"INSERT INTO lectures (class_id, room_id, date, start_time, end_time, content) VALUES ($1, $2, $3, $4, $5, $6) RETURNING lecture_id",
+		lecture.ClassID, lecture.RoomID, lecture.Date, lecture.StartTime, lecture.EndTime, lecture.Content,
 	).Scan(&lecture.LectureID)
 }
 
-func (r *lectureRepositoryImpl) FindAll() ([]domain.Lecture, error) {
-	rows, err := r.db.Query("SELECT lecture_id, class_id, room_id, date, content FROM lectures")
+func (r *lectureRepositoryImpl) FindAll(page domain.Page) ([]domain.Lecture, error) {
+	var lectures []domain.Lecture
+	err := r.stream(page, func(l domain.Lecture) error {
+		lectures = append(lectures, l)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return lectures, nil
+}
+
+func (r *lectureRepositoryImpl) StreamAll(fn func(domain.Lecture) error) error {
+	return r.stream(domain.Page{}, fn)
+}
+
+// stream calls fn for each row of page, in ID order.
+func (r *lectureRepositoryImpl) stream(page domain.Page, fn func(domain.Lecture) error) error {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT lecture_id, class_id, room_id, date, start_time, end_time, content FROM lectures ORDER BY lecture_id LIMIT $1 OFFSET $2", limit, offset)
+	if err != nil {
+		return err
+	}
 	defer rows.Close()
 
-	var lectures []domain.Lecture
 	for rows.Next() {
 		var l domain.Lecture
-		if err := rows.Scan(&l.LectureID, &l.ClassID, &l.RoomID, &l.Date, &l.Content); err != nil {
-			return nil, err
+		if err := rows.Scan(&l.LectureID, &l.ClassID, &l.RoomID, &l.Date, &l.StartTime, &l.EndTime, &l.Content); err != nil {
+			return err
+		}
+		if err := fn(l); err != nil {
+			return err
 		}
-		lectures = append(lectures, l)
 	}
-	return lectures, nil
+	return rows.Err()
 }
 
 func (r *lectureRepositoryImpl) FindByID(id uint) (*domain.Lecture, error) {
-	row := r.db.QueryRow("SELECT lecture_id, class_id, room_id, date, content FROM lectures WHERE lecture_id = $1", id)
+	row := r.db.QueryRow("SELECT lecture_id, class_id, room_id, date, start_time, end_time, content FROM lectures WHERE lecture_id = $1", id)
 	var l domain.Lecture
-	if err := row.Scan(&l.LectureID, &l.ClassID, &l.RoomID, &l.Date, &l.Content); err != nil {
+	if err := row.Scan(&l.LectureID, &l.ClassID, &l.RoomID, &l.Date, &l.StartTime, &l.EndTime, &l.Content); err != nil {
 		return nil, err
 	}
 	return &l, nil
 }
 
+func (r *lectureRepositoryImpl) FindByIDs(ids []uint) ([]domain.Lecture, error) {
+	rows, err := r.db.Query("SELECT lecture_id, class_id, room_id, date, start_time, end_time, content FROM lectures WHERE lecture_id = ANY($1) ORDER BY lecture_id", idArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lectures []domain.Lecture
+	for rows.Next() {
+		var l domain.Lecture
+		if err := rows.Scan(&l.LectureID, &l.ClassID, &l.RoomID, &l.Date, &l.StartTime, &l.EndTime, &l.Content); err != nil {
+			return nil, err
+		}
+		lectures = append(lectures, l)
+	}
+	return lectures, rows.Err()
+}
+
+func (r *lectureRepositoryImpl) FindByClass(classID uint, page domain.Page) ([]domain.Lecture, error) {
+	return r.findBy("class_id", classID, page)
+}
+
+func (r *lectureRepositoryImpl) FindByRoom(roomID uint, page domain.Page) ([]domain.Lecture, error) {
+	return r.findBy("room_id", roomID, page)
+}
+
+func (r *lectureRepositoryImpl) FindBetween(from, to string) ([]domain.Lecture, error) {
+	return r.find("WHERE date BETWEEN $1 AND $2 ORDER BY date, lecture_id", from, to)
+}
+
+func (r *lectureRepositoryImpl) FindByClasses(classIDs []uint, from, to string) ([]domain.Lecture, error) {
+	from, to = dateBounds(from, to)
+	return r.find("WHERE class_id = ANY($1) AND date BETWEEN $2 AND $3 ORDER BY date, lecture_id", idArray(classIDs), from, to)
+}
+
+// findBy lists the lectures whose column equals id; column is never user input.
+func (r *lectureRepositoryImpl) findBy(column string, id uint, page domain.Page) ([]domain.Lecture, error) {
+	limit, offset := pageArgs(page)
+	return r.find("WHERE "+column+" = $1 ORDER BY date, lecture_id LIMIT $2 OFFSET $3", id, limit, offset)
+}
+
+// find lists the lectures selected by clause.
+func (r *lectureRepositoryImpl) find(clause string, args ...any) ([]domain.Lecture, error) {
+	rows, err := r.db.Query("SELECT lecture_id, class_id, room_id, date, start_time, end_time, content FROM lectures "+clause, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lectures []domain.Lecture
+	for rows.Next() {
+		var l domain.Lecture
+		if err := rows.Scan(&l.LectureID, &l.ClassID, &l.RoomID, &l.Date, &l.StartTime, &l.EndTime, &l.Content); err != nil {
+			return nil, err
+		}
+		lectures = append(lectures, l)
+	}
+	return lectures, rows.Err()
+}
+
 func (r *lectureRepositoryImpl) Update(id uint, lecture *domain.Lecture) error {
 	_, err := r.db.Exec(
-		"UPDATE lectures SET class_id = $1, room_id = $2, date = $3, content = $4 WHERE lecture_id = $5",
-		lecture.ClassID, lecture.RoomID, lecture.Date, lecture.Content, id,
+		"UPDATE lectures SET class_id = $1, room_id = $2, date = $3, start_time = $4, end_time = $5, content = $6 WHERE lecture_id = $7",
+		lecture.ClassID, lecture.RoomID, lecture.Date, lecture.StartTime, lecture.EndTime, lecture.Content, id,
 	)
 	return err
 }
@@ -60,3 +140,9 @@ func (r *lectureRepositoryImpl) Delete(id uint) error {
 	_, err := r.db.Exec("DELETE FROM lectures WHERE lecture_id = $1", id)
 	return err
 }
+
+// dateBounds fills in the empty ends of a date range with dates no lecture
+// falls outside of.
+func dateBounds(from, to string) (string, string) {
+	return cmp.Or(from, "0001-01-01"), cmp.Or(to, "9999-12-31")
+}
@@ -0,0 +1,192 @@
+package repoImpl_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"sarc/core/domain"
+	repoimpl "sarc/infrastructure/repositories/SQLimpl"
+)
+
+const (
+	benchReservations = 2000
+	benchCurriculums  = 200
+)
+
+// openSeededDB seeds the test database with enough rows for the per-row and
+// batched listings to differ measurably. Run with
+//
+//	go test ./infrastructure/repositories/SQLimpl -run '^$' -bench Listing
+func openSeededDB(b *testing.B) *sql.DB {
+	b.Helper()
+	conn := openTestDB(b)
+	truncateAll(b, conn)
+
+	_, err := conn.Exec(`
+        INSERT INTO buildings (building_name, address) VALUES ('Bench', 'Bench St');
+        INSERT INTO rooms (room_number, building_id, room_capacity, floor) VALUES ('1', 1, 30, 1);
+        INSERT INTO disciplines (name, credits, program, bibliography)
+            SELECT 'Discipline ' || g, 4, 'Program', ARRAY['Book A', 'Book B'] FROM generate_series(1, 50) g;
+        INSERT INTO classes (name, description, discipline_id) VALUES ('Bench', 'Bench', 1);
+        INSERT INTO lectures (class_id, room_id, date, content) VALUES (1, 1, '2025-01-01', ARRAY['Intro']);
+        INSERT INTO resource_types (name) VALUES ('Projector');
+        INSERT INTO resources (description, status, characteristics, resource_type_id)
+            SELECT 'Resource ' || g, 'available', ARRAY['HDMI'], 1 FROM generate_series(1, 20) g;
+    `)
+	if err != nil {
+		b.Fatal(err)
+	}
+	_, err = conn.Exec(`
+        INSERT INTO reservations (lecture_id, observation) SELECT 1, 'bench' FROM generate_series(1, $1);
+    `, benchReservations)
+	if err != nil {
+		b.Fatal(err)
+	}
+	_, err = conn.Exec(`
+        INSERT INTO reservation_resources (reservation_id, resource_id)
+            SELECT r, (r % 20) + 1 FROM generate_series(1, $1) r
+            UNION ALL
+            SELECT r, ((r + 7) % 20) + 1 FROM generate_series(1, $1) r;
+    `, benchReservations)
+	if err != nil {
+		b.Fatal(err)
+	}
+	_, err = conn.Exec(`
+        INSERT INTO curriculums (course_name, data_inicio, data_fim)
+            SELECT 'Course ' || g, '2025-01-01', '2029-01-01' FROM generate_series(1, $1) g;
+    `, benchCurriculums)
+	if err != nil {
+		b.Fatal(err)
+	}
+	_, err = conn.Exec(`
+        INSERT INTO curriculum_disciplines (curriculum_id, discipline_id)
+            SELECT c, d FROM generate_series(1, $1) c, generate_series(1, 10) d;
+    `, benchCurriculums)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return conn
+}
+
+func BenchmarkListingReservations(b *testing.B) {
+	conn := openSeededDB(b)
+	repo := repoimpl.NewReservationRepository(conn)
+
+	b.Run("batched", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			list, err := repo.FindAll(domain.Page{})
+			if err != nil {
+				b.Fatal(err)
+			}
+			if len(list) != benchReservations {
+				b.Fatalf("got %d reservations", len(list))
+			}
+		}
+	})
+	b.Run("per-row", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := reservationsPerRow(conn); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkListingCurriculums(b *testing.B) {
+	conn := openSeededDB(b)
+	repo := repoimpl.NewCurriculumRepository(conn)
+
+	b.Run("batched", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			list, err := repo.FindAll(domain.Page{})
+			if err != nil {
+				b.Fatal(err)
+			}
+			if len(list) != benchCurriculums {
+				b.Fatalf("got %d curriculums", len(list))
+			}
+		}
+	})
+	b.Run("per-row", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := curriculumsPerRow(conn); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// reservationsPerRow is the previous one-query-per-reservation loading, kept
+// as the baseline the batched version is measured against.
+func reservationsPerRow(conn *sql.DB) ([]domain.Reservation, error) {
+	rows, err := conn.Query("SELECT reservation_id, lecture_id, observation FROM reservations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reservations []domain.Reservation
+	for rows.Next() {
+		var rsv domain.Reservation
+		if err := rows.Scan(&rsv.ReservationID, &rsv.LectureID, &rsv.Observation); err != nil {
+			return nil, err
+		}
+		resRows, err := conn.Query(`
+            SELECT res.resource_id, res.description, res.status, res.characteristics, res.resource_type_id
+            FROM resources res
+            JOIN reservation_resources rr ON rr.resource_id = res.resource_id
+            WHERE rr.reservation_id = $1
+        `, rsv.ReservationID)
+		if err != nil {
+			return nil, err
+		}
+		for resRows.Next() {
+			var res domain.Resource
+			if err := resRows.Scan(&res.ResourceID, &res.Description, &res.Status, &res.Characteristics, &res.ResourceTypeID); err != nil {
+				resRows.Close()
+				return nil, err
+			}
+			rsv.Resources = append(rsv.Resources, res)
+		}
+		resRows.Close()
+		reservations = append(reservations, rsv)
+	}
+	return reservations, nil
+}
+
+// curriculumsPerRow is the previous one-query-per-curriculum loading.
+func curriculumsPerRow(conn *sql.DB) ([]domain.Curriculum, error) {
+	rows, err := conn.Query("SELECT curriculum_id, course_name, data_inicio, data_fim FROM curriculums")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var curriculums []domain.Curriculum
+	for rows.Next() {
+		var c domain.Curriculum
+		if err := rows.Scan(&c.ID, &c.CourseName, &c.DataInicio, &c.DataFim); err != nil {
+			return nil, err
+		}
+		discRows, err := conn.Query(`
+            SELECT d.discipline_id, d.name, d.credits, d.program, d.bibliography
+            FROM disciplines d
+            JOIN curriculum_disciplines cd ON cd.discipline_id = d.discipline_id
+            WHERE cd.curriculum_id = $1
+        `, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		for discRows.Next() {
+			var d domain.Discipline
+			if err := discRows.Scan(&d.ID, &d.Name, &d.Credits, &d.Program, &d.Bibliography); err != nil {
+				discRows.Close()
+				return nil, err
+			}
+			c.Disciplines = append(c.Disciplines, d)
+		}
+		discRows.Close()
+		curriculums = append(curriculums, c)
+	}
+	return curriculums, nil
+}
@@ -0,0 +1,12 @@
+package repoImpl
+
+import "sarc/core/domain"
+
+// pageArgs returns the LIMIT and OFFSET arguments for page. Postgres reads
+// LIMIT NULL as no limit.
+func pageArgs(page domain.Page) (any, int) {
+	if page.Limit <= 0 {
+		return nil, page.Offset
+	}
+	return page.Limit, page.Offset
+}
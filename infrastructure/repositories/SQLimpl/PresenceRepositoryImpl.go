@@ -0,0 +1,85 @@
+package repoImpl
+
+import (
+	"time"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type presenceRepositoryImpl struct {
+	db DBTX
+}
+
+func NewPresenceRepository(db DBTX) repositories.PresenceRepository {
+	return &presenceRepositoryImpl{db}
+}
+
+// Mark inserts every user in a single statement, so a bad user ID rejects
+// the whole roll call.
+func (r *presenceRepositoryImpl) Mark(lectureID uint, userIDs []uint, method domain.PresenceMethod, at time.Time) (int, error) {
+	res, err := r.db.Exec(`
+        INSERT INTO lecture_presence (lecture_id, user_id, method, marked_at)
+        SELECT $1, user_id, $3, $4 FROM unnest($2::integer[]) AS user_id
+        ON CONFLICT (lecture_id, user_id) DO NOTHING
+    `, lectureID, idArray(userIDs), method, at)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (r *presenceRepositoryImpl) Unmark(lectureID uint, userID uint) error {
+	_, err := r.db.Exec("DELETE FROM lecture_presence WHERE lecture_id = $1 AND user_id = $2", lectureID, userID)
+	return err
+}
+
+func (r *presenceRepositoryImpl) FindByLectures(lectureIDs []uint) ([]domain.Presence, error) {
+	rows, err := r.db.Query(`
+        SELECT p.lecture_id, p.user_id, p.method, p.marked_at,
+               u.user_id, u.email, u.nome, u.birth_date, u.sex, u.telephone, u.profile_id
+        FROM lecture_presence p
+        JOIN users u ON u.user_id = p.user_id
+        WHERE p.lecture_id = ANY($1)
+        ORDER BY p.lecture_id, p.marked_at, p.user_id
+    `, idArray(lectureIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var presence []domain.Presence
+	for rows.Next() {
+		var p domain.Presence
+		var u domain.User
+		if err := rows.Scan(&p.LectureID, &p.UserID, &p.Method, &p.MarkedAt,
+			&u.ID, &u.Email, &u.Nome, &u.BirthDate, &u.Sex, &u.Telephone, &u.ProfileID); err != nil {
+			return nil, err
+		}
+		p.User = &u
+		presence = append(presence, p)
+	}
+	return presence, rows.Err()
+}
+
+func (r *presenceRepositoryImpl) FindByUser(userID uint) ([]domain.Presence, error) {
+	rows, err := r.db.Query(
+		"SELECT lecture_id, user_id, method, marked_at FROM lecture_presence WHERE user_id = $1 ORDER BY lecture_id",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var presence []domain.Presence
+	for rows.Next() {
+		var p domain.Presence
+		if err := rows.Scan(&p.LectureID, &p.UserID, &p.Method, &p.MarkedAt); err != nil {
+			return nil, err
+		}
+		presence = append(presence, p)
+	}
+	return presence, rows.Err()
+}
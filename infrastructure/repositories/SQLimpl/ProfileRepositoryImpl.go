@@ -1,28 +1,28 @@
 package repoImpl
 
 import (
-	"database/sql"
 	"sarc/core/domain"
 	repositories "sarc/infrastructure/repositories/interfaces"
 )
 
 type profileRepositoryImpl struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewProfileRepository(db *sql.DB) repositories.ProfileRepository {
+func NewProfileRepository(db DBTX) repositories.ProfileRepository {
 	return &profileRepositoryImpl{db}
 }
 
 func (r *profileRepositoryImpl) Create(profile *domain.Profile) error {
 	return r.db.QueryRow(
-		"INSERT INTO profiles (role) VALUES ($1) RETURNING profile_id",
-		profile.Role,
+		"INSERT INTO profiles (role, can_teach) VALUES ($1, $2) RETURNING profile_id",
+		profile.Role, profile.CanTeach,
 	).Scan(&profile.ID)
 }
 
-func (r *profileRepositoryImpl) FindAll() ([]domain.Profile, error) {
-	rows, err := r.db.Query("SELECT profile_id, role FROM profiles")
+func (r *profileRepositoryImpl) FindAll(page domain.Page) ([]domain.Profile, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT profile_id, role, can_teach FROM profiles ORDER BY profile_id LIMIT $1 OFFSET $2", limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +31,7 @@ func (r *profileRepositoryImpl) FindAll() ([]domain.Profile, error) {
 	var profiles []domain.Profile
 	for rows.Next() {
 		var p domain.Profile
-		if err := rows.Scan(&p.ID, &p.Role); err != nil {
+		if err := rows.Scan(&p.ID, &p.Role, &p.CanTeach); err != nil {
 			return nil, err
 		}
 		profiles = append(profiles, p)
@@ -40,9 +40,9 @@ func (r *profileRepositoryImpl) FindAll() ([]domain.Profile, error) {
 }
 
 func (r *profileRepositoryImpl) FindByID(id uint) (*domain.Profile, error) {
-	row := r.db.QueryRow("SELECT profile_id, role FROM profiles WHERE profile_id = $1", id)
+	row := r.db.QueryRow("SELECT profile_id, role, can_teach FROM profiles WHERE profile_id = $1", id)
 	var p domain.Profile
-	if err := row.Scan(&p.ID, &p.Role); err != nil {
+	if err := row.Scan(&p.ID, &p.Role, &p.CanTeach); err != nil {
 		return nil, err
 	}
 	return &p, nil
@@ -50,8 +50,8 @@ func (r *profileRepositoryImpl) FindByID(id uint) (*domain.Profile, error) {
 
 func (r *profileRepositoryImpl) Update(id uint, profile *domain.Profile) error {
 	_, err := r.db.Exec(
-		"UPDATE profiles SET role = $1 WHERE profile_id = $2",
-		profile.Role, id,
+		"UPDATE profiles SET role = $1, can_teach = $2 WHERE profile_id = $3",
+		profile.Role, profile.CanTeach, id,
 	)
 	return err
 }
@@ -7,10 +7,10 @@ import (
 )
 
 type reservationRepositoryImpl struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewReservationRepository(db *sql.DB) repositories.ReservationRepository {
+func NewReservationRepository(db DBTX) repositories.ReservationRepository {
 	return &reservationRepositoryImpl{db}
 }
 
@@ -28,70 +28,171 @@ func (r *reservationRepositoryImpl) FindByID(id uint) (*domain.Reservation, erro
 		return nil, err
 	}
 
-	// Fetch resources for this reservation
-	resRows, err := r.db.Query(`
-        SELECT res.resource_id, res.description, res.status, res.characteristics, res.resource_type_id
-        FROM resources res
-        JOIN reservation_resources rr ON rr.resource_id = res.resource_id
-        WHERE rr.reservation_id = $1
-    `, id)
+	resources, err := r.resourcesByReservation([]uint{id})
 	if err != nil {
 		return nil, err
 	}
-	defer resRows.Close()
+	rsv.Resources = resources[id]
 
-	var resources []domain.Resource
-	for resRows.Next() {
-		var res domain.Resource
-		if err := resRows.Scan(&res.ResourceID, &res.Description, &res.Status, &res.Characteristics, &res.ResourceTypeID); err != nil {
-			return nil, err
-		}
-		resources = append(resources, res)
+	return &rsv, nil
+}
+
+func (r *reservationRepositoryImpl) FindAll(page domain.Page) ([]domain.Reservation, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT reservation_id, lecture_id, observation FROM reservations ORDER BY reservation_id LIMIT $1 OFFSET $2", limit, offset)
+	if err != nil {
+		return nil, err
 	}
-	rsv.Resources = resources
+	reservations, err := scanReservations(rows)
+	if err != nil {
+		return nil, err
+	}
+	return r.attachResources(reservations)
+}
 
-	return &rsv, nil
+func (r *reservationRepositoryImpl) FindByLecture(lectureID uint, page domain.Page) ([]domain.Reservation, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query(
+		"SELECT reservation_id, lecture_id, observation FROM reservations WHERE lecture_id = $1 ORDER BY reservation_id LIMIT $2 OFFSET $3",
+		lectureID, limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	reservations, err := scanReservations(rows)
+	if err != nil {
+		return nil, err
+	}
+	return r.attachResources(reservations)
 }
 
-func (r *reservationRepositoryImpl) FindAll() ([]domain.Reservation, error) {
-	rows, err := r.db.Query("SELECT reservation_id, lecture_id, observation FROM reservations")
+func (r *reservationRepositoryImpl) FindByLectures(lectureIDs []uint) ([]domain.Reservation, error) {
+	if len(lectureIDs) == 0 {
+		return nil, nil
+	}
+	rows, err := r.db.Query(
+		"SELECT reservation_id, lecture_id, observation FROM reservations WHERE lecture_id = ANY($1) ORDER BY lecture_id, reservation_id",
+		idArray(lectureIDs),
+	)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	reservations, err := scanReservations(rows)
+	if err != nil {
+		return nil, err
+	}
+	return r.attachResources(reservations)
+}
 
+// scanReservations reads every row of a reservations query, without the
+// resources, and closes rows.
+func scanReservations(rows *sql.Rows) ([]domain.Reservation, error) {
+	defer rows.Close()
 	var reservations []domain.Reservation
 	for rows.Next() {
 		var rsv domain.Reservation
 		if err := rows.Scan(&rsv.ReservationID, &rsv.LectureID, &rsv.Observation); err != nil {
 			return nil, err
 		}
+		reservations = append(reservations, rsv)
+	}
+	return reservations, rows.Err()
+}
 
-		// Fetch resources for each reservation
-		resRows, err := r.db.Query(`
-            SELECT res.resource_id, res.description, res.status, res.characteristics, res.resource_type_id
-            FROM resources res
-            JOIN reservation_resources rr ON rr.resource_id = res.resource_id
-            WHERE rr.reservation_id = $1
-        `, rsv.ReservationID)
-		if err != nil {
-			return nil, err
+// attachResources fetches the resources of every reservation in a single
+// query.
+func (r *reservationRepositoryImpl) attachResources(reservations []domain.Reservation) ([]domain.Reservation, error) {
+	ids := make([]uint, len(reservations))
+	for i, rsv := range reservations {
+		ids[i] = rsv.ReservationID
+	}
+	resources, err := r.resourcesByReservation(ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range reservations {
+		reservations[i].Resources = resources[reservations[i].ReservationID]
+	}
+	return reservations, nil
+}
+
+// StreamAll reads reservations and their resources in one ordered join,
+// emitting each reservation once its last resource row has been read.
+func (r *reservationRepositoryImpl) StreamAll(fn func(domain.Reservation) error) error {
+	rows, err := r.db.Query(`
+        SELECT rsv.reservation_id, rsv.lecture_id, rsv.observation,
+               res.resource_id, COALESCE(res.description, ''), COALESCE(res.status, ''),
+               res.characteristics, COALESCE(res.resource_type_id, 0)
+        FROM reservations rsv
+        LEFT JOIN reservation_resources rr ON rr.reservation_id = rsv.reservation_id
+        LEFT JOIN resources res ON res.resource_id = rr.resource_id
+        ORDER BY rsv.reservation_id, res.resource_id
+    `)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var current *domain.Reservation
+	for rows.Next() {
+		var rsv domain.Reservation
+		var resourceID sql.NullInt64
+		var res domain.Resource
+		if err := rows.Scan(&rsv.ReservationID, &rsv.LectureID, &rsv.Observation,
+			&resourceID, &res.Description, &res.Status, &res.Characteristics, &res.ResourceTypeID); err != nil {
+			return err
 		}
-		var resources []domain.Resource
-		for resRows.Next() {
-			var res domain.Resource
-			if err := resRows.Scan(&res.ResourceID, &res.Description, &res.Status, &res.Characteristics, &res.ResourceTypeID); err != nil {
-				resRows.Close()
-				return nil, err
+		if current != nil && current.ReservationID != rsv.ReservationID {
+			if err := fn(*current); err != nil {
+				return err
 			}
-			resources = append(resources, res)
+			current = nil
+		}
+		if current == nil {
+			current = &rsv
 		}
-		resRows.Close()
-		rsv.Resources = resources
+		if resourceID.Valid {
+			res.ResourceID = uint(resourceID.Int64)
+			current.Resources = append(current.Resources, res)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if current != nil {
+		return fn(*current)
+	}
+	return nil
+}
 
-		reservations = append(reservations, rsv)
+// resourcesByReservation loads the resources of all given reservations in
+// one round trip, keyed by reservation ID.
+func (r *reservationRepositoryImpl) resourcesByReservation(ids []uint) (map[uint][]domain.Resource, error) {
+	result := make(map[uint][]domain.Resource, len(ids))
+	if len(ids) == 0 {
+		return result, nil
 	}
-	return reservations, nil
+	rows, err := r.db.Query(`
+        SELECT rr.reservation_id, res.resource_id, res.description, res.status, res.characteristics, res.resource_type_id
+        FROM resources res
+        JOIN reservation_resources rr ON rr.resource_id = res.resource_id
+        WHERE rr.reservation_id = ANY($1)
+        ORDER BY rr.reservation_id, res.resource_id
+    `, idArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reservationID uint
+		var res domain.Resource
+		if err := rows.Scan(&reservationID, &res.ResourceID, &res.Description, &res.Status, &res.Characteristics, &res.ResourceTypeID); err != nil {
+			return nil, err
+		}
+		result[reservationID] = append(result[reservationID], res)
+	}
+	return result, rows.Err()
 }
 
 func (r *reservationRepositoryImpl) Update(id uint, reservation *domain.Reservation) error {
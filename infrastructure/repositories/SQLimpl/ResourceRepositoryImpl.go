@@ -1,16 +1,15 @@
 package repoImpl
 
 import (
-	"database/sql"
 	"sarc/core/domain"
 	repositories "sarc/infrastructure/repositories/interfaces"
 )
 
 type resourceRepositoryImpl struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewResourceRepository(db *sql.DB) repositories.ResourceRepository {
+func NewResourceRepository(db DBTX) repositories.ResourceRepository {
 	return &resourceRepositoryImpl{db}
 }
 
@@ -21,13 +20,61 @@ func (r *resourceRepositoryImpl) Create(resource *domain.Resource) error {
 	).Scan(&resource.ResourceID)
 }
 
-func (r *resourceRepositoryImpl) FindAll() ([]domain.Resource, error) {
+func (r *resourceRepositoryImpl) FindAll(page domain.Page) ([]domain.Resource, error) {
+	var resources []domain.Resource
+	err := r.stream(page, func(res domain.Resource) error {
+		resources = append(resources, res)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+func (r *resourceRepositoryImpl) StreamAll(fn func(domain.Resource) error) error {
+	return r.stream(domain.Page{}, fn)
+}
+
+// stream calls fn for each row of page, in ID order.
+func (r *resourceRepositoryImpl) stream(page domain.Page, fn func(domain.Resource) error) error {
+	limit, offset := pageArgs(page)
 	rows, err := r.db.Query(`
         SELECT res.resource_id, res.description, res.status, res.characteristics, res.resource_type_id,
                rt.resource_type_id, rt.name
         FROM resources res
         LEFT JOIN resource_types rt ON res.resource_type_id = rt.resource_type_id
-    `)
+        ORDER BY res.resource_id
+        LIMIT $1 OFFSET $2
+    `, limit, offset)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var res domain.Resource
+		var rt domain.ResourceType
+		if err := rows.Scan(&res.ResourceID, &res.Description, &res.Status, &res.Characteristics, &res.ResourceTypeID, &rt.ResourceTypeID, &rt.Name); err != nil {
+			return err
+		}
+		res.ResourceType = &rt
+		if err := fn(res); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *resourceRepositoryImpl) FindByResourceType(resourceTypeID uint) ([]domain.Resource, error) {
+	rows, err := r.db.Query(`
+        SELECT res.resource_id, res.description, res.status, res.characteristics, res.resource_type_id,
+               rt.resource_type_id, rt.name
+        FROM resources res
+        JOIN resource_types rt ON res.resource_type_id = rt.resource_type_id
+        WHERE res.resource_type_id = $1
+        ORDER BY res.resource_id
+    `, resourceTypeID)
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +90,7 @@ func (r *resourceRepositoryImpl) FindAll() ([]domain.Resource, error) {
 		res.ResourceType = &rt
 		resources = append(resources, res)
 	}
-	return resources, nil
+	return resources, rows.Err()
 }
 
 func (r *resourceRepositoryImpl) FindByID(id uint) (*domain.Resource, error) {
@@ -1,29 +1,28 @@
 package repoImpl
 
 import (
-	"database/sql"
 	"sarc/core/domain"
 	repositories "sarc/infrastructure/repositories/interfaces"
 )
 
 type resourceTypeRepositoryImpl struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewResourceTypeRepository(db *sql.DB) repositories.ResourceTypeRepository {
+func NewResourceTypeRepository(db DBTX) repositories.ResourceTypeRepository {
 	return &resourceTypeRepositoryImpl{db}
 }
 
 func (r *resourceTypeRepositoryImpl) Create(resourceType *domain.ResourceType) error {
-	_, err := r.db.Exec(
-		"INSERT INTO resource_types (name) VALUES ($1)",
+	return r.db.QueryRow(
+		"INSERT INTO resource_types (name) VALUES ($1) RETURNING resource_type_id",
 		resourceType.Name,
-	)
-	return err
+	).Scan(&resourceType.ResourceTypeID)
 }
 
-func (r *resourceTypeRepositoryImpl) FindAll() ([]domain.ResourceType, error) {
-	rows, err := r.db.Query("SELECT resource_type_id, name FROM resource_types")
+func (r *resourceTypeRepositoryImpl) FindAll(page domain.Page) ([]domain.ResourceType, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT resource_type_id, name FROM resource_types ORDER BY resource_type_id LIMIT $1 OFFSET $2", limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -49,6 +48,24 @@ func (r *resourceTypeRepositoryImpl) FindByID(id uint) (*domain.ResourceType, er
 	return &t, nil
 }
 
+func (r *resourceTypeRepositoryImpl) FindByIDs(ids []uint) ([]domain.ResourceType, error) {
+	rows, err := r.db.Query("SELECT resource_type_id, name FROM resource_types WHERE resource_type_id = ANY($1) ORDER BY resource_type_id", idArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var types []domain.ResourceType
+	for rows.Next() {
+		var t domain.ResourceType
+		if err := rows.Scan(&t.ResourceTypeID, &t.Name); err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+	return types, rows.Err()
+}
+
 func (r *resourceTypeRepositoryImpl) Update(id uint, resourceType *domain.ResourceType) error {
 	_, err := r.db.Exec(
 		"UPDATE resource_types SET name = $1 WHERE resource_type_id = $2",
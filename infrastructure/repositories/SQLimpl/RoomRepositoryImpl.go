@@ -1,16 +1,15 @@
 package repoImpl
 
 import (
-	"database/sql"
 	"sarc/core/domain"
 	repositories "sarc/infrastructure/repositories/interfaces"
 )
 
 type roomRepositoryImpl struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewRoomRepository(db *sql.DB) repositories.RoomRepository {
+func NewRoomRepository(db DBTX) repositories.RoomRepository {
 	return &roomRepositoryImpl{db}
 }
 
@@ -21,22 +20,41 @@ func (r *roomRepositoryImpl) Create(room *domain.Room) error {
 	).Scan(&room.RoomID)
 }
 
-func (r *roomRepositoryImpl) FindAll() ([]domain.Room, error) {
-	rows, err := r.db.Query("SELECT room_id, room_number, building_id, room_capacity, floor FROM rooms")
+func (r *roomRepositoryImpl) FindAll(page domain.Page) ([]domain.Room, error) {
+	var rooms []domain.Room
+	err := r.stream(page, func(rm domain.Room) error {
+		rooms = append(rooms, rm)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return rooms, nil
+}
+
+func (r *roomRepositoryImpl) StreamAll(fn func(domain.Room) error) error {
+	return r.stream(domain.Page{}, fn)
+}
+
+// stream calls fn for each row of page, in ID order.
+func (r *roomRepositoryImpl) stream(page domain.Page, fn func(domain.Room) error) error {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT room_id, room_number, building_id, room_capacity, floor FROM rooms ORDER BY room_id LIMIT $1 OFFSET $2", limit, offset)
+	if err != nil {
+		return err
+	}
 	defer rows.Close()
 
-	var rooms []domain.Room
 	for rows.Next() {
 		var rm domain.Room
 		if err := rows.Scan(&rm.RoomID, &rm.RoomNumber, &rm.BuildingID, &rm.RoomCapacity, &rm.Floor); err != nil {
-			return nil, err
+			return err
+		}
+		if err := fn(rm); err != nil {
+			return err
 		}
-		rooms = append(rooms, rm)
 	}
-	return rooms, nil
+	return rows.Err()
 }
 
 func (r *roomRepositoryImpl) FindByID(id uint) (*domain.Room, error) {
@@ -48,6 +66,46 @@ func (r *roomRepositoryImpl) FindByID(id uint) (*domain.Room, error) {
 	return &rm, nil
 }
 
+func (r *roomRepositoryImpl) FindByIDs(ids []uint) ([]domain.Room, error) {
+	rows, err := r.db.Query("SELECT room_id, room_number, building_id, room_capacity, floor FROM rooms WHERE room_id = ANY($1) ORDER BY room_id", idArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rooms []domain.Room
+	for rows.Next() {
+		var rm domain.Room
+		if err := rows.Scan(&rm.RoomID, &rm.RoomNumber, &rm.BuildingID, &rm.RoomCapacity, &rm.Floor); err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, rm)
+	}
+	return rooms, rows.Err()
+}
+
+func (r *roomRepositoryImpl) FindByBuilding(buildingID uint, page domain.Page) ([]domain.Room, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query(
+		"SELECT room_id, room_number, building_id, room_capacity, floor FROM rooms WHERE building_id = $1 ORDER BY room_id LIMIT $2 OFFSET $3",
+		buildingID, limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rooms []domain.Room
+	for rows.Next() {
+		var rm domain.Room
+		if err := rows.Scan(&rm.RoomID, &rm.RoomNumber, &rm.BuildingID, &rm.RoomCapacity, &rm.Floor); err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, rm)
+	}
+	return rooms, rows.Err()
+}
+
 func (r *roomRepositoryImpl) Update(id uint, room *domain.Room) error {
 	_, err := r.db.Exec(
 		"UPDATE rooms SET room_number = $1, building_id = $2, room_capacity = $3, floor = $4 WHERE room_id = $5",
@@ -0,0 +1,105 @@
+package repoImpl
+
+import (
+	"html"
+	"strings"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type searchRepositoryImpl struct {
+	db DBTX
+}
+
+func NewSearchRepository(db DBTX) repositories.SearchRepository {
+	return &searchRepositoryImpl{db}
+}
+
+// searchQuery matches any word of $1, stemmed in Portuguese or English,
+// against the search_vector columns. Each type is ranked and limited to $2
+// before ts_headline runs, as building headlines is the expensive part.
+// Rooms match through their building, or directly by room number.
+const searchQuery = `
+    WITH q AS (
+        SELECT replace(plainto_tsquery('portuguese', $1)::text, '&', '|')::tsquery
+            || replace(plainto_tsquery('english', $1)::text, '&', '|')::tsquery AS query,
+            regexp_split_to_array(lower(trim($1)), '\s+') AS words
+    )
+    SELECT 'discipline', d.discipline_id, d.name, d.rank,
+           ts_headline('portuguese', concat_ws(' · ', d.name, d.program, search_array_text(d.bibliography)), q.query, $3)
+    FROM (
+        SELECT discipline_id, name, program, bibliography, ts_rank(search_vector, q.query) AS rank
+        FROM disciplines, q
+        WHERE search_vector @@ q.query
+        ORDER BY rank DESC, discipline_id LIMIT $2
+    ) d, q
+    UNION ALL
+    SELECT 'resource', r.resource_id, r.description, r.rank,
+           ts_headline('portuguese', concat_ws(' · ', r.description, search_array_text(r.characteristics)), q.query, $3)
+    FROM (
+        SELECT resource_id, description, characteristics, ts_rank(search_vector, q.query) AS rank
+        FROM resources, q
+        WHERE search_vector @@ q.query
+        ORDER BY rank DESC, resource_id LIMIT $2
+    ) r, q
+    UNION ALL
+    SELECT 'building', b.building_id, b.building_name, b.rank,
+           ts_headline('portuguese', concat_ws(' · ', b.building_name, b.address), q.query, $3)
+    FROM (
+        SELECT building_id, building_name, address, ts_rank(search_vector, q.query) AS rank
+        FROM buildings, q
+        WHERE search_vector @@ q.query
+        ORDER BY rank DESC, building_id LIMIT $2
+    ) b, q
+    UNION ALL
+    SELECT 'room', rm.room_id, concat_ws(' ', rm.building_name, rm.room_number), rm.rank,
+           ts_headline('portuguese', concat_ws(' · ', rm.building_name, rm.address, rm.room_number), q.query, $3)
+    FROM (
+        SELECT r.room_id, r.room_number, b.building_name, b.address,
+               ts_rank(b.search_vector, q.query)
+                   + CASE WHEN lower(r.room_number) = ANY(q.words) THEN 1 ELSE 0 END AS rank
+        FROM rooms r
+        JOIN buildings b ON b.building_id = r.building_id, q
+        WHERE b.search_vector @@ q.query OR lower(r.room_number) = ANY(q.words)
+        ORDER BY rank DESC, r.room_id LIMIT $2
+    ) rm, q
+    UNION ALL
+    SELECT 'class', c.class_id, c.name, c.rank,
+           ts_headline('portuguese', concat_ws(' · ', c.name, c.description), q.query, $3)
+    FROM (
+        SELECT class_id, name, description, ts_rank(search_vector, q.query) AS rank
+        FROM classes, q
+        WHERE search_vector @@ q.query
+        ORDER BY rank DESC, class_id LIMIT $2
+    ) c, q
+`
+
+// ts_headline marks matches with private-use characters rather than tags,
+// so the text can be HTML-escaped before the marks become <b></b>.
+const (
+	headlineStart   = "\uE000"
+	headlineStop    = "\uE001"
+	headlineOptions = "StartSel=" + headlineStart + ", StopSel=" + headlineStop + ", MaxWords=25, MinWords=8, MaxFragments=2"
+)
+
+var headlineMarkup = strings.NewReplacer(headlineStart, "<b>", headlineStop, "</b>")
+
+func (r *searchRepositoryImpl) Search(q domain.SearchQuery) ([]domain.SearchHit, error) {
+	rows, err := r.db.Query(searchQuery, q.Text, q.Limit, headlineOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []domain.SearchHit
+	for rows.Next() {
+		var hit domain.SearchHit
+		if err := rows.Scan(&hit.Type, &hit.ID, &hit.Title, &hit.Rank, &hit.Headline); err != nil {
+			return nil, err
+		}
+		hit.Headline = headlineMarkup.Replace(html.EscapeString(hit.Headline))
+		hits = append(hits, hit)
+	}
+	return hits, rows.Err()
+}
@@ -0,0 +1,40 @@
+package repoImpl
+
+import (
+	"database/sql"
+	"fmt"
+
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+// DBTX is the subset of *sql.DB and *sql.Tx the repositories use, so the
+// same implementation can run inside or outside a transaction.
+type DBTX interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+type transactor struct {
+	db *sql.DB
+}
+
+// NewTransactor returns a Transactor that runs each unit of work in a
+// Postgres transaction.
+func NewTransactor(db *sql.DB) repositories.Transactor {
+	return &transactor{db: db}
+}
+
+func (t *transactor) WithinTransaction(fn func(repos repositories.Repositories) error) error {
+	tx, err := t.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(NewRepositories(tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
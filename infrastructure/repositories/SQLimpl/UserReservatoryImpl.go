@@ -1,16 +1,15 @@
 package repoImpl
 
 import (
-	"database/sql"
 	"sarc/core/domain"
 	repositories "sarc/infrastructure/repositories/interfaces"
 )
 
 type userRepositoryImpl struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewUserRepository(db *sql.DB) repositories.UserRepository {
+func NewUserRepository(db DBTX) repositories.UserRepository {
 	return &userRepositoryImpl{db}
 }
 
@@ -21,22 +20,41 @@ func (r *userRepositoryImpl) Create(user *domain.User) error {
 	).Scan(&user.ID)
 }
 
-func (r *userRepositoryImpl) FindAll() ([]domain.User, error) {
-	rows, err := r.db.Query("SELECT user_id, email, nome, birth_date, sex, telephone, profile_id FROM users")
+func (r *userRepositoryImpl) FindAll(page domain.Page) ([]domain.User, error) {
+	var users []domain.User
+	err := r.stream(page, func(u domain.User) error {
+		users = append(users, u)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return users, nil
+}
+
+func (r *userRepositoryImpl) StreamAll(fn func(domain.User) error) error {
+	return r.stream(domain.Page{}, fn)
+}
+
+// stream calls fn for each row of page, in ID order.
+func (r *userRepositoryImpl) stream(page domain.Page, fn func(domain.User) error) error {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT user_id, email, nome, birth_date, sex, telephone, profile_id FROM users ORDER BY user_id LIMIT $1 OFFSET $2", limit, offset)
+	if err != nil {
+		return err
+	}
 	defer rows.Close()
 
-	var users []domain.User
 	for rows.Next() {
 		var u domain.User
 		if err := rows.Scan(&u.ID, &u.Email, &u.Nome, &u.BirthDate, &u.Sex, &u.Telephone, &u.ProfileID); err != nil {
-			return nil, err
+			return err
+		}
+		if err := fn(u); err != nil {
+			return err
 		}
-		users = append(users, u)
 	}
-	return users, nil
+	return rows.Err()
 }
 
 func (r *userRepositoryImpl) FindByID(id uint) (*domain.User, error) {
@@ -48,6 +66,24 @@ func (r *userRepositoryImpl) FindByID(id uint) (*domain.User, error) {
 	return &u, nil
 }
 
+func (r *userRepositoryImpl) FindByIDs(ids []uint) ([]domain.User, error) {
+	rows, err := r.db.Query("SELECT user_id, email, nome, birth_date, sex, telephone, profile_id FROM users WHERE user_id = ANY($1) ORDER BY user_id", idArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var u domain.User
+		if err := rows.Scan(&u.ID, &u.Email, &u.Nome, &u.BirthDate, &u.Sex, &u.Telephone, &u.ProfileID); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
 func (r *userRepositoryImpl) Update(id uint, user *domain.User) error {
 	_, err := r.db.Exec(
 		"UPDATE users SET email = $1, nome = $2, birth_date = $3, sex = $4, telephone = $5, profile_id = $6 WHERE user_id = $7",
@@ -0,0 +1,92 @@
+package sqliteImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type buildingRepositoryImpl struct {
+	db DBTX
+}
+
+func NewBuildingRepository(db DBTX) repositories.BuildingRepository {
+	return &buildingRepositoryImpl{db}
+}
+
+func (r *buildingRepositoryImpl) Create(building *domain.Building) error {
+	res, err := r.db.Exec(
+		"INSERT INTO buildings (building_name, address) VALUES (?, ?)",
+		building.BuildingName, building.Address,
+	)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	building.BuildingID = uint(id)
+	return err
+}
+
+func (r *buildingRepositoryImpl) FindAll(page domain.Page) ([]domain.Building, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT building_id, building_name, address FROM buildings ORDER BY building_id LIMIT ? OFFSET ?", limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var buildings []domain.Building
+	for rows.Next() {
+		var b domain.Building
+		if err := rows.Scan(&b.BuildingID, &b.BuildingName, &b.Address); err != nil {
+			return nil, err
+		}
+		buildings = append(buildings, b)
+	}
+	return buildings, nil
+}
+
+func (r *buildingRepositoryImpl) FindByID(id uint) (*domain.Building, error) {
+	row := r.db.QueryRow("SELECT building_id, building_name, address FROM buildings WHERE building_id = ?", id)
+	var b domain.Building
+	if err := row.Scan(&b.BuildingID, &b.BuildingName, &b.Address); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (r *buildingRepositoryImpl) FindByIDs(ids []uint) ([]domain.Building, error) {
+	var buildings []domain.Building
+	for _, batch := range chunks(ids) {
+		in, args := inClause(batch)
+		rows, err := r.db.Query("SELECT building_id, building_name, address FROM buildings WHERE building_id IN "+in+" ORDER BY building_id", args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var b domain.Building
+			if err := rows.Scan(&b.BuildingID, &b.BuildingName, &b.Address); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			buildings = append(buildings, b)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return buildings, nil
+}
+
+func (r *buildingRepositoryImpl) Update(id uint, building *domain.Building) error {
+	_, err := r.db.Exec(
+		"UPDATE buildings SET building_name = ?, address = ? WHERE building_id = ?",
+		building.BuildingName, building.Address, id,
+	)
+	return err
+}
+
+func (r *buildingRepositoryImpl) Delete(id uint) error {
+	_, err := r.db.Exec("DELETE FROM buildings WHERE building_id = ?", id)
+	return err
+}
@@ -0,0 +1,33 @@
+package sqliteImpl
+
+import (
+	"time"
+
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type checkInClaimRepositoryImpl struct {
+	db DBTX
+}
+
+func NewCheckInClaimRepository(db DBTX) repositories.CheckInClaimRepository {
+	return &checkInClaimRepositoryImpl{db}
+}
+
+// Claim relies on the primary key, so of two concurrent claims of the
+// same token only one inserts a row.
+func (r *checkInClaimRepositoryImpl) Claim(lectureID uint, userID uint, window int64, expiresAt time.Time, now time.Time) (bool, error) {
+	if _, err := r.db.Exec("DELETE FROM check_in_claims WHERE expires_at <= ?", now.UTC()); err != nil {
+		return false, err
+	}
+	res, err := r.db.Exec(`
+        INSERT INTO check_in_claims (lecture_id, user_id, token_window, expires_at)
+        VALUES (?, ?, ?, ?)
+        ON CONFLICT (lecture_id, user_id, token_window) DO NOTHING
+    `, lectureID, userID, window, expiresAt.UTC())
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
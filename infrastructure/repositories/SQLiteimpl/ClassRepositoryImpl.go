@@ -0,0 +1,114 @@
+package sqliteImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type classRepositoryImpl struct {
+	db DBTX
+}
+
+func NewClassRepository(db DBTX) repositories.ClassRepository {
+	return &classRepositoryImpl{db}
+}
+
+func (r *classRepositoryImpl) Create(class *domain.Class) error {
+	res, err := r.db.Exec(
+		"INSERT INTO classes (name, description, discipline_id) VALUES (?, ?, ?)",
+		class.Name, class.Description, class.DisciplineID,
+	)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	class.ClassID = uint(id)
+	return err
+}
+
+func (r *classRepositoryImpl) FindAll(page domain.Page) ([]domain.Class, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT class_id, name, description, discipline_id FROM classes ORDER BY class_id LIMIT ? OFFSET ?", limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var classes []domain.Class
+	for rows.Next() {
+		var c domain.Class
+		if err := rows.Scan(&c.ClassID, &c.Name, &c.Description, &c.DisciplineID); err != nil {
+			return nil, err
+		}
+		classes = append(classes, c)
+	}
+	return classes, nil
+}
+
+func (r *classRepositoryImpl) FindByID(id uint) (*domain.Class, error) {
+	row := r.db.QueryRow("SELECT class_id, name, description, discipline_id FROM classes WHERE class_id = ?", id)
+	var c domain.Class
+	if err := row.Scan(&c.ClassID, &c.Name, &c.Description, &c.DisciplineID); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *classRepositoryImpl) FindByIDs(ids []uint) ([]domain.Class, error) {
+	var classes []domain.Class
+	for _, batch := range chunks(ids) {
+		in, args := inClause(batch)
+		rows, err := r.db.Query("SELECT class_id, name, description, discipline_id FROM classes WHERE class_id IN "+in+" ORDER BY class_id", args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var c domain.Class
+			if err := rows.Scan(&c.ClassID, &c.Name, &c.Description, &c.DisciplineID); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			classes = append(classes, c)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return classes, nil
+}
+
+func (r *classRepositoryImpl) FindByDiscipline(disciplineID uint, page domain.Page) ([]domain.Class, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query(
+		"SELECT class_id, name, description, discipline_id FROM classes WHERE discipline_id = ? ORDER BY class_id LIMIT ? OFFSET ?",
+		disciplineID, limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var classes []domain.Class
+	for rows.Next() {
+		var c domain.Class
+		if err := rows.Scan(&c.ClassID, &c.Name, &c.Description, &c.DisciplineID); err != nil {
+			return nil, err
+		}
+		classes = append(classes, c)
+	}
+	return classes, rows.Err()
+}
+
+func (r *classRepositoryImpl) Update(id uint, class *domain.Class) error {
+	_, err := r.db.Exec(
+		"UPDATE classes SET name = ?, description = ?, discipline_id = ? WHERE class_id = ?",
+		class.Name, class.Description, class.DisciplineID, id,
+	)
+	return err
+}
+
+func (r *classRepositoryImpl) Delete(id uint) error {
+	_, err := r.db.Exec("DELETE FROM classes WHERE class_id = ?", id)
+	return err
+}
@@ -0,0 +1,29 @@
+package sqliteImpl_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	sqliteimpl "sarc/infrastructure/repositories/SQLiteimpl"
+	repositories "sarc/infrastructure/repositories/interfaces"
+	"sarc/infrastructure/repositories/repotest"
+	"sarc/pkg/db"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestContract(t *testing.T) {
+	repotest.RunContract(t, func(t *testing.T) repositories.Repositories {
+		path := filepath.Join(t.TempDir(), "sarc.db")
+		conn, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		if err := db.Migrate(conn, db.DriverSQLite); err != nil {
+			t.Fatal(err)
+		}
+		return sqliteimpl.NewRepositories(conn)
+	})
+}
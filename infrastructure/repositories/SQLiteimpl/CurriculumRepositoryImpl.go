@@ -0,0 +1,229 @@
+package sqliteImpl
+
+import (
+	"database/sql"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type curriculumRepositoryImpl struct {
+	db DBTX
+}
+
+// curriculumColumns reads lineage_id as the curriculum's own ID for first
+// versions, where it is NULL.
+const curriculumColumns = "curriculum_id, course_name, data_inicio, data_fim, COALESCE(lineage_id, curriculum_id), version"
+
+func NewCurriculumRepository(db DBTX) repositories.CurriculumRepository {
+	return &curriculumRepositoryImpl{db}
+}
+
+func (r *curriculumRepositoryImpl) Create(curriculum *domain.Curriculum) error {
+	res, err := r.db.Exec(
+		"INSERT INTO curriculums (course_name, data_inicio, data_fim, lineage_id, version) VALUES (?, ?, ?, NULLIF(?, 0), ?)",
+		curriculum.CourseName, curriculum.DataInicio, curriculum.DataFim, curriculum.LineageID, curriculum.Version,
+	)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	curriculum.ID = uint(id)
+	if curriculum.LineageID == 0 {
+		curriculum.LineageID = curriculum.ID
+	}
+	return err
+}
+
+func (r *curriculumRepositoryImpl) FindByID(id uint) (*domain.Curriculum, error) {
+	row := r.db.QueryRow("SELECT "+curriculumColumns+" FROM curriculums WHERE curriculum_id = ?", id)
+	var c domain.Curriculum
+	if err := row.Scan(&c.ID, &c.CourseName, &c.DataInicio, &c.DataFim, &c.LineageID, &c.Version); err != nil {
+		return nil, err
+	}
+
+	disciplines, err := r.disciplinesByCurriculum([]uint{id})
+	if err != nil {
+		return nil, err
+	}
+	c.Disciplines = disciplines[id]
+
+	return &c, nil
+}
+
+func (r *curriculumRepositoryImpl) FindAll(page domain.Page) ([]domain.Curriculum, error) {
+	limit, offset := pageArgs(page)
+	return r.find("ORDER BY curriculum_id LIMIT ? OFFSET ?", limit, offset)
+}
+
+func (r *curriculumRepositoryImpl) FindByLineage(lineageID uint) ([]domain.Curriculum, error) {
+	return r.find("WHERE COALESCE(lineage_id, curriculum_id) = ? ORDER BY version, curriculum_id", lineageID)
+}
+
+func (r *curriculumRepositoryImpl) LockLineage(id uint) error {
+	// SQLite has no row locks; a write takes the database's write lock,
+	// which is held until the transaction ends.
+	_, err := r.db.Exec("UPDATE curriculums SET version = version WHERE curriculum_id = ?", id)
+	return err
+}
+
+// find loads the curriculums selected by clause, with their disciplines.
+func (r *curriculumRepositoryImpl) find(clause string, args ...any) ([]domain.Curriculum, error) {
+	rows, err := r.db.Query("SELECT "+curriculumColumns+" FROM curriculums "+clause, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var curriculums []domain.Curriculum
+	var ids []uint
+	for rows.Next() {
+		var c domain.Curriculum
+		if err := rows.Scan(&c.ID, &c.CourseName, &c.DataInicio, &c.DataFim, &c.LineageID, &c.Version); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		curriculums = append(curriculums, c)
+		ids = append(ids, c.ID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	disciplines, err := r.disciplinesByCurriculum(ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range curriculums {
+		curriculums[i].Disciplines = disciplines[curriculums[i].ID]
+	}
+	return curriculums, nil
+}
+
+// disciplinesByCurriculum loads the disciplines of all given curriculums
+// with one query per batch of IDs, keyed by curriculum ID.
+func (r *curriculumRepositoryImpl) disciplinesByCurriculum(ids []uint) (map[uint][]domain.Discipline, error) {
+	result := make(map[uint][]domain.Discipline, len(ids))
+	for _, batch := range chunks(ids) {
+		in, args := inClause(batch)
+		rows, err := r.db.Query(`
+            SELECT cd.curriculum_id, d.discipline_id, d.name, d.credits, d.program, d.bibliography, cd.semester, cd.elective
+            FROM disciplines d
+            JOIN curriculum_disciplines cd ON cd.discipline_id = d.discipline_id
+            WHERE cd.curriculum_id IN `+in+`
+            ORDER BY cd.curriculum_id, cd.semester, d.discipline_id
+        `, args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var curriculumID uint
+			var d domain.Discipline
+			if err := rows.Scan(&curriculumID, &d.ID, &d.Name, &d.Credits, &d.Program, jsonArray{&d.Bibliography}, &d.Semester, &d.Elective); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			result[curriculumID] = append(result[curriculumID], d)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (r *curriculumRepositoryImpl) Update(id uint, curriculum *domain.Curriculum) error {
+	_, err := r.db.Exec(
+		"UPDATE curriculums SET course_name = ?, data_inicio = ?, data_fim = ? WHERE curriculum_id = ?",
+		curriculum.CourseName, curriculum.DataInicio, curriculum.DataFim, id,
+	)
+	return err
+}
+
+func (r *curriculumRepositoryImpl) Delete(id uint) error {
+	_, err := r.db.Exec("DELETE FROM curriculums WHERE curriculum_id = ?", id)
+	return err
+}
+
+func (r *curriculumRepositoryImpl) AddDisciplineToCurriculum(curriculumID uint, discipline domain.Discipline) error {
+	_, err := r.db.Exec(
+		`
+        INSERT INTO curriculum_disciplines (curriculum_id, discipline_id, semester, elective) VALUES (?, ?, ?, ?)
+        ON CONFLICT (curriculum_id, discipline_id) DO NOTHING
+    `,
+		curriculumID, discipline.ID, discipline.Semester, discipline.Elective,
+	)
+	return err
+}
+
+func (r *curriculumRepositoryImpl) RemoveDisciplineFromCurriculum(curriculumID uint, disciplineID uint) error {
+	if _, err := r.db.Exec(
+		"DELETE FROM curriculum_requisites WHERE curriculum_id = ? AND (discipline_id = ? OR requisite_id = ?)",
+		curriculumID, disciplineID, disciplineID,
+	); err != nil {
+		return err
+	}
+	_, err := r.db.Exec(
+		"DELETE FROM curriculum_disciplines WHERE curriculum_id = ? AND discipline_id = ?",
+		curriculumID, disciplineID,
+	)
+	return err
+}
+
+func (r *curriculumRepositoryImpl) PlaceDiscipline(curriculumID uint, discipline domain.Discipline) error {
+	res, err := r.db.Exec(
+		"UPDATE curriculum_disciplines SET semester = ?, elective = ? WHERE curriculum_id = ? AND discipline_id = ?",
+		discipline.Semester, discipline.Elective, curriculumID, discipline.ID,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *curriculumRepositoryImpl) FindRequisites(curriculumID uint) ([]domain.Requisite, error) {
+	rows, err := r.db.Query(`
+        SELECT curriculum_id, discipline_id, requisite_id, kind
+        FROM curriculum_requisites
+        WHERE curriculum_id = ?
+        ORDER BY discipline_id, requisite_id
+    `, curriculumID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requisites []domain.Requisite
+	for rows.Next() {
+		var q domain.Requisite
+		if err := rows.Scan(&q.CurriculumID, &q.DisciplineID, &q.RequisiteID, &q.Kind); err != nil {
+			return nil, err
+		}
+		requisites = append(requisites, q)
+	}
+	return requisites, rows.Err()
+}
+
+func (r *curriculumRepositoryImpl) AddRequisite(requisite *domain.Requisite) error {
+	_, err := r.db.Exec(
+		"INSERT INTO curriculum_requisites (curriculum_id, discipline_id, requisite_id, kind) VALUES (?, ?, ?, ?)",
+		requisite.CurriculumID, requisite.DisciplineID, requisite.RequisiteID, requisite.Kind,
+	)
+	return err
+}
+
+func (r *curriculumRepositoryImpl) RemoveRequisite(curriculumID uint, disciplineID uint, requisiteID uint) error {
+	_, err := r.db.Exec(
+		"DELETE FROM curriculum_requisites WHERE curriculum_id = ? AND discipline_id = ? AND requisite_id = ?",
+		curriculumID, disciplineID, requisiteID,
+	)
+	return err
+}
@@ -0,0 +1,92 @@
+package sqliteImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type disciplineRepositoryImpl struct {
+	db DBTX
+}
+
+func NewDisciplineRepository(db DBTX) repositories.DisciplineRepository {
+	return &disciplineRepositoryImpl{db}
+}
+
+func (r *disciplineRepositoryImpl) Create(discipline *domain.Discipline) error {
+	res, err := r.db.Exec(
+		"INSERT INTO disciplines (name, credits, program, bibliography) VALUES (?, ?, ?, ?)",
+		discipline.Name, discipline.Credits, discipline.Program, arrayValue(discipline.Bibliography),
+	)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	discipline.ID = uint(id)
+	return err
+}
+
+func (r *disciplineRepositoryImpl) FindAll(page domain.Page) ([]domain.Discipline, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT discipline_id, name, credits, program, bibliography FROM disciplines ORDER BY discipline_id LIMIT ? OFFSET ?", limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var disciplines []domain.Discipline
+	for rows.Next() {
+		var d domain.Discipline
+		if err := rows.Scan(&d.ID, &d.Name, &d.Credits, &d.Program, jsonArray{&d.Bibliography}); err != nil {
+			return nil, err
+		}
+		disciplines = append(disciplines, d)
+	}
+	return disciplines, nil
+}
+
+func (r *disciplineRepositoryImpl) FindByID(id uint) (*domain.Discipline, error) {
+	row := r.db.QueryRow("SELECT discipline_id, name, credits, program, bibliography FROM disciplines WHERE discipline_id = ?", id)
+	var d domain.Discipline
+	if err := row.Scan(&d.ID, &d.Name, &d.Credits, &d.Program, jsonArray{&d.Bibliography}); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *disciplineRepositoryImpl) FindByIDs(ids []uint) ([]domain.Discipline, error) {
+	var disciplines []domain.Discipline
+	for _, batch := range chunks(ids) {
+		in, args := inClause(batch)
+		rows, err := r.db.Query("SELECT discipline_id, name, credits, program, bibliography FROM disciplines WHERE discipline_id IN "+in+" ORDER BY discipline_id", args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var d domain.Discipline
+			if err := rows.Scan(&d.ID, &d.Name, &d.Credits, &d.Program, jsonArray{&d.Bibliography}); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			disciplines = append(disciplines, d)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return disciplines, nil
+}
+
+func (r *disciplineRepositoryImpl) Update(id uint, discipline *domain.Discipline) error {
+	_, err := r.db.Exec(
+		"UPDATE disciplines SET name = ?, credits = ?, program = ?, bibliography = ? WHERE discipline_id = ?",
+		discipline.Name, discipline.Credits, discipline.Program, arrayValue(discipline.Bibliography), id,
+	)
+	return err
+}
+
+func (r *disciplineRepositoryImpl) Delete(id uint) error {
+	_, err := r.db.Exec("DELETE FROM disciplines WHERE discipline_id = ?", id)
+	return err
+}
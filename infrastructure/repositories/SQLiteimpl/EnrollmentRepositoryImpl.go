@@ -0,0 +1,159 @@
+package sqliteImpl
+
+import (
+	"cmp"
+	"database/sql"
+	"slices"
+	"time"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type enrollmentRepositoryImpl struct {
+	db DBTX
+}
+
+func NewEnrollmentRepository(db DBTX) repositories.EnrollmentRepository {
+	return &enrollmentRepositoryImpl{db}
+}
+
+func (r *enrollmentRepositoryImpl) Create(enrollment *domain.Enrollment) error {
+	res, err := r.db.Exec(
+		"INSERT INTO enrollments (class_id, user_id, role, status, enrolled_at, dropped_at) VALUES (?, ?, ?, ?, ?, ?)",
+		enrollment.ClassID, enrollment.UserID, enrollment.Role, enrollment.Status, enrollment.EnrolledAt.UTC(), utcTime(enrollment.DroppedAt),
+	)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	enrollment.EnrollmentID = uint(id)
+	return err
+}
+
+func (r *enrollmentRepositoryImpl) Update(enrollment *domain.Enrollment) error {
+	_, err := r.db.Exec(
+		"UPDATE enrollments SET role = ?, status = ?, enrolled_at = ?, dropped_at = ? WHERE enrollment_id = ?",
+		enrollment.Role, enrollment.Status, enrollment.EnrolledAt.UTC(), utcTime(enrollment.DroppedAt), enrollment.EnrollmentID,
+	)
+	return err
+}
+
+func (r *enrollmentRepositoryImpl) Find(classID uint, userID uint) (*domain.Enrollment, error) {
+	row := r.db.QueryRow(
+		"SELECT enrollment_id, class_id, user_id, role, status, enrolled_at, dropped_at FROM enrollments WHERE class_id = ? AND user_id = ?",
+		classID, userID,
+	)
+	var e domain.Enrollment
+	if err := row.Scan(&e.EnrollmentID, &e.ClassID, &e.UserID, &e.Role, &e.Status, &e.EnrolledAt, &e.DroppedAt); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *enrollmentRepositoryImpl) FindByClass(classID uint) ([]domain.Enrollment, error) {
+	return r.findRoster("e.class_id = ?", classID)
+}
+
+func (r *enrollmentRepositoryImpl) FindByClasses(classIDs []uint) ([]domain.Enrollment, error) {
+	var enrollments []domain.Enrollment
+	for _, batch := range chunks(classIDs) {
+		in, args := inClause(batch)
+		found, err := r.findRoster("e.class_id IN "+in, args...)
+		if err != nil {
+			return nil, err
+		}
+		enrollments = append(enrollments, found...)
+	}
+	slices.SortStableFunc(enrollments, func(a, b domain.Enrollment) int {
+		return cmp.Or(cmp.Compare(a.ClassID, b.ClassID), cmp.Compare(a.UserID, b.UserID))
+	})
+	return enrollments, nil
+}
+
+// findRoster lists the enrollments matching where, each with its user.
+func (r *enrollmentRepositoryImpl) findRoster(where string, args ...any) ([]domain.Enrollment, error) {
+	rows, err := r.db.Query(`
+        SELECT e.enrollment_id, e.class_id, e.user_id, e.role, e.status, e.enrolled_at, e.dropped_at,
+               u.user_id, u.email, u.nome, u.birth_date, u.sex, u.telephone, u.profile_id
+        FROM enrollments e
+        JOIN users u ON u.user_id = e.user_id
+        WHERE `+where+`
+        ORDER BY e.class_id, e.user_id
+    `, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var enrollments []domain.Enrollment
+	for rows.Next() {
+		var e domain.Enrollment
+		var u domain.User
+		if err := rows.Scan(&e.EnrollmentID, &e.ClassID, &e.UserID, &e.Role, &e.Status, &e.EnrolledAt, &e.DroppedAt,
+			&u.ID, &u.Email, &u.Nome, &u.BirthDate, &u.Sex, &u.Telephone, &u.ProfileID); err != nil {
+			return nil, err
+		}
+		e.User = &u
+		enrollments = append(enrollments, e)
+	}
+	return enrollments, rows.Err()
+}
+
+func (r *enrollmentRepositoryImpl) CountStudents(classIDs []uint) (map[uint]int, error) {
+	counts := make(map[uint]int)
+	for _, batch := range chunks(classIDs) {
+		in, args := inClause(batch)
+		rows, err := r.db.Query(
+			"SELECT class_id, COUNT(*) FROM enrollments WHERE class_id IN "+in+" AND role = ? AND status = ? GROUP BY class_id",
+			append(args, domain.EnrollmentRoleStudent, domain.EnrollmentActive)...,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var classID uint
+			var n int
+			if err := rows.Scan(&classID, &n); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			counts[classID] = n
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return counts, nil
+}
+
+func (r *enrollmentRepositoryImpl) FindByUser(userID uint) ([]domain.Enrollment, error) {
+	rows, err := r.db.Query(
+		"SELECT enrollment_id, class_id, user_id, role, status, enrolled_at, dropped_at FROM enrollments WHERE user_id = ? ORDER BY class_id",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var enrollments []domain.Enrollment
+	for rows.Next() {
+		var e domain.Enrollment
+		if err := rows.Scan(&e.EnrollmentID, &e.ClassID, &e.UserID, &e.Role, &e.Status, &e.EnrolledAt, &e.DroppedAt); err != nil {
+			return nil, err
+		}
+		enrollments = append(enrollments, e)
+	}
+	return enrollments, rows.Err()
+}
+
+// utcTime stores optional times in UTC, like the required ones, and NULL
+// when unset.
+func utcTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
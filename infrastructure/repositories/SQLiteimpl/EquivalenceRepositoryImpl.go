@@ -0,0 +1,120 @@
+package sqliteImpl
+
+import (
+	"cmp"
+	"database/sql"
+	"slices"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type equivalenceRepositoryImpl struct {
+	db DBTX
+}
+
+func NewEquivalenceRepository(db DBTX) repositories.EquivalenceRepository {
+	return &equivalenceRepositoryImpl{db}
+}
+
+func (r *equivalenceRepositoryImpl) Create(equivalence *domain.Equivalence) error {
+	res, err := r.db.Exec("INSERT INTO discipline_equivalences (target_id) VALUES (?)", equivalence.TargetID)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	equivalence.ID = uint(id)
+	for _, sourceID := range equivalence.SourceIDs {
+		if _, err := r.db.Exec(
+			"INSERT INTO discipline_equivalence_sources (equivalence_id, discipline_id) VALUES (?, ?)",
+			equivalence.ID, sourceID,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *equivalenceRepositoryImpl) FindByID(id uint) (*domain.Equivalence, error) {
+	equivalences, err := r.find("e.equivalence_id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(equivalences) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return &equivalences[0], nil
+}
+
+func (r *equivalenceRepositoryImpl) FindAll(page domain.Page) ([]domain.Equivalence, error) {
+	// Page over equivalences, not over the joined source rows
+	limit, offset := pageArgs(page)
+	return r.find(`e.equivalence_id IN (
+            SELECT equivalence_id FROM discipline_equivalences ORDER BY equivalence_id LIMIT ? OFFSET ?
+        )`, limit, offset)
+}
+
+func (r *equivalenceRepositoryImpl) FindByDiscipline(disciplineID uint, page domain.Page) ([]domain.Equivalence, error) {
+	limit, offset := pageArgs(page)
+	return r.find(`e.equivalence_id IN (
+            SELECT equivalence_id FROM discipline_equivalences
+            WHERE target_id = ? OR equivalence_id IN (
+                SELECT equivalence_id FROM discipline_equivalence_sources WHERE discipline_id = ?
+            )
+            ORDER BY equivalence_id LIMIT ? OFFSET ?
+        )`, disciplineID, disciplineID, limit, offset)
+}
+
+func (r *equivalenceRepositoryImpl) FindByTargets(targetIDs []uint) ([]domain.Equivalence, error) {
+	var equivalences []domain.Equivalence
+	for _, batch := range chunks(targetIDs) {
+		in, args := inClause(batch)
+		found, err := r.find("e.target_id IN "+in, args...)
+		if err != nil {
+			return nil, err
+		}
+		equivalences = append(equivalences, found...)
+	}
+	slices.SortFunc(equivalences, func(a, b domain.Equivalence) int { return cmp.Compare(a.ID, b.ID) })
+	return equivalences, nil
+}
+
+func (r *equivalenceRepositoryImpl) Delete(id uint) error {
+	if _, err := r.db.Exec("DELETE FROM discipline_equivalence_sources WHERE equivalence_id = ?", id); err != nil {
+		return err
+	}
+	_, err := r.db.Exec("DELETE FROM discipline_equivalences WHERE equivalence_id = ?", id)
+	return err
+}
+
+// find loads the equivalences matching where, one row per source.
+func (r *equivalenceRepositoryImpl) find(where string, args ...any) ([]domain.Equivalence, error) {
+	rows, err := r.db.Query(`
+        SELECT e.equivalence_id, e.target_id, s.discipline_id
+        FROM discipline_equivalences e
+        JOIN discipline_equivalence_sources s ON s.equivalence_id = e.equivalence_id
+        WHERE `+where+`
+        ORDER BY e.equivalence_id, s.discipline_id
+    `, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var equivalences []domain.Equivalence
+	for rows.Next() {
+		var id, targetID, sourceID uint
+		if err := rows.Scan(&id, &targetID, &sourceID); err != nil {
+			return nil, err
+		}
+		if n := len(equivalences); n == 0 || equivalences[n-1].ID != id {
+			equivalences = append(equivalences, domain.Equivalence{ID: id, TargetID: targetID})
+		}
+		last := &equivalences[len(equivalences)-1]
+		last.SourceIDs = append(last.SourceIDs, sourceID)
+	}
+	return equivalences, rows.Err()
+}
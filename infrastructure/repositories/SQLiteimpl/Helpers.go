@@ -0,0 +1,82 @@
+package sqliteImpl
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sarc/core/domain"
+
+	"github.com/lib/pq"
+)
+
+// SQLite has no array type, so text arrays are stored as JSON documents.
+// A nil array is stored as NULL and an empty one as "[]", which keeps the
+// nil/empty distinction the Postgres backend has.
+func arrayValue(a pq.StringArray) any {
+	if a == nil {
+		return nil
+	}
+	data, _ := json.Marshal([]string(a))
+	return string(data)
+}
+
+// jsonArray scans a JSON array column into a pq.StringArray.
+type jsonArray struct {
+	target *pq.StringArray
+}
+
+func (a jsonArray) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*a.target = nil
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("cannot scan %T into a text array", src)
+	}
+	var out []string
+	if err := json.Unmarshal(data, &out); err != nil {
+		return err
+	}
+	*a.target = out
+	return nil
+}
+
+// batchSize keeps IN lists well below SQLite's bound parameter limit.
+const batchSize = 500
+
+// inClause returns "(?, ?, ...)" and the matching arguments for ids.
+func inClause(ids []uint) (string, []any) {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return "(" + strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ") + ")", args
+}
+
+// chunks splits ids into slices of at most batchSize.
+func chunks(ids []uint) [][]uint {
+	var out [][]uint
+	for len(ids) > batchSize {
+		out = append(out, ids[:batchSize])
+		ids = ids[batchSize:]
+	}
+	if len(ids) > 0 {
+		out = append(out, ids)
+	}
+	return out
+}
+
+// pageArgs returns the LIMIT and OFFSET arguments for page. SQLite reads a
+// negative LIMIT as no limit.
+func pageArgs(page domain.Page) (int, int) {
+	if page.Limit <= 0 {
+		return -1, page.Offset
+	}
+	return page.Limit, page.Offset
+}
@@ -0,0 +1,173 @@
+package sqliteImpl
+
+import (
+	"cmp"
+	"slices"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type lectureRepositoryImpl struct {
+	db DBTX
+}
+
+func NewLectureRepository(db DBTX) repositories.LectureRepository {
+	return &lectureRepositoryImpl{db}
+}
+
+func (r *lectureRepositoryImpl) Create(lecture *domain.Lecture) error {
+	res, err := r.db.Exec(
+		"INSERT INTO lectures (class_id, room_id, date, start_time, end_time, content) VALUES (?, ?, ?, ?, ?, ?)",
+		lecture.ClassID, lecture.RoomID, lecture.Date, lecture.StartTime, lecture.EndTime, arrayValue(lecture.Content),
+	)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	lecture.LectureID = uint(id)
+	return err
+}
+
+func (r *lectureRepositoryImpl) FindAll(page domain.Page) ([]domain.Lecture, error) {
+	var lectures []domain.Lecture
+	err := r.stream(page, func(l domain.Lecture) error {
+		lectures = append(lectures, l)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lectures, nil
+}
+
+func (r *lectureRepositoryImpl) StreamAll(fn func(domain.Lecture) error) error {
+	return r.stream(domain.Page{}, fn)
+}
+
+// stream calls fn for each row of page, in ID order.
+func (r *lectureRepositoryImpl) stream(page domain.Page, fn func(domain.Lecture) error) error {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT lecture_id, class_id, room_id, date, start_time, end_time, content FROM lectures ORDER BY lecture_id LIMIT ? OFFSET ?", limit, offset)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l domain.Lecture
+		if err := rows.Scan(&l.LectureID, &l.ClassID, &l.RoomID, &l.Date, &l.StartTime, &l.EndTime, jsonArray{&l.Content}); err != nil {
+			return err
+		}
+		if err := fn(l); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *lectureRepositoryImpl) FindByID(id uint) (*domain.Lecture, error) {
+	row := r.db.QueryRow("SELECT lecture_id, class_id, room_id, date, start_time, end_time, content FROM lectures WHERE lecture_id = ?", id)
+	var l domain.Lecture
+	if err := row.Scan(&l.LectureID, &l.ClassID, &l.RoomID, &l.Date, &l.StartTime, &l.EndTime, jsonArray{&l.Content}); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (r *lectureRepositoryImpl) FindByIDs(ids []uint) ([]domain.Lecture, error) {
+	var lectures []domain.Lecture
+	for _, batch := range chunks(ids) {
+		in, args := inClause(batch)
+		rows, err := r.db.Query("SELECT lecture_id, class_id, room_id, date, start_time, end_time, content FROM lectures WHERE lecture_id IN "+in+" ORDER BY lecture_id", args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var l domain.Lecture
+			if err := rows.Scan(&l.LectureID, &l.ClassID, &l.RoomID, &l.Date, &l.StartTime, &l.EndTime, jsonArray{&l.Content}); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			lectures = append(lectures, l)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return lectures, nil
+}
+
+func (r *lectureRepositoryImpl) FindByClass(classID uint, page domain.Page) ([]domain.Lecture, error) {
+	return r.findBy("class_id", classID, page)
+}
+
+func (r *lectureRepositoryImpl) FindByRoom(roomID uint, page domain.Page) ([]domain.Lecture, error) {
+	return r.findBy("room_id", roomID, page)
+}
+
+func (r *lectureRepositoryImpl) FindBetween(from, to string) ([]domain.Lecture, error) {
+	return r.find("WHERE date BETWEEN ? AND ? ORDER BY date, lecture_id", from, to)
+}
+
+func (r *lectureRepositoryImpl) FindByClasses(classIDs []uint, from, to string) ([]domain.Lecture, error) {
+	from, to = dateBounds(from, to)
+	var lectures []domain.Lecture
+	for _, batch := range chunks(classIDs) {
+		in, args := inClause(batch)
+		found, err := r.find("WHERE class_id IN "+in+" AND date BETWEEN ? AND ? ORDER BY date, lecture_id", append(args, from, to)...)
+		if err != nil {
+			return nil, err
+		}
+		lectures = append(lectures, found...)
+	}
+	slices.SortStableFunc(lectures, func(a, b domain.Lecture) int {
+		return cmp.Or(cmp.Compare(a.Date, b.Date), cmp.Compare(a.LectureID, b.LectureID))
+	})
+	return lectures, nil
+}
+
+// findBy lists the lectures whose column equals id; column is never user input.
+func (r *lectureRepositoryImpl) findBy(column string, id uint, page domain.Page) ([]domain.Lecture, error) {
+	limit, offset := pageArgs(page)
+	return r.find("WHERE "+column+" = ? ORDER BY date, lecture_id LIMIT ? OFFSET ?", id, limit, offset)
+}
+
+// find lists the lectures selected by clause.
+func (r *lectureRepositoryImpl) find(clause string, args ...any) ([]domain.Lecture, error) {
+	rows, err := r.db.Query("SELECT lecture_id, class_id, room_id, date, start_time, end_time, content FROM lectures "+clause, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lectures []domain.Lecture
+	for rows.Next() {
+		var l domain.Lecture
+		if err := rows.Scan(&l.LectureID, &l.ClassID, &l.RoomID, &l.Date, &l.StartTime, &l.EndTime, jsonArray{&l.Content}); err != nil {
+			return nil, err
+		}
+		lectures = append(lectures, l)
+	}
+	return lectures, rows.Err()
+}
+
+func (r *lectureRepositoryImpl) Update(id uint, lecture *domain.Lecture) error {
+	_, err := r.db.Exec(
+		"UPDATE lectures SET class_id = ?, room_id = ?, date = ?, start_time = ?, end_time = ?, content = ? WHERE lecture_id = ?",
+		lecture.ClassID, lecture.RoomID, lecture.Date, lecture.StartTime, lecture.EndTime, arrayValue(lecture.Content), id,
+	)
+	return err
+}
+
+func (r *lectureRepositoryImpl) Delete(id uint) error {
+	_, err := r.db.Exec("DELETE FROM lectures WHERE lecture_id = ?", id)
+	return err
+}
+
+// dateBounds fills in the empty ends of a date range with dates no lecture
+// falls outside of.
+func dateBounds(from, to string) (string, string) {
+	return cmp.Or(from, "0001-01-01"), cmp.Or(to, "9999-12-31")
+}
@@ -0,0 +1,97 @@
+package sqliteImpl
+
+import (
+	"encoding/json"
+	"time"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type presenceRepositoryImpl struct {
+	db DBTX
+}
+
+func NewPresenceRepository(db DBTX) repositories.PresenceRepository {
+	return &presenceRepositoryImpl{db}
+}
+
+// Mark inserts every user in a single statement, reading the IDs from a JSON
+// array, so a bad user ID rejects the whole roll call. The WHERE clause is
+// required by SQLite's parser before ON CONFLICT in an INSERT ... SELECT.
+func (r *presenceRepositoryImpl) Mark(lectureID uint, userIDs []uint, method domain.PresenceMethod, at time.Time) (int, error) {
+	ids, err := json.Marshal(userIDs)
+	if err != nil {
+		return 0, err
+	}
+	res, err := r.db.Exec(`
+        INSERT INTO lecture_presence (lecture_id, user_id, method, marked_at)
+        SELECT ?, value, ?, ? FROM json_each(?) WHERE true
+        ON CONFLICT (lecture_id, user_id) DO NOTHING
+    `, lectureID, method, at.UTC(), string(ids))
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (r *presenceRepositoryImpl) Unmark(lectureID uint, userID uint) error {
+	_, err := r.db.Exec("DELETE FROM lecture_presence WHERE lecture_id = ? AND user_id = ?", lectureID, userID)
+	return err
+}
+
+func (r *presenceRepositoryImpl) FindByLectures(lectureIDs []uint) ([]domain.Presence, error) {
+	var presence []domain.Presence
+	for _, batch := range chunks(lectureIDs) {
+		in, args := inClause(batch)
+		rows, err := r.db.Query(`
+            SELECT p.lecture_id, p.user_id, p.method, p.marked_at,
+                   u.user_id, u.email, u.nome, u.birth_date, u.sex, u.telephone, u.profile_id
+            FROM lecture_presence p
+            JOIN users u ON u.user_id = p.user_id
+            WHERE p.lecture_id IN `+in+`
+            ORDER BY p.lecture_id, p.marked_at, p.user_id
+        `, args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var p domain.Presence
+			var u domain.User
+			if err := rows.Scan(&p.LectureID, &p.UserID, &p.Method, &p.MarkedAt,
+				&u.ID, &u.Email, &u.Nome, &u.BirthDate, &u.Sex, &u.Telephone, &u.ProfileID); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			p.User = &u
+			presence = append(presence, p)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return presence, nil
+}
+
+func (r *presenceRepositoryImpl) FindByUser(userID uint) ([]domain.Presence, error) {
+	rows, err := r.db.Query(
+		"SELECT lecture_id, user_id, method, marked_at FROM lecture_presence WHERE user_id = ? ORDER BY lecture_id",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var presence []domain.Presence
+	for rows.Next() {
+		var p domain.Presence
+		if err := rows.Scan(&p.LectureID, &p.UserID, &p.Method, &p.MarkedAt); err != nil {
+			return nil, err
+		}
+		presence = append(presence, p)
+	}
+	return presence, rows.Err()
+}
@@ -0,0 +1,62 @@
+package sqliteImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type profileRepositoryImpl struct {
+	db DBTX
+}
+
+func NewProfileRepository(db DBTX) repositories.ProfileRepository {
+	return &profileRepositoryImpl{db}
+}
+
+func (r *profileRepositoryImpl) Create(profile *domain.Profile) error {
+	res, err := r.db.Exec("INSERT INTO profiles (role, can_teach) VALUES (?, ?)", profile.Role, profile.CanTeach)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	profile.ID = uint(id)
+	return err
+}
+
+func (r *profileRepositoryImpl) FindAll(page domain.Page) ([]domain.Profile, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT profile_id, role, can_teach FROM profiles ORDER BY profile_id LIMIT ? OFFSET ?", limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var profiles []domain.Profile
+	for rows.Next() {
+		var p domain.Profile
+		if err := rows.Scan(&p.ID, &p.Role, &p.CanTeach); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+	return profiles, nil
+}
+
+func (r *profileRepositoryImpl) FindByID(id uint) (*domain.Profile, error) {
+	row := r.db.QueryRow("SELECT profile_id, role, can_teach FROM profiles WHERE profile_id = ?", id)
+	var p domain.Profile
+	if err := row.Scan(&p.ID, &p.Role, &p.CanTeach); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *profileRepositoryImpl) Update(id uint, profile *domain.Profile) error {
+	_, err := r.db.Exec("UPDATE profiles SET role = ?, can_teach = ? WHERE profile_id = ?", profile.Role, profile.CanTeach, id)
+	return err
+}
+
+func (r *profileRepositoryImpl) Delete(id uint) error {
+	_, err := r.db.Exec("DELETE FROM profiles WHERE profile_id = ?", id)
+	return err
+}
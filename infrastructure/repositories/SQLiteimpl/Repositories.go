@@ -0,0 +1,30 @@
+package sqliteImpl
+
+import (
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+// NewRepositories returns the SQLite implementation of every repository.
+func NewRepositories(db DBTX) repositories.Repositories {
+	return repositories.Repositories{
+		Profile:         NewProfileRepository(db),
+		User:            NewUserRepository(db),
+		Building:        NewBuildingRepository(db),
+		Room:            NewRoomRepository(db),
+		Discipline:      NewDisciplineRepository(db),
+		Curriculum:      NewCurriculumRepository(db),
+		Class:           NewClassRepository(db),
+		Lecture:         NewLectureRepository(db),
+		ResourceType:    NewResourceTypeRepository(db),
+		Resource:        NewResourceRepository(db),
+		Reservation:     NewReservationRepository(db),
+		Search:          NewSearchRepository(db),
+		Presence:        NewPresenceRepository(db),
+		CheckInClaim:    NewCheckInClaimRepository(db),
+		AttendanceAlert: NewAttendanceAlertRepository(db),
+		Enrollment:      NewEnrollmentRepository(db),
+		Equivalence:     NewEquivalenceRepository(db),
+		Assessment:      NewAssessmentRepository(db),
+		Record:          NewAcademicRecordRepository(db),
+	}
+}
@@ -0,0 +1,225 @@
+package sqliteImpl
+
+import (
+	"database/sql"
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type reservationRepositoryImpl struct {
+	db DBTX
+}
+
+func NewReservationRepository(db DBTX) repositories.ReservationRepository {
+	return &reservationRepositoryImpl{db}
+}
+
+func (r *reservationRepositoryImpl) Create(reservation *domain.Reservation) error {
+	res, err := r.db.Exec(
+		"INSERT INTO reservations (lecture_id, observation) VALUES (?, ?)",
+		reservation.LectureID, reservation.Observation,
+	)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	reservation.ReservationID = uint(id)
+	return err
+}
+
+func (r *reservationRepositoryImpl) FindByID(id uint) (*domain.Reservation, error) {
+	row := r.db.QueryRow("SELECT reservation_id, lecture_id, observation FROM reservations WHERE reservation_id = ?", id)
+	var rsv domain.Reservation
+	if err := row.Scan(&rsv.ReservationID, &rsv.LectureID, &rsv.Observation); err != nil {
+		return nil, err
+	}
+
+	resources, err := r.resourcesByReservation([]uint{id})
+	if err != nil {
+		return nil, err
+	}
+	rsv.Resources = resources[id]
+
+	return &rsv, nil
+}
+
+func (r *reservationRepositoryImpl) FindAll(page domain.Page) ([]domain.Reservation, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT reservation_id, lecture_id, observation FROM reservations ORDER BY reservation_id LIMIT ? OFFSET ?", limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	reservations, err := scanReservations(rows)
+	if err != nil {
+		return nil, err
+	}
+	return r.attachResources(reservations)
+}
+
+func (r *reservationRepositoryImpl) FindByLecture(lectureID uint, page domain.Page) ([]domain.Reservation, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query(
+		"SELECT reservation_id, lecture_id, observation FROM reservations WHERE lecture_id = ? ORDER BY reservation_id LIMIT ? OFFSET ?",
+		lectureID, limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	reservations, err := scanReservations(rows)
+	if err != nil {
+		return nil, err
+	}
+	return r.attachResources(reservations)
+}
+
+func (r *reservationRepositoryImpl) FindByLectures(lectureIDs []uint) ([]domain.Reservation, error) {
+	var reservations []domain.Reservation
+	for _, batch := range chunks(lectureIDs) {
+		in, args := inClause(batch)
+		rows, err := r.db.Query("SELECT reservation_id, lecture_id, observation FROM reservations WHERE lecture_id IN "+in+" ORDER BY lecture_id, reservation_id", args...)
+		if err != nil {
+			return nil, err
+		}
+		found, err := scanReservations(rows)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, found...)
+	}
+	return r.attachResources(reservations)
+}
+
+// scanReservations reads every row of a reservations query, without the
+// resources, and closes rows before the connection is needed again.
+func scanReservations(rows *sql.Rows) ([]domain.Reservation, error) {
+	defer rows.Close()
+	var reservations []domain.Reservation
+	for rows.Next() {
+		var rsv domain.Reservation
+		if err := rows.Scan(&rsv.ReservationID, &rsv.LectureID, &rsv.Observation); err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, rsv)
+	}
+	return reservations, rows.Err()
+}
+
+// attachResources fetches the resources of every reservation in a single
+// query.
+func (r *reservationRepositoryImpl) attachResources(reservations []domain.Reservation) ([]domain.Reservation, error) {
+	ids := make([]uint, len(reservations))
+	for i, rsv := range reservations {
+		ids[i] = rsv.ReservationID
+	}
+	resources, err := r.resourcesByReservation(ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range reservations {
+		reservations[i].Resources = resources[reservations[i].ReservationID]
+	}
+	return reservations, nil
+}
+
+// StreamAll reads reservations and their resources in one ordered join,
+// emitting each reservation once its last resource row has been read.
+func (r *reservationRepositoryImpl) StreamAll(fn func(domain.Reservation) error) error {
+	rows, err := r.db.Query(`
+        SELECT rsv.reservation_id, rsv.lecture_id, rsv.observation,
+               res.resource_id, COALESCE(res.description, ''), COALESCE(res.status, ''),
+               res.characteristics, COALESCE(res.resource_type_id, 0)
+        FROM reservations rsv
+        LEFT JOIN reservation_resources rr ON rr.reservation_id = rsv.reservation_id
+        LEFT JOIN resources res ON res.resource_id = rr.resource_id
+        ORDER BY rsv.reservation_id, res.resource_id
+    `)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var current *domain.Reservation
+	for rows.Next() {
+		var rsv domain.Reservation
+		var resourceID sql.NullInt64
+		var res domain.Resource
+		if err := rows.Scan(&rsv.ReservationID, &rsv.LectureID, &rsv.Observation,
+			&resourceID, &res.Description, &res.Status, jsonArray{&res.Characteristics}, &res.ResourceTypeID); err != nil {
+			return err
+		}
+		if current != nil && current.ReservationID != rsv.ReservationID {
+			if err := fn(*current); err != nil {
+				return err
+			}
+			current = nil
+		}
+		if current == nil {
+			current = &rsv
+		}
+		if resourceID.Valid {
+			res.ResourceID = uint(resourceID.Int64)
+			current.Resources = append(current.Resources, res)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if current != nil {
+		return fn(*current)
+	}
+	return nil
+}
+
+// resourcesByReservation loads the resources of all given reservations with
+// one query per batch of IDs, keyed by reservation ID.
+func (r *reservationRepositoryImpl) resourcesByReservation(ids []uint) (map[uint][]domain.Resource, error) {
+	result := make(map[uint][]domain.Resource, len(ids))
+	for _, batch := range chunks(ids) {
+		in, args := inClause(batch)
+		rows, err := r.db.Query(`
+            SELECT rr.reservation_id, res.resource_id, res.description, res.status, res.characteristics, res.resource_type_id
+            FROM resources res
+            JOIN reservation_resources rr ON rr.resource_id = res.resource_id
+            WHERE rr.reservation_id IN `+in+`
+            ORDER BY rr.reservation_id, res.resource_id
+        `, args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var reservationID uint
+			var res domain.Resource
+			if err := rows.Scan(&reservationID, &res.ResourceID, &res.Description, &res.Status, jsonArray{&res.Characteristics}, &res.ResourceTypeID); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			result[reservationID] = append(result[reservationID], res)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (r *reservationRepositoryImpl) Update(id uint, reservation *domain.Reservation) error {
+	_, err := r.db.Exec(
+		"UPDATE reservations SET lecture_id = ?, observation = ? WHERE reservation_id = ?",
+		reservation.LectureID, reservation.Observation, id,
+	)
+	return err
+}
+
+func (r *reservationRepositoryImpl) Delete(id uint) error {
+	_, err := r.db.Exec("DELETE FROM reservations WHERE reservation_id = ?", id)
+	return err
+}
+
+func (r *reservationRepositoryImpl) AddResourceToReservation(reservationID uint, resourceID uint) error {
+	_, err := r.db.Exec(
+		"INSERT INTO reservation_resources (reservation_id, resource_id) VALUES (?, ?)",
+		reservationID, resourceID,
+	)
+	return err
+}
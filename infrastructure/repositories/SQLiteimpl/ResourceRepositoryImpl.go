@@ -0,0 +1,130 @@
+package sqliteImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type resourceRepositoryImpl struct {
+	db DBTX
+}
+
+func NewResourceRepository(db DBTX) repositories.ResourceRepository {
+	return &resourceRepositoryImpl{db}
+}
+
+func (r *resourceRepositoryImpl) Create(resource *domain.Resource) error {
+	res, err := r.db.Exec(
+		"INSERT INTO resources (description, status, characteristics, resource_type_id) VALUES (?, ?, ?, ?)",
+		resource.Description, resource.Status, arrayValue(resource.Characteristics), resource.ResourceTypeID,
+	)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	resource.ResourceID = uint(id)
+	return err
+}
+
+func (r *resourceRepositoryImpl) FindAll(page domain.Page) ([]domain.Resource, error) {
+	var resources []domain.Resource
+	err := r.stream(page, func(res domain.Resource) error {
+		resources = append(resources, res)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+func (r *resourceRepositoryImpl) StreamAll(fn func(domain.Resource) error) error {
+	return r.stream(domain.Page{}, fn)
+}
+
+// stream calls fn for each row of page, in ID order.
+func (r *resourceRepositoryImpl) stream(page domain.Page, fn func(domain.Resource) error) error {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query(`
+        SELECT res.resource_id, res.description, res.status, res.characteristics, res.resource_type_id,
+               rt.resource_type_id, rt.name
+        FROM resources res
+        LEFT JOIN resource_types rt ON res.resource_type_id = rt.resource_type_id
+        ORDER BY res.resource_id
+        LIMIT ? OFFSET ?
+    `, limit, offset)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var res domain.Resource
+		var rt domain.ResourceType
+		if err := rows.Scan(&res.ResourceID, &res.Description, &res.Status, jsonArray{&res.Characteristics}, &res.ResourceTypeID, &rt.ResourceTypeID, &rt.Name); err != nil {
+			return err
+		}
+		res.ResourceType = &rt
+		if err := fn(res); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *resourceRepositoryImpl) FindByResourceType(resourceTypeID uint) ([]domain.Resource, error) {
+	rows, err := r.db.Query(`
+        SELECT res.resource_id, res.description, res.status, res.characteristics, res.resource_type_id,
+               rt.resource_type_id, rt.name
+        FROM resources res
+        JOIN resource_types rt ON res.resource_type_id = rt.resource_type_id
+        WHERE res.resource_type_id = ?
+        ORDER BY res.resource_id
+    `, resourceTypeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var resources []domain.Resource
+	for rows.Next() {
+		var res domain.Resource
+		var rt domain.ResourceType
+		if err := rows.Scan(&res.ResourceID, &res.Description, &res.Status, jsonArray{&res.Characteristics}, &res.ResourceTypeID, &rt.ResourceTypeID, &rt.Name); err != nil {
+			return nil, err
+		}
+		res.ResourceType = &rt
+		resources = append(resources, res)
+	}
+	return resources, rows.Err()
+}
+
+func (r *resourceRepositoryImpl) FindByID(id uint) (*domain.Resource, error) {
+	row := r.db.QueryRow(`
+        SELECT res.resource_id, res.description, res.status, res.characteristics, res.resource_type_id,
+               rt.resource_type_id, rt.name
+        FROM resources res
+        LEFT JOIN resource_types rt ON res.resource_type_id = rt.resource_type_id
+        WHERE res.resource_id = ?
+    `, id)
+	var res domain.Resource
+	var rt domain.ResourceType
+	if err := row.Scan(&res.ResourceID, &res.Description, &res.Status, jsonArray{&res.Characteristics}, &res.ResourceTypeID, &rt.ResourceTypeID, &rt.Name); err != nil {
+		return nil, err
+	}
+	res.ResourceType = &rt
+	return &res, nil
+}
+
+func (r *resourceRepositoryImpl) Update(id uint, resource *domain.Resource) error {
+	_, err := r.db.Exec(
+		"UPDATE resources SET description = ?, status = ?, characteristics = ?, resource_type_id = ? WHERE resource_id = ?",
+		resource.Description, resource.Status, arrayValue(resource.Characteristics), resource.ResourceTypeID, id,
+	)
+	return err
+}
+
+func (r *resourceRepositoryImpl) Delete(id uint) error {
+	_, err := r.db.Exec("DELETE FROM resources WHERE resource_id = ?", id)
+	return err
+}
@@ -0,0 +1,86 @@
+package sqliteImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type resourceTypeRepositoryImpl struct {
+	db DBTX
+}
+
+func NewResourceTypeRepository(db DBTX) repositories.ResourceTypeRepository {
+	return &resourceTypeRepositoryImpl{db}
+}
+
+func (r *resourceTypeRepositoryImpl) Create(resourceType *domain.ResourceType) error {
+	res, err := r.db.Exec("INSERT INTO resource_types (name) VALUES (?)", resourceType.Name)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	resourceType.ResourceTypeID = uint(id)
+	return err
+}
+
+func (r *resourceTypeRepositoryImpl) FindAll(page domain.Page) ([]domain.ResourceType, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT resource_type_id, name FROM resource_types ORDER BY resource_type_id LIMIT ? OFFSET ?", limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var types []domain.ResourceType
+	for rows.Next() {
+		var t domain.ResourceType
+		if err := rows.Scan(&t.ResourceTypeID, &t.Name); err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+func (r *resourceTypeRepositoryImpl) FindByID(id uint) (*domain.ResourceType, error) {
+	row := r.db.QueryRow("SELECT resource_type_id, name FROM resource_types WHERE resource_type_id = ?", id)
+	var t domain.ResourceType
+	if err := row.Scan(&t.ResourceTypeID, &t.Name); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *resourceTypeRepositoryImpl) FindByIDs(ids []uint) ([]domain.ResourceType, error) {
+	var types []domain.ResourceType
+	for _, batch := range chunks(ids) {
+		in, args := inClause(batch)
+		rows, err := r.db.Query("SELECT resource_type_id, name FROM resource_types WHERE resource_type_id IN "+in+" ORDER BY resource_type_id", args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var t domain.ResourceType
+			if err := rows.Scan(&t.ResourceTypeID, &t.Name); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			types = append(types, t)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return types, nil
+}
+
+func (r *resourceTypeRepositoryImpl) Update(id uint, resourceType *domain.ResourceType) error {
+	_, err := r.db.Exec("UPDATE resource_types SET name = ? WHERE resource_type_id = ?", resourceType.Name, id)
+	return err
+}
+
+func (r *resourceTypeRepositoryImpl) Delete(id uint) error {
+	_, err := r.db.Exec("DELETE FROM resource_types WHERE resource_type_id = ?", id)
+	return err
+}
@@ -0,0 +1,132 @@
+package sqliteImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type roomRepositoryImpl struct {
+	db DBTX
+}
+
+func NewRoomRepository(db DBTX) repositories.RoomRepository {
+	return &roomRepositoryImpl{db}
+}
+
+func (r *roomRepositoryImpl) Create(room *domain.Room) error {
+	res, err := r.db.Exec(
+		"INSERT INTO rooms (room_number, building_id, room_capacity, floor) VALUES (?, ?, ?, ?)",
+		room.RoomNumber, room.BuildingID, room.RoomCapacity, room.Floor,
+	)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	room.RoomID = uint(id)
+	return err
+}
+
+func (r *roomRepositoryImpl) FindAll(page domain.Page) ([]domain.Room, error) {
+	var rooms []domain.Room
+	err := r.stream(page, func(rm domain.Room) error {
+		rooms = append(rooms, rm)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
+func (r *roomRepositoryImpl) StreamAll(fn func(domain.Room) error) error {
+	return r.stream(domain.Page{}, fn)
+}
+
+// stream calls fn for each row of page, in ID order.
+func (r *roomRepositoryImpl) stream(page domain.Page, fn func(domain.Room) error) error {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT room_id, room_number, building_id, room_capacity, floor FROM rooms ORDER BY room_id LIMIT ? OFFSET ?", limit, offset)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rm domain.Room
+		if err := rows.Scan(&rm.RoomID, &rm.RoomNumber, &rm.BuildingID, &rm.RoomCapacity, &rm.Floor); err != nil {
+			return err
+		}
+		if err := fn(rm); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *roomRepositoryImpl) FindByID(id uint) (*domain.Room, error) {
+	row := r.db.QueryRow("SELECT room_id, room_number, building_id, room_capacity, floor FROM rooms WHERE room_id = ?", id)
+	var rm domain.Room
+	if err := row.Scan(&rm.RoomID, &rm.RoomNumber, &rm.BuildingID, &rm.RoomCapacity, &rm.Floor); err != nil {
+		return nil, err
+	}
+	return &rm, nil
+}
+
+func (r *roomRepositoryImpl) FindByIDs(ids []uint) ([]domain.Room, error) {
+	var rooms []domain.Room
+	for _, batch := range chunks(ids) {
+		in, args := inClause(batch)
+		rows, err := r.db.Query("SELECT room_id, room_number, building_id, room_capacity, floor FROM rooms WHERE room_id IN "+in+" ORDER BY room_id", args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var rm domain.Room
+			if err := rows.Scan(&rm.RoomID, &rm.RoomNumber, &rm.BuildingID, &rm.RoomCapacity, &rm.Floor); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			rooms = append(rooms, rm)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return rooms, nil
+}
+
+func (r *roomRepositoryImpl) FindByBuilding(buildingID uint, page domain.Page) ([]domain.Room, error) {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query(
+		"SELECT room_id, room_number, building_id, room_capacity, floor FROM rooms WHERE building_id = ? ORDER BY room_id LIMIT ? OFFSET ?",
+		buildingID, limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rooms []domain.Room
+	for rows.Next() {
+		var rm domain.Room
+		if err := rows.Scan(&rm.RoomID, &rm.RoomNumber, &rm.BuildingID, &rm.RoomCapacity, &rm.Floor); err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, rm)
+	}
+	return rooms, rows.Err()
+}
+
+func (r *roomRepositoryImpl) Update(id uint, room *domain.Room) error {
+	_, err := r.db.Exec(
+		"UPDATE rooms SET room_number = ?, building_id = ?, room_capacity = ?, floor = ? WHERE room_id = ?",
+		room.RoomNumber, room.BuildingID, room.RoomCapacity, room.Floor, id,
+	)
+	return err
+}
+
+func (r *roomRepositoryImpl) Delete(id uint) error {
+	_, err := r.db.Exec("DELETE FROM rooms WHERE room_id = ?", id)
+	return err
+}
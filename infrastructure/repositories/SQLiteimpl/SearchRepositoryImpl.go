@@ -0,0 +1,137 @@
+package sqliteImpl
+
+import (
+	"strings"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+
+	"github.com/lib/pq"
+)
+
+// searchCandidates caps the rows read per type. Hits are ranked by the
+// service afterwards, so the cap is set well above any page size.
+const searchCandidates = 500
+
+type searchRepositoryImpl struct {
+	db DBTX
+}
+
+func NewSearchRepository(db DBTX) repositories.SearchRepository {
+	return &searchRepositoryImpl{db}
+}
+
+// Search matches each word with LIKE. SQLite only folds ASCII case, so
+// accented words must be typed with the case used in the data.
+func (r *searchRepositoryImpl) Search(q domain.SearchQuery) ([]domain.SearchHit, error) {
+	if len(q.Terms) == 0 {
+		return nil, nil
+	}
+	var hits []domain.SearchHit
+
+	where, args := likeAny("lower(coalesce(name, '') || ' ' || coalesce(program, '') || ' ' || coalesce(bibliography, ''))", q.Terms)
+	err := r.collect(&hits, `
+        SELECT discipline_id, coalesce(name, ''), coalesce(program, ''), bibliography
+        FROM disciplines WHERE `+where, args, func(scan func(...any) error) (domain.SearchHit, error) {
+		var d domain.Discipline
+		err := scan(&d.ID, &d.Name, &d.Program, jsonArray{&d.Bibliography})
+		return domain.SearchHit{Type: domain.SearchTypeDiscipline, ID: d.ID, Title: d.Name,
+			Text: domain.SearchText(d.Name, d.Program, strings.Join(d.Bibliography, "; "))}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	where, args = likeAny("lower(coalesce(description, '') || ' ' || coalesce(characteristics, ''))", q.Terms)
+	err = r.collect(&hits, `
+        SELECT resource_id, coalesce(description, ''), characteristics
+        FROM resources WHERE `+where, args, func(scan func(...any) error) (domain.SearchHit, error) {
+		var id uint
+		var description string
+		var characteristics pq.StringArray
+		err := scan(&id, &description, jsonArray{&characteristics})
+		return domain.SearchHit{Type: domain.SearchTypeResource, ID: id, Title: description,
+			Text: domain.SearchText(description, strings.Join(characteristics, "; "))}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	where, args = likeAny("lower(coalesce(building_name, '') || ' ' || coalesce(address, ''))", q.Terms)
+	err = r.collect(&hits, `
+        SELECT building_id, coalesce(building_name, ''), coalesce(address, '')
+        FROM buildings WHERE `+where, args, func(scan func(...any) error) (domain.SearchHit, error) {
+		var b domain.Building
+		err := scan(&b.BuildingID, &b.BuildingName, &b.Address)
+		return domain.SearchHit{Type: domain.SearchTypeBuilding, ID: b.BuildingID, Title: b.BuildingName,
+			Text: domain.SearchText(b.BuildingName, b.Address)}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	where, args = likeAny("lower(coalesce(b.building_name, '') || ' ' || coalesce(b.address, ''))", q.Terms)
+	numbers := strings.TrimSuffix(strings.Repeat("?, ", len(q.Terms)), ", ")
+	for _, term := range q.Terms {
+		args = append(args, term)
+	}
+	err = r.collect(&hits, `
+        SELECT r.room_id, coalesce(r.room_number, ''), coalesce(b.building_name, ''), coalesce(b.address, '')
+        FROM rooms r JOIN buildings b ON b.building_id = r.building_id
+        WHERE `+where+` OR lower(r.room_number) IN (`+numbers+`)`, args, func(scan func(...any) error) (domain.SearchHit, error) {
+		var id uint
+		var number, building, address string
+		err := scan(&id, &number, &building, &address)
+		return domain.SearchHit{Type: domain.SearchTypeRoom, ID: id, Title: strings.TrimSpace(building + " " + number),
+			Text: domain.SearchText(building, address, number)}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	where, args = likeAny("lower(coalesce(name, '') || ' ' || coalesce(description, ''))", q.Terms)
+	err = r.collect(&hits, `
+        SELECT class_id, coalesce(name, ''), coalesce(description, '')
+        FROM classes WHERE `+where, args, func(scan func(...any) error) (domain.SearchHit, error) {
+		var c domain.Class
+		err := scan(&c.ClassID, &c.Name, &c.Description)
+		return domain.SearchHit{Type: domain.SearchTypeClass, ID: c.ClassID, Title: c.Name,
+			Text: domain.SearchText(c.Name, c.Description)}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+// collect runs query, limited to searchCandidates rows, and appends a hit
+// per row built by read.
+func (r *searchRepositoryImpl) collect(hits *[]domain.SearchHit, query string, args []any, read func(scan func(...any) error) (domain.SearchHit, error)) error {
+	rows, err := r.db.Query(query+" LIMIT ?", append(args, searchCandidates)...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		hit, err := read(rows.Scan)
+		if err != nil {
+			return err
+		}
+		*hits = append(*hits, hit)
+	}
+	return rows.Err()
+}
+
+// likeAny returns a condition matching expr against any of the terms.
+func likeAny(expr string, terms []string) (string, []any) {
+	conditions := make([]string, len(terms))
+	args := make([]any, len(terms))
+	for i, term := range terms {
+		conditions[i] = expr + ` LIKE ? ESCAPE '\'`
+		args[i] = "%" + likeEscaper.Replace(term) + "%"
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
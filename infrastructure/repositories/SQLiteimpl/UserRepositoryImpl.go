@@ -0,0 +1,110 @@
+package sqliteImpl
+
+import (
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+type userRepositoryImpl struct {
+	db DBTX
+}
+
+func NewUserRepository(db DBTX) repositories.UserRepository {
+	return &userRepositoryImpl{db}
+}
+
+func (r *userRepositoryImpl) Create(user *domain.User) error {
+	res, err := r.db.Exec(
+		"INSERT INTO users (email, nome, birth_date, sex, telephone, profile_id) VALUES (?, ?, ?, ?, ?, ?)",
+		user.Email, user.Nome, user.BirthDate, user.Sex, user.Telephone, user.ProfileID,
+	)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	user.ID = uint(id)
+	return err
+}
+
+func (r *userRepositoryImpl) FindAll(page domain.Page) ([]domain.User, error) {
+	var users []domain.User
+	err := r.stream(page, func(u domain.User) error {
+		users = append(users, u)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *userRepositoryImpl) StreamAll(fn func(domain.User) error) error {
+	return r.stream(domain.Page{}, fn)
+}
+
+// stream calls fn for each row of page, in ID order.
+func (r *userRepositoryImpl) stream(page domain.Page, fn func(domain.User) error) error {
+	limit, offset := pageArgs(page)
+	rows, err := r.db.Query("SELECT user_id, email, nome, birth_date, sex, telephone, profile_id FROM users ORDER BY user_id LIMIT ? OFFSET ?", limit, offset)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u domain.User
+		if err := rows.Scan(&u.ID, &u.Email, &u.Nome, &u.BirthDate, &u.Sex, &u.Telephone, &u.ProfileID); err != nil {
+			return err
+		}
+		if err := fn(u); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *userRepositoryImpl) FindByID(id uint) (*domain.User, error) {
+	row := r.db.QueryRow("SELECT user_id, email, nome, birth_date, sex, telephone, profile_id FROM users WHERE user_id = ?", id)
+	var u domain.User
+	if err := row.Scan(&u.ID, &u.Email, &u.Nome, &u.BirthDate, &u.Sex, &u.Telephone, &u.ProfileID); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *userRepositoryImpl) FindByIDs(ids []uint) ([]domain.User, error) {
+	var users []domain.User
+	for _, batch := range chunks(ids) {
+		in, args := inClause(batch)
+		rows, err := r.db.Query("SELECT user_id, email, nome, birth_date, sex, telephone, profile_id FROM users WHERE user_id IN "+in+" ORDER BY user_id", args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var u domain.User
+			if err := rows.Scan(&u.ID, &u.Email, &u.Nome, &u.BirthDate, &u.Sex, &u.Telephone, &u.ProfileID); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			users = append(users, u)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return users, nil
+}
+
+func (r *userRepositoryImpl) Update(id uint, user *domain.User) error {
+	_, err := r.db.Exec(
+		"UPDATE users SET email = ?, nome = ?, birth_date = ?, sex = ?, telephone = ?, profile_id = ? WHERE user_id = ?",
+		user.Email, user.Nome, user.BirthDate, user.Sex, user.Telephone, user.ProfileID, id,
+	)
+	return err
+}
+
+func (r *userRepositoryImpl) Delete(id uint) error {
+	_, err := r.db.Exec("DELETE FROM users WHERE user_id = ?", id)
+	return err
+}
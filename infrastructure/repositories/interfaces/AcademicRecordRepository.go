@@ -0,0 +1,12 @@
+package repositories
+
+import "sarc/core/domain"
+
+type AcademicRecordRepository interface {
+	// Save stores the user's record in the class, replacing any previous
+	// one.
+	Save(record *domain.AcademicRecord) error
+	// FindByUser returns the user's records, each with its discipline,
+	// ordered by completion time and then by class.
+	FindByUser(userID uint) ([]domain.AcademicRecord, error)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import "sarc/core/domain"
+
+type AssessmentRepository interface {
+	Create(assessment *domain.Assessment) error
+	// Update saves the name and weight of assessment.ID.
+	Update(assessment *domain.Assessment) error
+	FindByID(id uint) (*domain.Assessment, error)
+	// FindByClass returns the class's assessments ordered by ID.
+	FindByClass(classID uint) ([]domain.Assessment, error)
+	// Delete removes the assessment and its grades, so it should run in a
+	// transaction.
+	Delete(id uint) error
+	// SetGrade saves the user's grade in the assessment, replacing any
+	// previous one.
+	SetGrade(grade *domain.Grade) error
+	// FindGradesByClass returns the grades of every assessment of the class,
+	// ordered by assessment and then by user.
+	FindGradesByClass(classID uint) ([]domain.Grade, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"time"
+
+	"sarc/core/domain"
+)
+
+type AttendanceAlertRepository interface {
+	// FindNotifiedStatuses returns the attendance status last notified for
+	// each user of the class, keyed by user ID.
+	FindNotifiedStatuses(classID uint) (map[uint]domain.AttendanceStatus, error)
+	// SaveNotifiedStatus records status as the last one notified for the
+	// user in the class.
+	SaveNotifiedStatus(classID uint, userID uint, status domain.AttendanceStatus, at time.Time) error
+}
@@ -4,8 +4,11 @@ import "sarc/core/domain"
 
 type BuildingRepository interface {
 	Create(building *domain.Building) error
-	FindAll() ([]domain.Building, error)
+	FindAll(page domain.Page) ([]domain.Building, error)
 	FindByID(id uint) (*domain.Building, error)
+	// FindByIDs loads the given buildings in one query, ordered by ID.
+	// IDs with no row are skipped.
+	FindByIDs(ids []uint) ([]domain.Building, error)
 	Update(id uint, building *domain.Building) error
 	Delete(id uint) error
 }
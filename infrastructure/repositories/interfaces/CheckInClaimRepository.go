@@ -0,0 +1,10 @@
+package repositories
+
+import "time"
+
+type CheckInClaimRepository interface {
+	// Claim records that the user checked in to the lecture with the token
+	// of window, and returns false if they already had. Claims are kept
+	// until expiresAt; those expired at now are dropped.
+	Claim(lectureID uint, userID uint, window int64, expiresAt time.Time, now time.Time) (bool, error)
+}
@@ -4,8 +4,13 @@ import "sarc/core/domain"
 
 type ClassRepository interface {
 	Create(class *domain.Class) error
-	FindAll() ([]domain.Class, error)
+	FindAll(page domain.Page) ([]domain.Class, error)
 	FindByID(id uint) (*domain.Class, error)
+	// FindByIDs loads the given classes in one query, ordered by ID.
+	// IDs with no row are skipped.
+	FindByIDs(ids []uint) ([]domain.Class, error)
+	// FindByDiscipline lists the classes of a discipline ordered by ID.
+	FindByDiscipline(disciplineID uint, page domain.Page) ([]domain.Class, error)
 	Update(id uint, class *domain.Class) error
 	Delete(id uint) error
 }
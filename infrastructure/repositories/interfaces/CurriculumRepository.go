@@ -4,9 +4,28 @@ import "sarc/core/domain"
 
 type CurriculumRepository interface {
 	Create(curriculum *domain.Curriculum) error
-	FindAll() ([]domain.Curriculum, error)
+	FindAll(page domain.Page) ([]domain.Curriculum, error)
 	FindByID(id uint) (*domain.Curriculum, error)
+	// FindByLineage returns every version of a curriculum ordered by version.
+	FindByLineage(lineageID uint) ([]domain.Curriculum, error)
+	// LockLineage keeps other transactions from adding versions to the
+	// lineage of a curriculum until the current transaction ends.
+	LockLineage(id uint) error
 	Update(id uint, curriculum *domain.Curriculum) error
 	Delete(id uint) error
-	AddDisciplineToCurriculum(curriculumID uint, disciplineID uint) error
+	// AddDisciplineToCurriculum links discipline.ID to the curriculum in
+	// discipline.Semester, as an elective if discipline.Elective is set.
+	// Linking a discipline twice changes nothing.
+	AddDisciplineToCurriculum(curriculumID uint, discipline domain.Discipline) error
+	// RemoveDisciplineFromCurriculum unlinks a discipline, along with the
+	// requisites to and from it in the curriculum.
+	RemoveDisciplineFromCurriculum(curriculumID uint, disciplineID uint) error
+	// PlaceDiscipline moves a discipline of the curriculum to another
+	// semester or changes whether it is elective.
+	PlaceDiscipline(curriculumID uint, discipline domain.Discipline) error
+	// FindRequisites returns the requisites of a curriculum ordered by
+	// discipline and requisite ID.
+	FindRequisites(curriculumID uint) ([]domain.Requisite, error)
+	AddRequisite(requisite *domain.Requisite) error
+	RemoveRequisite(curriculumID uint, disciplineID uint, requisiteID uint) error
 }
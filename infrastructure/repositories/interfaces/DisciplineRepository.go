@@ -4,8 +4,11 @@ import "sarc/core/domain"
 
 type DisciplineRepository interface {
 	Create(discipline *domain.Discipline) error
-	FindAll() ([]domain.Discipline, error)
+	FindAll(page domain.Page) ([]domain.Discipline, error)
 	FindByID(id uint) (*domain.Discipline, error)
+	// FindByIDs loads the given disciplines in one query, ordered by ID.
+	// IDs with no row are skipped.
+	FindByIDs(ids []uint) ([]domain.Discipline, error)
 	Update(id uint, discipline *domain.Discipline) error
 	Delete(id uint) error
 }
@@ -0,0 +1,24 @@
+package repositories
+
+import "sarc/core/domain"
+
+type EnrollmentRepository interface {
+	// Create fails if the user is already enrolled in the class.
+	Create(enrollment *domain.Enrollment) error
+	// Update saves the role, status and dates of enrollment.EnrollmentID.
+	Update(enrollment *domain.Enrollment) error
+	// Find returns the user's enrollment in the class.
+	Find(classID uint, userID uint) (*domain.Enrollment, error)
+	// FindByClass returns the class roster, each with its user, ordered by
+	// user ID.
+	FindByClass(classID uint) ([]domain.Enrollment, error)
+	// FindByClasses returns the rosters of the given classes in one query,
+	// each with its user, ordered by class, then user ID.
+	FindByClasses(classIDs []uint) ([]domain.Enrollment, error)
+	// FindByUser returns the user's enrollments ordered by class, without
+	// the user attached.
+	FindByUser(userID uint) ([]domain.Enrollment, error)
+	// CountStudents returns the number of active students of each class in
+	// one query. Classes with none are left out.
+	CountStudents(classIDs []uint) (map[uint]int, error)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import "sarc/core/domain"
+
+// EquivalenceRepository returns equivalences ordered by ID, each with its
+// source IDs in ascending order.
+type EquivalenceRepository interface {
+	// Create saves the equivalence and its sources, so it should run in a
+	// transaction.
+	Create(equivalence *domain.Equivalence) error
+	FindByID(id uint) (*domain.Equivalence, error)
+	FindAll(page domain.Page) ([]domain.Equivalence, error)
+	// FindByDiscipline returns the equivalences in which the discipline is
+	// the target or one of the sources.
+	FindByDiscipline(disciplineID uint, page domain.Page) ([]domain.Equivalence, error)
+	// FindByTargets returns the equivalences crediting any of targetIDs.
+	FindByTargets(targetIDs []uint) ([]domain.Equivalence, error)
+	// Delete removes the equivalence and its sources, so it should run in a
+	// transaction.
+	Delete(id uint) error
+}
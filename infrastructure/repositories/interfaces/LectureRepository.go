@@ -4,8 +4,24 @@ import "sarc/core/domain"
 
 type LectureRepository interface {
 	Create(lecture *domain.Lecture) error
-	FindAll() ([]domain.Lecture, error)
+	FindAll(page domain.Page) ([]domain.Lecture, error)
+	// StreamAll calls fn for every lecture in turn, reading rows as they
+	// arrive instead of loading the whole table. It stops at fn's first error.
+	StreamAll(fn func(domain.Lecture) error) error
 	FindByID(id uint) (*domain.Lecture, error)
+	// FindByIDs loads the given lectures in one query, ordered by ID.
+	// IDs with no row are skipped.
+	FindByIDs(ids []uint) ([]domain.Lecture, error)
+	// FindByClass and FindByRoom list lectures ordered by date, then ID.
+	FindByClass(classID uint, page domain.Page) ([]domain.Lecture, error)
+	FindByRoom(roomID uint, page domain.Page) ([]domain.Lecture, error)
+	// FindBetween lists the lectures dated from through to, both
+	// YYYY-MM-DD, ordered by date, then ID.
+	FindBetween(from, to string) ([]domain.Lecture, error)
+	// FindByClasses lists the lectures of the given classes dated from
+	// through to in one query, ordered by date, then ID. An empty from or to
+	// leaves that end of the range open.
+	FindByClasses(classIDs []uint, from, to string) ([]domain.Lecture, error)
 	Update(id uint, lecture *domain.Lecture) error
 	Delete(id uint) error
 }
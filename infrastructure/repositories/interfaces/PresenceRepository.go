@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"time"
+
+	"sarc/core/domain"
+)
+
+type PresenceRepository interface {
+	// Mark records the users as present at the lecture, skipping those
+	// already marked, and returns how many were added. Either every user is
+	// recorded or none is.
+	Mark(lectureID uint, userIDs []uint, method domain.PresenceMethod, at time.Time) (int, error)
+	Unmark(lectureID uint, userID uint) error
+	// FindByLectures returns the presence of the given lectures, each with
+	// its user, ordered by lecture and then by the time it was marked.
+	FindByLectures(lectureIDs []uint) ([]domain.Presence, error)
+	// FindByUser returns every lecture the user was marked present at,
+	// ordered by lecture, without the user attached.
+	FindByUser(userID uint) ([]domain.Presence, error)
+}
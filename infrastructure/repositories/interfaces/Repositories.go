@@ -0,0 +1,25 @@
+package repositories
+
+// Repositories groups one implementation of every repository so a whole
+// storage backend can be passed around and swapped as a unit.
+type Repositories struct {
+	Profile         ProfileRepository
+	User            UserRepository
+	Building        BuildingRepository
+	Room            RoomRepository
+	Discipline      DisciplineRepository
+	Curriculum      CurriculumRepository
+	Class           ClassRepository
+	Lecture         LectureRepository
+	ResourceType    ResourceTypeRepository
+	Resource        ResourceRepository
+	Reservation     ReservationRepository
+	Search          SearchRepository
+	Presence        PresenceRepository
+	CheckInClaim    CheckInClaimRepository
+	AttendanceAlert AttendanceAlertRepository
+	Enrollment      EnrollmentRepository
+	Equivalence     EquivalenceRepository
+	Assessment      AssessmentRepository
+	Record          AcademicRecordRepository
+}
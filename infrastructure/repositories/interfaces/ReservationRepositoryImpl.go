@@ -4,8 +4,17 @@ import "sarc/core/domain"
 
 type ReservationRepository interface {
 	Create(reservation *domain.Reservation) error
-	FindAll() ([]domain.Reservation, error)
+	FindAll(page domain.Page) ([]domain.Reservation, error)
+	// StreamAll calls fn for every reservation in turn, reading rows as they
+	// arrive instead of loading the whole table. It stops at fn's first error.
+	StreamAll(fn func(domain.Reservation) error) error
 	FindByID(id uint) (*domain.Reservation, error)
+	// FindByLecture lists the reservations of a lecture, with their
+	// resources, ordered by ID.
+	FindByLecture(lectureID uint, page domain.Page) ([]domain.Reservation, error)
+	// FindByLectures loads the reservations of the given lectures, with
+	// their resources, ordered by lecture then ID.
+	FindByLectures(lectureIDs []uint) ([]domain.Reservation, error)
 	Update(id uint, reservation *domain.Reservation) error
 	Delete(id uint) error
 	AddResourceToReservation(reservationID uint, resourceID uint) error
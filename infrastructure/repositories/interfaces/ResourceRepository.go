@@ -4,8 +4,13 @@ import "sarc/core/domain"
 
 type ResourceRepository interface {
 	Create(resource *domain.Resource) error
-	FindAll() ([]domain.Resource, error)
+	FindAll(page domain.Page) ([]domain.Resource, error)
+	// StreamAll calls fn for every resource in turn, reading rows as they
+	// arrive instead of loading the whole table. It stops at fn's first error.
+	StreamAll(fn func(domain.Resource) error) error
 	FindByID(id uint) (*domain.Resource, error)
+	// FindByResourceType returns the resources of one type, ordered by ID.
+	FindByResourceType(resourceTypeID uint) ([]domain.Resource, error)
 	Update(id uint, resource *domain.Resource) error
 	Delete(id uint) error
 }
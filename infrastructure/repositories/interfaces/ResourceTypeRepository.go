@@ -6,8 +6,11 @@ import (
 
 type ResourceTypeRepository interface {
 	Create(resourceType *domain.ResourceType) error
-	FindAll() ([]domain.ResourceType, error)
+	FindAll(page domain.Page) ([]domain.ResourceType, error)
 	FindByID(id uint) (*domain.ResourceType, error)
+	// FindByIDs loads the given resource types in one query, ordered by ID.
+	// IDs with no row are skipped.
+	FindByIDs(ids []uint) ([]domain.ResourceType, error)
 	Update(id uint, resourceType *domain.ResourceType) error
 	Delete(id uint) error
 }
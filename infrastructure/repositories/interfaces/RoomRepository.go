@@ -4,8 +4,16 @@ import "sarc/core/domain"
 
 type RoomRepository interface {
 	Create(room *domain.Room) error
-	FindAll() ([]domain.Room, error)
+	FindAll(page domain.Page) ([]domain.Room, error)
+	// StreamAll calls fn for every room in turn, reading rows as they
+	// arrive instead of loading the whole table. It stops at fn's first error.
+	StreamAll(fn func(domain.Room) error) error
 	FindByID(id uint) (*domain.Room, error)
+	// FindByIDs loads the given rooms in one query, ordered by ID.
+	// IDs with no row are skipped.
+	FindByIDs(ids []uint) ([]domain.Room, error)
+	// FindByBuilding lists the rooms of a building ordered by ID.
+	FindByBuilding(buildingID uint, page domain.Page) ([]domain.Room, error)
 	Update(id uint, room *domain.Room) error
 	Delete(id uint) error
 }
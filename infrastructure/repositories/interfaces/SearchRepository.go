@@ -0,0 +1,10 @@
+package repositories
+
+import "sarc/core/domain"
+
+type SearchRepository interface {
+	// Search returns the disciplines, resources, buildings, rooms and classes
+	// matching any of the query's words, at most q.Limit of each type.
+	// Backends without full-text search leave Rank and Headline empty.
+	Search(q domain.SearchQuery) ([]domain.SearchHit, error)
+}
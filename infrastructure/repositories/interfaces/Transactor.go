@@ -0,0 +1,8 @@
+package repositories
+
+// Transactor runs a unit of work atomically. fn receives repositories bound
+// to the transaction: if it returns an error nothing it wrote is kept,
+// otherwise every write is committed together.
+type Transactor interface {
+	WithinTransaction(fn func(repos Repositories) error) error
+}
@@ -4,8 +4,14 @@ import "sarc/core/domain"
 
 type UserRepository interface {
 	Create(user *domain.User) error
-	FindAll() ([]domain.User, error)
+	FindAll(page domain.Page) ([]domain.User, error)
+	// StreamAll calls fn for every user in turn, reading rows as they
+	// arrive instead of loading the whole table. It stops at fn's first error.
+	StreamAll(fn func(domain.User) error) error
 	FindByID(id uint) (*domain.User, error)
+	// FindByIDs loads the given users in one query, ordered by ID.
+	// IDs with no row are skipped.
+	FindByIDs(ids []uint) ([]domain.User, error)
 	Update(id uint, user *domain.User) error
 	Delete(id uint) error
 }
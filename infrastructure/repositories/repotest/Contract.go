@@ -0,0 +1,1101 @@
+// Package repotest holds the behaviour every repository backend must share.
+// Each backend runs RunContract from its own tests with a factory that
+// returns repositories over an empty store.
+package repotest
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"sarc/core/domain"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+// Factory returns repositories backed by a fresh, empty store.
+type Factory func(t *testing.T) repositories.Repositories
+
+// RunContract runs the whole contract suite against the backend built by newRepos.
+func RunContract(t *testing.T, newRepos Factory) {
+	t.Run("Profile", func(t *testing.T) { testProfiles(t, newRepos(t)) })
+	t.Run("User", func(t *testing.T) { testUsers(t, newRepos(t)) })
+	t.Run("Building", func(t *testing.T) { testBuildings(t, newRepos(t)) })
+	t.Run("Room", func(t *testing.T) { testRooms(t, newRepos(t)) })
+	t.Run("Discipline", func(t *testing.T) { testDisciplines(t, newRepos(t)) })
+	t.Run("Curriculum", func(t *testing.T) { testCurriculums(t, newRepos(t)) })
+	t.Run("Class", func(t *testing.T) { testClasses(t, newRepos(t)) })
+	t.Run("Lecture", func(t *testing.T) { testLectures(t, newRepos(t)) })
+	t.Run("ResourceType", func(t *testing.T) { testResourceTypes(t, newRepos(t)) })
+	t.Run("Resource", func(t *testing.T) { testResources(t, newRepos(t)) })
+	t.Run("Reservation", func(t *testing.T) { testReservations(t, newRepos(t)) })
+	t.Run("StreamAll", func(t *testing.T) { testStreamAll(t, newRepos(t)) })
+	t.Run("Search", func(t *testing.T) { testSearch(t, newRepos(t)) })
+	t.Run("Nested", func(t *testing.T) { testNested(t, newRepos(t)) })
+	t.Run("FindByIDs", func(t *testing.T) { testFindByIDs(t, newRepos(t)) })
+	t.Run("Presence", func(t *testing.T) { testPresence(t, newRepos(t)) })
+	t.Run("CheckInClaim", func(t *testing.T) { testCheckInClaims(t, newRepos(t)) })
+	t.Run("AttendanceAlert", func(t *testing.T) { testAttendanceAlerts(t, newRepos(t)) })
+	t.Run("Enrollment", func(t *testing.T) { testEnrollments(t, newRepos(t)) })
+	t.Run("Equivalence", func(t *testing.T) { testEquivalences(t, newRepos(t)) })
+	t.Run("Grades", func(t *testing.T) { testGrades(t, newRepos(t)) })
+}
+
+// fixture is one row of every entity, linked together.
+type fixture struct {
+	profile      domain.Profile
+	user         domain.User
+	building     domain.Building
+	room         domain.Room
+	discipline   domain.Discipline
+	curriculum   domain.Curriculum
+	class        domain.Class
+	lecture      domain.Lecture
+	resourceType domain.ResourceType
+	resource     domain.Resource
+	reservation  domain.Reservation
+}
+
+func seed(t *testing.T, repos repositories.Repositories) *fixture {
+	t.Helper()
+	f := &fixture{}
+	f.profile = domain.Profile{Role: "teacher", CanTeach: true}
+	must(t, repos.Profile.Create(&f.profile))
+	f.user = domain.User{Email: "ana@example.com", Nome: "Ana", BirthDate: "1990-05-04", Sex: "F", Telephone: "555", ProfileID: f.profile.ID}
+	must(t, repos.User.Create(&f.user))
+	f.building = domain.Building{BuildingName: "Prédio 32", Address: "Av. Ipiranga, 6681"}
+	must(t, repos.Building.Create(&f.building))
+	f.room = domain.Room{RoomNumber: "101", BuildingID: f.building.BuildingID, RoomCapacity: 40, Floor: 1}
+	must(t, repos.Room.Create(&f.room))
+	f.discipline = domain.Discipline{Name: "Cálculo I", Credits: 4, Program: "Limits", Bibliography: []string{"Stewart", "Thomas"}}
+	must(t, repos.Discipline.Create(&f.discipline))
+	f.curriculum = domain.Curriculum{CourseName: "Engenharia", DataInicio: "2025-01-01", DataFim: "2029-12-31"}
+	must(t, repos.Curriculum.Create(&f.curriculum))
+	f.class = domain.Class{Name: "Turma 10", Description: "Morning", DisciplineID: f.discipline.ID}
+	must(t, repos.Class.Create(&f.class))
+	f.lecture = domain.Lecture{ClassID: f.class.ClassID, RoomID: f.room.RoomID, Date: "2025-03-10", StartTime: "08:00", EndTime: "09:40", Content: []string{"Intro"}}
+	must(t, repos.Lecture.Create(&f.lecture))
+	f.resourceType = domain.ResourceType{Name: "Projector"}
+	must(t, repos.ResourceType.Create(&f.resourceType))
+	f.resource = domain.Resource{Description: "Epson", Status: domain.ResourceStatusAvailable, Characteristics: []string{"HDMI"}, ResourceTypeID: f.resourceType.ResourceTypeID}
+	must(t, repos.Resource.Create(&f.resource))
+	f.reservation = domain.Reservation{LectureID: f.lecture.LectureID, Observation: "bring cables"}
+	must(t, repos.Reservation.Create(&f.reservation))
+	return f
+}
+
+func testProfiles(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+	other := domain.Profile{Role: "student"}
+	must(t, repos.Profile.Create(&other))
+	if other.ID == 0 || other.ID == f.profile.ID {
+		t.Fatalf("Create assigned ID %d", other.ID)
+	}
+
+	got, err := repos.Profile.FindByID(other.ID)
+	must(t, err)
+	equal(t, got, &other)
+
+	all, err := repos.Profile.FindAll(domain.Page{})
+	must(t, err)
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	equal(t, all, []domain.Profile{f.profile, other})
+
+	must(t, repos.Profile.Update(other.ID, &domain.Profile{Role: "assistant", CanTeach: true}))
+	got, err = repos.Profile.FindByID(other.ID)
+	must(t, err)
+	equal(t, got.Role, "assistant")
+	equal(t, got.CanTeach, true)
+	equal(t, got.ID, other.ID)
+
+	must(t, repos.Profile.Update(9999, &domain.Profile{Role: "ghost"}))
+
+	if err := repos.Profile.Delete(f.profile.ID); err == nil {
+		t.Error("deleting a profile still used by a user should fail")
+	}
+	must(t, repos.Profile.Delete(other.ID))
+	expectNotFound(t, func() error { _, err := repos.Profile.FindByID(other.ID); return err })
+	must(t, repos.Profile.Delete(9999))
+}
+
+func testUsers(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+
+	got, err := repos.User.FindByID(f.user.ID)
+	must(t, err)
+	got.BirthDate = dateOnly(got.BirthDate)
+	equal(t, got, &f.user)
+
+	if err := repos.User.Create(&domain.User{Email: "x@example.com", ProfileID: 9999}); err == nil {
+		t.Error("creating a user with an unknown profile should fail")
+	}
+
+	updated := f.user
+	updated.Nome = "Ana Maria"
+	updated.Telephone = "556"
+	must(t, repos.User.Update(f.user.ID, &updated))
+	got, err = repos.User.FindByID(f.user.ID)
+	must(t, err)
+	equal(t, got.Nome, "Ana Maria")
+	equal(t, got.Telephone, "556")
+
+	all, err := repos.User.FindAll(domain.Page{})
+	must(t, err)
+	equal(t, len(all), 1)
+
+	must(t, repos.User.Delete(f.user.ID))
+	expectNotFound(t, func() error { _, err := repos.User.FindByID(f.user.ID); return err })
+}
+
+func testBuildings(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+	empty := domain.Building{BuildingName: "Prédio 15"}
+	must(t, repos.Building.Create(&empty))
+
+	got, err := repos.Building.FindByID(f.building.BuildingID)
+	must(t, err)
+	equal(t, got, &f.building)
+
+	all, err := repos.Building.FindAll(domain.Page{})
+	must(t, err)
+	sort.Slice(all, func(i, j int) bool { return all[i].BuildingID < all[j].BuildingID })
+	equal(t, all, []domain.Building{f.building, empty})
+
+	must(t, repos.Building.Update(empty.BuildingID, &domain.Building{BuildingName: "Prédio 16", Address: "Campus"}))
+	got, err = repos.Building.FindByID(empty.BuildingID)
+	must(t, err)
+	equal(t, got, &domain.Building{BuildingID: empty.BuildingID, BuildingName: "Prédio 16", Address: "Campus"})
+
+	if err := repos.Building.Delete(f.building.BuildingID); err == nil {
+		t.Error("deleting a building that still has rooms should fail")
+	}
+	must(t, repos.Building.Delete(empty.BuildingID))
+	expectNotFound(t, func() error { _, err := repos.Building.FindByID(empty.BuildingID); return err })
+}
+
+func testRooms(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+
+	got, err := repos.Room.FindByID(f.room.RoomID)
+	must(t, err)
+	equal(t, got, &f.room)
+
+	if err := repos.Room.Create(&domain.Room{RoomNumber: "X", BuildingID: 9999}); err == nil {
+		t.Error("creating a room in an unknown building should fail")
+	}
+
+	spare := domain.Room{RoomNumber: "102", BuildingID: f.building.BuildingID, RoomCapacity: 20, Floor: 1}
+	must(t, repos.Room.Create(&spare))
+	spare.RoomCapacity = 25
+	must(t, repos.Room.Update(spare.RoomID, &spare))
+	got, err = repos.Room.FindByID(spare.RoomID)
+	must(t, err)
+	equal(t, got, &spare)
+
+	if err := repos.Room.Update(spare.RoomID, &domain.Room{RoomNumber: "102", BuildingID: 9999}); err == nil {
+		t.Error("moving a room to an unknown building should fail")
+	}
+
+	if err := repos.Room.Delete(f.room.RoomID); err == nil {
+		t.Error("deleting a room that still has lectures should fail")
+	}
+	must(t, repos.Room.Delete(spare.RoomID))
+	expectNotFound(t, func() error { _, err := repos.Room.FindByID(spare.RoomID); return err })
+}
+
+func testDisciplines(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+
+	got, err := repos.Discipline.FindByID(f.discipline.ID)
+	must(t, err)
+	equal(t, got, &f.discipline)
+
+	noBooks := domain.Discipline{Name: "Seminário", Credits: 2, Program: "Talks"}
+	must(t, repos.Discipline.Create(&noBooks))
+	got, err = repos.Discipline.FindByID(noBooks.ID)
+	must(t, err)
+	equal(t, len(got.Bibliography), 0)
+
+	noBooks.Bibliography = []string{"Knuth", "Sedgewick", "Cormen"}
+	must(t, repos.Discipline.Update(noBooks.ID, &noBooks))
+	got, err = repos.Discipline.FindByID(noBooks.ID)
+	must(t, err)
+	equal(t, []string(got.Bibliography), []string{"Knuth", "Sedgewick", "Cormen"})
+
+	all, err := repos.Discipline.FindAll(domain.Page{})
+	must(t, err)
+	equal(t, len(all), 2)
+
+	if err := repos.Discipline.Delete(f.discipline.ID); err == nil {
+		t.Error("deleting a discipline that still has classes should fail")
+	}
+	must(t, repos.Discipline.Delete(noBooks.ID))
+	expectNotFound(t, func() error { _, err := repos.Discipline.FindByID(noBooks.ID); return err })
+}
+
+func testCurriculums(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+
+	got, err := repos.Curriculum.FindByID(f.curriculum.ID)
+	must(t, err)
+	equal(t, len(got.Disciplines), 0)
+	equal(t, dateOnly(got.DataInicio), "2025-01-01")
+	equal(t, dateOnly(got.DataFim), "2029-12-31")
+
+	second := domain.Discipline{Name: "Álgebra", Credits: 4, Program: "Vectors", Bibliography: []string{"Boldrini"}}
+	must(t, repos.Discipline.Create(&second))
+	must(t, repos.Curriculum.AddDisciplineToCurriculum(f.curriculum.ID, domain.Discipline{ID: second.ID, Semester: 1}))
+	must(t, repos.Curriculum.AddDisciplineToCurriculum(f.curriculum.ID, domain.Discipline{ID: f.discipline.ID}))
+	// Adding a discipline again keeps its placement.
+	must(t, repos.Curriculum.AddDisciplineToCurriculum(f.curriculum.ID, domain.Discipline{ID: f.discipline.ID, Semester: 3}))
+	if err := repos.Curriculum.AddDisciplineToCurriculum(f.curriculum.ID, domain.Discipline{ID: 9999}); err == nil {
+		t.Error("adding an unknown discipline should fail")
+	}
+
+	got, err = repos.Curriculum.FindByID(f.curriculum.ID)
+	must(t, err)
+	equal(t, disciplineIDs(got.Disciplines), []uint{f.discipline.ID, second.ID})
+	equal(t, got.Disciplines[0], f.discipline)
+
+	other := domain.Curriculum{CourseName: "Computação", DataInicio: "2024-01-01", DataFim: "2028-12-31"}
+	must(t, repos.Curriculum.Create(&other))
+	all, err := repos.Curriculum.FindAll(domain.Page{})
+	must(t, err)
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	equal(t, len(all), 2)
+	equal(t, disciplineIDs(all[0].Disciplines), []uint{f.discipline.ID, second.ID})
+	equal(t, len(all[1].Disciplines), 0)
+
+	must(t, repos.Curriculum.PlaceDiscipline(f.curriculum.ID, domain.Discipline{ID: f.discipline.ID, Semester: 2, Elective: true}))
+	expectNotFound(t, func() error {
+		return repos.Curriculum.PlaceDiscipline(other.ID, domain.Discipline{ID: f.discipline.ID, Semester: 2})
+	})
+	got, err = repos.Curriculum.FindByID(f.curriculum.ID)
+	must(t, err)
+	equal(t, disciplineIDs(got.Disciplines), []uint{second.ID, f.discipline.ID})
+	equal(t, got.Disciplines[0].Semester, 1)
+	equal(t, got.Disciplines[0].Elective, false)
+	equal(t, got.Disciplines[1].Semester, 2)
+	equal(t, got.Disciplines[1].Elective, true)
+	stored, err := repos.Discipline.FindByID(f.discipline.ID)
+	must(t, err)
+	equal(t, *stored, f.discipline)
+
+	must(t, repos.Curriculum.AddRequisite(&domain.Requisite{CurriculumID: f.curriculum.ID, DisciplineID: second.ID, RequisiteID: f.discipline.ID, Kind: domain.RequisitePrerequisite}))
+	must(t, repos.Curriculum.AddRequisite(&domain.Requisite{CurriculumID: f.curriculum.ID, DisciplineID: f.discipline.ID, RequisiteID: second.ID, Kind: domain.RequisiteCorequisite}))
+	if err := repos.Curriculum.AddRequisite(&domain.Requisite{CurriculumID: f.curriculum.ID, DisciplineID: second.ID, RequisiteID: f.discipline.ID, Kind: domain.RequisiteCorequisite}); err == nil {
+		t.Error("adding the same requisite twice should fail")
+	}
+	if err := repos.Curriculum.AddRequisite(&domain.Requisite{CurriculumID: other.ID, DisciplineID: second.ID, RequisiteID: f.discipline.ID, Kind: domain.RequisitePrerequisite}); err == nil {
+		t.Error("adding a requisite between disciplines outside the curriculum should fail")
+	}
+	requisites, err := repos.Curriculum.FindRequisites(f.curriculum.ID)
+	must(t, err)
+	equal(t, requisites, []domain.Requisite{
+		{CurriculumID: f.curriculum.ID, DisciplineID: f.discipline.ID, RequisiteID: second.ID, Kind: domain.RequisiteCorequisite},
+		{CurriculumID: f.curriculum.ID, DisciplineID: second.ID, RequisiteID: f.discipline.ID, Kind: domain.RequisitePrerequisite},
+	})
+	must(t, repos.Curriculum.RemoveRequisite(f.curriculum.ID, f.discipline.ID, second.ID))
+	requisites, err = repos.Curriculum.FindRequisites(f.curriculum.ID)
+	must(t, err)
+	equal(t, len(requisites), 1)
+	requisites, err = repos.Curriculum.FindRequisites(other.ID)
+	must(t, err)
+	equal(t, len(requisites), 0)
+
+	must(t, repos.Curriculum.Update(other.ID, &domain.Curriculum{CourseName: "Ciência da Computação", DataInicio: "2024-02-01", DataFim: "2028-12-31"}))
+	got, err = repos.Curriculum.FindByID(other.ID)
+	must(t, err)
+	equal(t, got.CourseName, "Ciência da Computação")
+	equal(t, dateOnly(got.DataInicio), "2024-02-01")
+
+	versions, err := repos.Curriculum.FindByLineage(f.curriculum.ID)
+	must(t, err)
+	equal(t, len(versions), 1)
+
+	if err := repos.Curriculum.Delete(f.curriculum.ID); err == nil {
+		t.Error("deleting a curriculum that still lists disciplines should fail")
+	}
+	must(t, repos.Curriculum.RemoveDisciplineFromCurriculum(f.curriculum.ID, second.ID))
+	got, err = repos.Curriculum.FindByID(f.curriculum.ID)
+	must(t, err)
+	equal(t, disciplineIDs(got.Disciplines), []uint{f.discipline.ID})
+	requisites, err = repos.Curriculum.FindRequisites(f.curriculum.ID)
+	must(t, err)
+	equal(t, len(requisites), 0)
+	must(t, repos.Curriculum.RemoveDisciplineFromCurriculum(f.curriculum.ID, f.discipline.ID))
+	must(t, repos.Curriculum.Delete(f.curriculum.ID))
+
+	equal(t, other.LineageID, other.ID)
+	next := domain.Curriculum{CourseName: "Computação", DataInicio: "2029-01-01", DataFim: "2033-12-31", LineageID: other.ID, Version: 2}
+	must(t, repos.Curriculum.Create(&next))
+	duplicate := domain.Curriculum{CourseName: "Computação", LineageID: other.ID, Version: 2}
+	if err := repos.Curriculum.Create(&duplicate); err == nil {
+		t.Error("creating a second version 2 of a lineage should fail")
+	}
+	must(t, repos.Curriculum.LockLineage(next.ID))
+	must(t, repos.Curriculum.Update(next.ID, &domain.Curriculum{CourseName: "Computação 2029", DataInicio: "2029-01-01", DataFim: "2033-12-31"}))
+	versions, err = repos.Curriculum.FindByLineage(other.ID)
+	must(t, err)
+	equal(t, len(versions), 2)
+	equal(t, versions[0].ID, other.ID)
+	equal(t, versions[0].LineageID, other.ID)
+	equal(t, versions[1].ID, next.ID)
+	equal(t, versions[1].LineageID, other.ID)
+	equal(t, versions[1].Version, 2)
+	equal(t, versions[1].CourseName, "Computação 2029")
+	if err := repos.Curriculum.Delete(other.ID); err == nil {
+		t.Error("deleting a curriculum with later versions should fail")
+	}
+	must(t, repos.Curriculum.Delete(next.ID))
+	must(t, repos.Curriculum.Delete(other.ID))
+	expectNotFound(t, func() error { _, err := repos.Curriculum.FindByID(other.ID); return err })
+}
+
+func testClasses(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+
+	got, err := repos.Class.FindByID(f.class.ClassID)
+	must(t, err)
+	equal(t, got, &f.class)
+
+	if err := repos.Class.Create(&domain.Class{Name: "X", DisciplineID: 9999}); err == nil {
+		t.Error("creating a class of an unknown discipline should fail")
+	}
+
+	evening := domain.Class{Name: "Turma 11", Description: "Evening", DisciplineID: f.discipline.ID}
+	must(t, repos.Class.Create(&evening))
+	evening.Description = "Night"
+	must(t, repos.Class.Update(evening.ClassID, &evening))
+	all, err := repos.Class.FindAll(domain.Page{})
+	must(t, err)
+	sort.Slice(all, func(i, j int) bool { return all[i].ClassID < all[j].ClassID })
+	equal(t, all, []domain.Class{f.class, evening})
+
+	if err := repos.Class.Delete(f.class.ClassID); err == nil {
+		t.Error("deleting a class that still has lectures should fail")
+	}
+	must(t, repos.Class.Delete(evening.ClassID))
+	expectNotFound(t, func() error { _, err := repos.Class.FindByID(evening.ClassID); return err })
+}
+
+func testLectures(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+
+	got, err := repos.Lecture.FindByID(f.lecture.LectureID)
+	must(t, err)
+	got.Date = dateOnly(got.Date)
+	equal(t, got.LectureID, f.lecture.LectureID)
+	equal(t, got.ClassID, f.class.ClassID)
+	equal(t, got.RoomID, f.room.RoomID)
+	equal(t, got.Date, "2025-03-10")
+	equal(t, got.StartTime, "08:00")
+	equal(t, got.EndTime, "09:40")
+	equal(t, []string(got.Content), []string{"Intro"})
+
+	if err := repos.Lecture.Create(&domain.Lecture{ClassID: f.class.ClassID, RoomID: 9999, Date: "2025-03-11"}); err == nil {
+		t.Error("creating a lecture in an unknown room should fail")
+	}
+
+	next := domain.Lecture{ClassID: f.class.ClassID, RoomID: f.room.RoomID, Date: "2025-03-17", Content: []string{"Limits", "Continuity"}}
+	must(t, repos.Lecture.Create(&next))
+	next.Content = append(next.Content, "Derivatives")
+	next.StartTime, next.EndTime = "19:30", "21:10"
+	must(t, repos.Lecture.Update(next.LectureID, &next))
+	got, err = repos.Lecture.FindByID(next.LectureID)
+	must(t, err)
+	equal(t, []string(got.Content), []string{"Limits", "Continuity", "Derivatives"})
+	equal(t, got.StartTime, "19:30")
+	equal(t, got.EndTime, "21:10")
+
+	all, err := repos.Lecture.FindAll(domain.Page{})
+	must(t, err)
+	equal(t, len(all), 2)
+
+	between, err := repos.Lecture.FindBetween("2025-03-11", "2025-03-31")
+	must(t, err)
+	equal(t, len(between), 1)
+	equal(t, between[0].LectureID, next.LectureID)
+	between, err = repos.Lecture.FindBetween("2025-03-10", "2025-03-17")
+	must(t, err)
+	equal(t, len(between), 2)
+
+	evening := domain.Class{Name: "Turma 11", DisciplineID: f.discipline.ID}
+	must(t, repos.Class.Create(&evening))
+	late := domain.Lecture{ClassID: evening.ClassID, RoomID: f.room.RoomID, Date: "2025-03-12"}
+	must(t, repos.Lecture.Create(&late))
+	byClass, err := repos.Lecture.FindByClasses([]uint{f.class.ClassID, evening.ClassID}, "2025-03-11", "")
+	must(t, err)
+	equal(t, len(byClass), 2)
+	equal(t, byClass[0].LectureID, late.LectureID)
+	equal(t, byClass[1].LectureID, next.LectureID)
+	byClass, err = repos.Lecture.FindByClasses([]uint{evening.ClassID}, "", "2025-03-11")
+	must(t, err)
+	equal(t, len(byClass), 0)
+	must(t, repos.Lecture.Delete(late.LectureID))
+	must(t, repos.Class.Delete(evening.ClassID))
+
+	if err := repos.Lecture.Delete(f.lecture.LectureID); err == nil {
+		t.Error("deleting a lecture that still has reservations should fail")
+	}
+	must(t, repos.Lecture.Delete(next.LectureID))
+	expectNotFound(t, func() error { _, err := repos.Lecture.FindByID(next.LectureID); return err })
+}
+
+func testResourceTypes(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+	if f.resourceType.ResourceTypeID == 0 {
+		t.Fatal("Create did not assign an ID")
+	}
+
+	mic := domain.ResourceType{Name: "Microphone"}
+	must(t, repos.ResourceType.Create(&mic))
+	got, err := repos.ResourceType.FindByID(mic.ResourceTypeID)
+	must(t, err)
+	equal(t, got, &mic)
+
+	must(t, repos.ResourceType.Update(mic.ResourceTypeID, &domain.ResourceType{Name: "Wireless microphone"}))
+	all, err := repos.ResourceType.FindAll(domain.Page{})
+	must(t, err)
+	sort.Slice(all, func(i, j int) bool { return all[i].ResourceTypeID < all[j].ResourceTypeID })
+	equal(t, all, []domain.ResourceType{f.resourceType, {ResourceTypeID: mic.ResourceTypeID, Name: "Wireless microphone"}})
+
+	if err := repos.ResourceType.Delete(f.resourceType.ResourceTypeID); err == nil {
+		t.Error("deleting a resource type that still has resources should fail")
+	}
+	must(t, repos.ResourceType.Delete(mic.ResourceTypeID))
+	expectNotFound(t, func() error { _, err := repos.ResourceType.FindByID(mic.ResourceTypeID); return err })
+}
+
+func testResources(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+
+	got, err := repos.Resource.FindByID(f.resource.ResourceID)
+	must(t, err)
+	if got.ResourceType == nil || *got.ResourceType != f.resourceType {
+		t.Errorf("resource type not joined: %+v", got.ResourceType)
+	}
+	got.ResourceType = nil
+	equal(t, got, &f.resource)
+
+	if err := repos.Resource.Create(&domain.Resource{Description: "X", ResourceTypeID: 9999}); err == nil {
+		t.Error("creating a resource of an unknown type should fail")
+	}
+
+	f.resource.Status = domain.ResourceStatusUnavailable
+	f.resource.Characteristics = []string{"HDMI", "VGA"}
+	must(t, repos.Resource.Update(f.resource.ResourceID, &f.resource))
+	all, err := repos.Resource.FindAll(domain.Page{})
+	must(t, err)
+	equal(t, len(all), 1)
+	equal(t, all[0].Status, domain.ResourceStatusUnavailable)
+	equal(t, []string(all[0].Characteristics), []string{"HDMI", "VGA"})
+	if all[0].ResourceType == nil || all[0].ResourceType.Name != "Projector" {
+		t.Errorf("resource type not joined in FindAll: %+v", all[0].ResourceType)
+	}
+
+	microphone := domain.ResourceType{Name: "Microphone"}
+	must(t, repos.ResourceType.Create(&microphone))
+	wireless := domain.Resource{Description: "Shure", Status: domain.ResourceStatusAvailable, ResourceTypeID: microphone.ResourceTypeID}
+	must(t, repos.Resource.Create(&wireless))
+	byType, err := repos.Resource.FindByResourceType(microphone.ResourceTypeID)
+	must(t, err)
+	equal(t, resourceIDs(byType), []uint{wireless.ResourceID})
+	if byType[0].ResourceType == nil || byType[0].ResourceType.Name != "Microphone" {
+		t.Errorf("resource type not joined in FindByResourceType: %+v", byType[0].ResourceType)
+	}
+	byType, err = repos.Resource.FindByResourceType(9999)
+	must(t, err)
+	equal(t, len(byType), 0)
+
+	must(t, repos.Reservation.AddResourceToReservation(f.reservation.ReservationID, f.resource.ResourceID))
+	if err := repos.Resource.Delete(f.resource.ResourceID); err == nil {
+		t.Error("deleting a reserved resource should fail")
+	}
+}
+
+func testReservations(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+
+	got, err := repos.Reservation.FindByID(f.reservation.ReservationID)
+	must(t, err)
+	equal(t, len(got.Resources), 0)
+
+	cable := domain.Resource{Description: "HDMI cable", Status: domain.ResourceStatusAvailable, ResourceTypeID: f.resourceType.ResourceTypeID}
+	must(t, repos.Resource.Create(&cable))
+	must(t, repos.Reservation.AddResourceToReservation(f.reservation.ReservationID, cable.ResourceID))
+	must(t, repos.Reservation.AddResourceToReservation(f.reservation.ReservationID, f.resource.ResourceID))
+	if err := repos.Reservation.AddResourceToReservation(f.reservation.ReservationID, cable.ResourceID); err == nil {
+		t.Error("adding the same resource twice should fail")
+	}
+	if err := repos.Reservation.AddResourceToReservation(f.reservation.ReservationID, 9999); err == nil {
+		t.Error("adding an unknown resource should fail")
+	}
+
+	got, err = repos.Reservation.FindByID(f.reservation.ReservationID)
+	must(t, err)
+	equal(t, resourceIDs(got.Resources), []uint{f.resource.ResourceID, cable.ResourceID})
+	equal(t, []string(got.Resources[0].Characteristics), []string{"HDMI"})
+
+	empty := domain.Reservation{LectureID: f.lecture.LectureID, Observation: "none"}
+	must(t, repos.Reservation.Create(&empty))
+	all, err := repos.Reservation.FindAll(domain.Page{})
+	must(t, err)
+	sort.Slice(all, func(i, j int) bool { return all[i].ReservationID < all[j].ReservationID })
+	equal(t, len(all), 2)
+	equal(t, resourceIDs(all[0].Resources), []uint{f.resource.ResourceID, cable.ResourceID})
+	equal(t, len(all[1].Resources), 0)
+
+	if err := repos.Reservation.Create(&domain.Reservation{LectureID: 9999}); err == nil {
+		t.Error("creating a reservation for an unknown lecture should fail")
+	}
+
+	must(t, repos.Reservation.Update(empty.ReservationID, &domain.Reservation{LectureID: f.lecture.LectureID, Observation: "projector only"}))
+	got, err = repos.Reservation.FindByID(empty.ReservationID)
+	must(t, err)
+	equal(t, got.Observation, "projector only")
+
+	if err := repos.Reservation.Delete(f.reservation.ReservationID); err == nil {
+		t.Error("deleting a reservation that still holds resources should fail")
+	}
+	must(t, repos.Reservation.Delete(empty.ReservationID))
+	expectNotFound(t, func() error { _, err := repos.Reservation.FindByID(empty.ReservationID); return err })
+}
+
+func testStreamAll(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+	must(t, repos.Reservation.AddResourceToReservation(f.reservation.ReservationID, f.resource.ResourceID))
+	must(t, repos.Reservation.Create(&domain.Reservation{LectureID: f.lecture.LectureID, Observation: "no resources"}))
+	must(t, repos.Room.Create(&domain.Room{RoomNumber: "102", BuildingID: f.building.BuildingID}))
+
+	rooms, err := repos.Room.FindAll(domain.Page{})
+	must(t, err)
+	equal(t, collect(t, repos.Room.StreamAll), rooms)
+	users, err := repos.User.FindAll(domain.Page{})
+	must(t, err)
+	equal(t, collect(t, repos.User.StreamAll), users)
+	lectures, err := repos.Lecture.FindAll(domain.Page{})
+	must(t, err)
+	equal(t, collect(t, repos.Lecture.StreamAll), lectures)
+	resources, err := repos.Resource.FindAll(domain.Page{})
+	must(t, err)
+	equal(t, collect(t, repos.Resource.StreamAll), resources)
+
+	reservations, err := repos.Reservation.FindAll(domain.Page{})
+	must(t, err)
+	sort.Slice(reservations, func(i, j int) bool { return reservations[i].ReservationID < reservations[j].ReservationID })
+	streamed := collect(t, repos.Reservation.StreamAll)
+	equal(t, streamed, reservations)
+	equal(t, len(streamed), 2)
+
+	stop := errors.New("stop")
+	calls := 0
+	err = repos.Room.StreamAll(func(domain.Room) error { calls++; return stop })
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("StreamAll should stop at the first error, got %v after %d calls", err, calls)
+	}
+}
+
+func testSearch(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+	other := domain.Building{BuildingName: "Prédio 15", Address: "Rua Sem Saída"}
+	must(t, repos.Building.Create(&other))
+
+	search := func(text string, terms ...string) map[string][]uint {
+		t.Helper()
+		hits, err := repos.Search.Search(domain.SearchQuery{Text: text, Terms: terms, Limit: 10})
+		must(t, err)
+		found := make(map[string][]uint)
+		for _, hit := range hits {
+			found[hit.Type] = append(found[hit.Type], hit.ID)
+		}
+		for _, ids := range found {
+			sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		}
+		return found
+	}
+
+	equal(t, search("hdmi", "hdmi"), map[string][]uint{domain.SearchTypeResource: {f.resource.ResourceID}})
+	equal(t, search("stewart", "stewart"), map[string][]uint{domain.SearchTypeDiscipline: {f.discipline.ID}})
+	equal(t, search("morning", "morning"), map[string][]uint{domain.SearchTypeClass: {f.class.ClassID}})
+	equal(t, search("ipiranga", "ipiranga"), map[string][]uint{
+		domain.SearchTypeBuilding: {f.building.BuildingID},
+		domain.SearchTypeRoom:     {f.room.RoomID},
+	})
+	// Any word may match: the resource by its description, the building and
+	// its room by the building name, and the room by its number.
+	equal(t, search("epson 32 101", "epson", "32", "101"), map[string][]uint{
+		domain.SearchTypeResource: {f.resource.ResourceID},
+		domain.SearchTypeBuilding: {f.building.BuildingID},
+		domain.SearchTypeRoom:     {f.room.RoomID},
+	})
+	equal(t, search("nothing", "nothing"), map[string][]uint{})
+}
+
+func testNested(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+	other := domain.Building{BuildingName: "Prédio 15"}
+	must(t, repos.Building.Create(&other))
+	room2 := domain.Room{RoomNumber: "102", BuildingID: f.building.BuildingID}
+	must(t, repos.Room.Create(&room2))
+	must(t, repos.Room.Create(&domain.Room{RoomNumber: "201", BuildingID: other.BuildingID}))
+	class2 := domain.Class{Name: "Turma 11", DisciplineID: f.discipline.ID}
+	must(t, repos.Class.Create(&class2))
+	// Created later but earlier in the calendar, so it must come first
+	earlier := domain.Lecture{ClassID: f.class.ClassID, RoomID: room2.RoomID, Date: "2025-03-03"}
+	must(t, repos.Lecture.Create(&earlier))
+	must(t, repos.Lecture.Create(&domain.Lecture{ClassID: class2.ClassID, RoomID: f.room.RoomID, Date: "2025-03-12"}))
+	must(t, repos.Reservation.AddResourceToReservation(f.reservation.ReservationID, f.resource.ResourceID))
+	rsv2 := domain.Reservation{LectureID: f.lecture.LectureID}
+	must(t, repos.Reservation.Create(&rsv2))
+
+	all := domain.Page{}
+	rooms, err := repos.Room.FindByBuilding(f.building.BuildingID, all)
+	must(t, err)
+	equal(t, len(rooms), 2)
+	equal(t, rooms[1], room2)
+	rooms, err = repos.Room.FindByBuilding(f.building.BuildingID, domain.Page{Limit: 1, Offset: 1})
+	must(t, err)
+	equal(t, rooms, []domain.Room{room2})
+	rooms, err = repos.Room.FindByBuilding(f.building.BuildingID, domain.Page{Offset: 5})
+	must(t, err)
+	equal(t, len(rooms), 0)
+
+	classes, err := repos.Class.FindByDiscipline(f.discipline.ID, domain.Page{Limit: 1})
+	must(t, err)
+	equal(t, classes, []domain.Class{f.class})
+	classes, err = repos.Class.FindByDiscipline(f.discipline.ID, all)
+	must(t, err)
+	equal(t, len(classes), 2)
+
+	lectureIDs := func(ls []domain.Lecture, err error) []uint {
+		t.Helper()
+		must(t, err)
+		var ids []uint
+		for _, l := range ls {
+			ids = append(ids, l.LectureID)
+		}
+		return ids
+	}
+	equal(t, lectureIDs(repos.Lecture.FindByClass(f.class.ClassID, all)), []uint{earlier.LectureID, f.lecture.LectureID})
+	equal(t, lectureIDs(repos.Lecture.FindByClass(f.class.ClassID, domain.Page{Limit: 1, Offset: 1})), []uint{f.lecture.LectureID})
+	equal(t, len(lectureIDs(repos.Lecture.FindByRoom(f.room.RoomID, all))), 2)
+	equal(t, lectureIDs(repos.Lecture.FindByRoom(room2.RoomID, all)), []uint{earlier.LectureID})
+
+	reservations, err := repos.Reservation.FindByLecture(f.lecture.LectureID, all)
+	must(t, err)
+	equal(t, len(reservations), 2)
+	equal(t, resourceIDs(reservations[0].Resources), []uint{f.resource.ResourceID})
+	equal(t, reservations[1].ReservationID, rsv2.ReservationID)
+	reservations, err = repos.Reservation.FindByLecture(earlier.LectureID, all)
+	must(t, err)
+	equal(t, len(reservations), 0)
+
+	reservations, err = repos.Reservation.FindByLectures([]uint{earlier.LectureID, f.lecture.LectureID})
+	must(t, err)
+	equal(t, len(reservations), 2)
+	equal(t, resourceIDs(reservations[0].Resources), []uint{f.resource.ResourceID})
+	reservations, err = repos.Reservation.FindByLectures(nil)
+	must(t, err)
+	equal(t, len(reservations), 0)
+
+	// The top-level lists page the same way, ordered by ID
+	buildings, err := repos.Building.FindAll(domain.Page{Limit: 1, Offset: 1})
+	must(t, err)
+	equal(t, buildings, []domain.Building{other})
+	rooms, err = repos.Room.FindAll(domain.Page{Limit: 2, Offset: 1})
+	must(t, err)
+	equal(t, len(rooms), 2)
+	equal(t, rooms[0], room2)
+	classes, err = repos.Class.FindAll(domain.Page{Offset: 1})
+	must(t, err)
+	equal(t, classes, []domain.Class{class2})
+	equal(t, lectureIDs(repos.Lecture.FindAll(domain.Page{Limit: 1, Offset: 1})), []uint{earlier.LectureID})
+	reservations, err = repos.Reservation.FindAll(domain.Page{Limit: 1})
+	must(t, err)
+	equal(t, resourceIDs(reservations[0].Resources), []uint{f.resource.ResourceID})
+	reservations, err = repos.Reservation.FindAll(domain.Page{Offset: 5})
+	must(t, err)
+	equal(t, len(reservations), 0)
+}
+
+func testFindByIDs(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+	room2 := domain.Room{RoomNumber: "102", BuildingID: f.building.BuildingID}
+	must(t, repos.Room.Create(&room2))
+
+	// Duplicates and unknown IDs are fine; rows come back once, ordered by ID
+	rooms, err := repos.Room.FindByIDs([]uint{room2.RoomID, f.room.RoomID, room2.RoomID, 999})
+	must(t, err)
+	equal(t, rooms, []domain.Room{f.room, room2})
+	rooms, err = repos.Room.FindByIDs(nil)
+	must(t, err)
+	equal(t, len(rooms), 0)
+
+	buildings, err := repos.Building.FindByIDs([]uint{f.building.BuildingID})
+	must(t, err)
+	equal(t, buildings, []domain.Building{f.building})
+	disciplines, err := repos.Discipline.FindByIDs([]uint{f.discipline.ID})
+	must(t, err)
+	equal(t, disciplineIDs(disciplines), []uint{f.discipline.ID})
+	equal(t, []string(disciplines[0].Bibliography), []string(f.discipline.Bibliography))
+	classes, err := repos.Class.FindByIDs([]uint{f.class.ClassID})
+	must(t, err)
+	equal(t, classes, []domain.Class{f.class})
+	lectures, err := repos.Lecture.FindByIDs([]uint{f.lecture.LectureID})
+	must(t, err)
+	equal(t, len(lectures), 1)
+	equal(t, dateOnly(lectures[0].Date), f.lecture.Date)
+	equal(t, []string(lectures[0].Content), []string(f.lecture.Content))
+	types, err := repos.ResourceType.FindByIDs([]uint{f.resourceType.ResourceTypeID, 999})
+	must(t, err)
+	equal(t, types, []domain.ResourceType{f.resourceType})
+}
+
+func testPresence(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+	bia := domain.User{Email: "bia@example.com", Nome: "Bia", BirthDate: "2001-02-03", ProfileID: f.profile.ID}
+	must(t, repos.User.Create(&bia))
+	at := time.Date(2025, 3, 10, 8, 15, 0, 0, time.UTC)
+
+	n, err := repos.Presence.Mark(f.lecture.LectureID, []uint{f.user.ID}, domain.PresenceMethodManual, at)
+	must(t, err)
+	equal(t, n, 1)
+	// Already marked users are skipped and keep their original record
+	n, err = repos.Presence.Mark(f.lecture.LectureID, []uint{f.user.ID, bia.ID}, domain.PresenceMethodRollCall, at.Add(time.Minute))
+	must(t, err)
+	equal(t, n, 1)
+	// One unknown user rejects the whole call
+	if _, err := repos.Presence.Mark(f.lecture.LectureID, []uint{bia.ID, 999}, domain.PresenceMethodManual, at); err == nil {
+		t.Error("marking an unknown user should fail")
+	}
+
+	presence, err := repos.Presence.FindByLectures([]uint{f.lecture.LectureID})
+	must(t, err)
+	equal(t, len(presence), 2)
+	equal(t, presence[0].UserID, f.user.ID)
+	equal(t, presence[0].Method, domain.PresenceMethodManual)
+	if !presence[0].MarkedAt.Equal(at) {
+		t.Errorf("got marked at %v, want %v", presence[0].MarkedAt, at)
+	}
+	equal(t, presence[0].User.Nome, "Ana")
+	equal(t, presence[1].UserID, bia.ID)
+	equal(t, presence[1].Method, domain.PresenceMethodRollCall)
+
+	if err := repos.Lecture.Delete(f.lecture.LectureID); err == nil {
+		t.Error("deleting a lecture with presence should fail")
+	}
+
+	later := domain.Lecture{ClassID: f.class.ClassID, RoomID: f.room.RoomID, Date: "2025-03-17"}
+	must(t, repos.Lecture.Create(&later))
+	_, err = repos.Presence.Mark(later.LectureID, []uint{bia.ID}, domain.PresenceMethodQRCode, at.AddDate(0, 0, 7))
+	must(t, err)
+	byUser, err := repos.Presence.FindByUser(bia.ID)
+	must(t, err)
+	equal(t, len(byUser), 2)
+	equal(t, byUser[0].LectureID, f.lecture.LectureID)
+	equal(t, byUser[1].LectureID, later.LectureID)
+	equal(t, byUser[1].Method, domain.PresenceMethodQRCode)
+	if byUser[0].User != nil {
+		t.Error("FindByUser should not attach the user")
+	}
+
+	must(t, repos.Presence.Unmark(f.lecture.LectureID, f.user.ID))
+	must(t, repos.Presence.Unmark(f.lecture.LectureID, f.user.ID))
+	presence, err = repos.Presence.FindByLectures([]uint{f.lecture.LectureID})
+	must(t, err)
+	equal(t, len(presence), 1)
+	presence, err = repos.Presence.FindByLectures(nil)
+	must(t, err)
+	equal(t, len(presence), 0)
+}
+
+func testCheckInClaims(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+	at := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+	expires := at.Add(time.Minute)
+	claimed, err := repos.CheckInClaim.Claim(f.lecture.LectureID, f.user.ID, 7, expires, at)
+	must(t, err)
+	equal(t, claimed, true)
+	claimed, err = repos.CheckInClaim.Claim(f.lecture.LectureID, f.user.ID, 7, expires, at)
+	must(t, err)
+	equal(t, claimed, false)
+	claimed, err = repos.CheckInClaim.Claim(f.lecture.LectureID, f.user.ID, 8, expires, at)
+	must(t, err)
+	equal(t, claimed, true)
+	claimed, err = repos.CheckInClaim.Claim(f.lecture.LectureID, f.user.ID+1, 7, expires, at)
+	must(t, err)
+	equal(t, claimed, true)
+	// Once expired the claim is dropped, so the same key can be claimed again
+	claimed, err = repos.CheckInClaim.Claim(f.lecture.LectureID, f.user.ID, 7, expires.Add(time.Minute), expires)
+	must(t, err)
+	equal(t, claimed, true)
+}
+
+func testAttendanceAlerts(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+	bia := domain.User{Email: "bia@example.com", Nome: "Bia", BirthDate: "2001-02-03", ProfileID: f.profile.ID}
+	must(t, repos.User.Create(&bia))
+	at := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+
+	statuses, err := repos.AttendanceAlert.FindNotifiedStatuses(f.class.ClassID)
+	must(t, err)
+	equal(t, statuses, map[uint]domain.AttendanceStatus{})
+	must(t, repos.AttendanceAlert.SaveNotifiedStatus(f.class.ClassID, bia.ID, domain.AttendanceAtRisk, at))
+	must(t, repos.AttendanceAlert.SaveNotifiedStatus(f.class.ClassID, f.user.ID, domain.AttendanceAtRisk, at))
+	must(t, repos.AttendanceAlert.SaveNotifiedStatus(f.class.ClassID, bia.ID, domain.AttendanceFailed, at.Add(time.Hour)))
+	must(t, repos.AttendanceAlert.SaveNotifiedStatus(9999, bia.ID, domain.AttendanceOK, at))
+	statuses, err = repos.AttendanceAlert.FindNotifiedStatuses(f.class.ClassID)
+	must(t, err)
+	equal(t, statuses, map[uint]domain.AttendanceStatus{bia.ID: domain.AttendanceFailed, f.user.ID: domain.AttendanceAtRisk})
+}
+
+func testEnrollments(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+	bia := domain.User{Email: "bia@example.com", Nome: "Bia", BirthDate: "2001-02-03", ProfileID: f.profile.ID}
+	must(t, repos.User.Create(&bia))
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	teacher := domain.Enrollment{ClassID: f.class.ClassID, UserID: f.user.ID, Role: domain.EnrollmentRoleTeacher, Status: domain.EnrollmentActive, EnrolledAt: at}
+	must(t, repos.Enrollment.Create(&teacher))
+	student := domain.Enrollment{ClassID: f.class.ClassID, UserID: bia.ID, Role: domain.EnrollmentRoleStudent, Status: domain.EnrollmentActive, EnrolledAt: at}
+	must(t, repos.Enrollment.Create(&student))
+	if teacher.EnrollmentID == 0 || student.EnrollmentID == teacher.EnrollmentID {
+		t.Fatalf("Create assigned IDs %d and %d", teacher.EnrollmentID, student.EnrollmentID)
+	}
+	if err := repos.Enrollment.Create(&domain.Enrollment{ClassID: f.class.ClassID, UserID: bia.ID, Role: domain.EnrollmentRoleStudent, Status: domain.EnrollmentActive, EnrolledAt: at}); err == nil {
+		t.Error("enrolling the same user twice in a class should fail")
+	}
+	if err := repos.Enrollment.Create(&domain.Enrollment{ClassID: 9999, UserID: bia.ID, Role: domain.EnrollmentRoleStudent, Status: domain.EnrollmentActive, EnrolledAt: at}); err == nil {
+		t.Error("enrolling in an unknown class should fail")
+	}
+
+	got, err := repos.Enrollment.Find(f.class.ClassID, bia.ID)
+	must(t, err)
+	equal(t, got.EnrollmentID, student.EnrollmentID)
+	equal(t, got.Role, domain.EnrollmentRoleStudent)
+	if !got.EnrolledAt.Equal(at) || got.DroppedAt != nil {
+		t.Errorf("got enrolled at %v, dropped at %v", got.EnrolledAt, got.DroppedAt)
+	}
+	expectNotFound(t, func() error { _, err := repos.Enrollment.Find(f.class.ClassID, 9999); return err })
+
+	counts, err := repos.Enrollment.CountStudents([]uint{f.class.ClassID})
+	must(t, err)
+	equal(t, counts, map[uint]int{f.class.ClassID: 1})
+
+	dropped := at.Add(24 * time.Hour)
+	student.Status = domain.EnrollmentDropped
+	student.DroppedAt = &dropped
+	must(t, repos.Enrollment.Update(&student))
+	got, err = repos.Enrollment.Find(f.class.ClassID, bia.ID)
+	must(t, err)
+	equal(t, got.Status, domain.EnrollmentDropped)
+	if got.DroppedAt == nil || !got.DroppedAt.Equal(dropped) {
+		t.Errorf("got dropped at %v, want %v", got.DroppedAt, dropped)
+	}
+
+	roster, err := repos.Enrollment.FindByClass(f.class.ClassID)
+	must(t, err)
+	equal(t, len(roster), 2)
+	equal(t, roster[0].UserID, f.user.ID)
+	equal(t, roster[0].User.Nome, "Ana")
+	equal(t, roster[1].Status, domain.EnrollmentDropped)
+
+	evening := domain.Class{Name: "Turma 11", DisciplineID: f.discipline.ID}
+	must(t, repos.Class.Create(&evening))
+	must(t, repos.Enrollment.Create(&domain.Enrollment{ClassID: evening.ClassID, UserID: bia.ID, Role: domain.EnrollmentRoleAssistant, Status: domain.EnrollmentActive, EnrolledAt: at}))
+	mine, err := repos.Enrollment.FindByUser(bia.ID)
+	must(t, err)
+	equal(t, len(mine), 2)
+	equal(t, mine[0].ClassID, f.class.ClassID)
+	equal(t, mine[1].Role, domain.EnrollmentRoleAssistant)
+	rosters, err := repos.Enrollment.FindByClasses([]uint{evening.ClassID, f.class.ClassID})
+	must(t, err)
+	equal(t, len(rosters), 3)
+	equal(t, rosters[0].ClassID, f.class.ClassID)
+	equal(t, rosters[0].User.Nome, "Ana")
+	equal(t, rosters[2].ClassID, evening.ClassID)
+	equal(t, rosters[2].UserID, bia.ID)
+
+	if err := repos.Class.Delete(evening.ClassID); err == nil {
+		t.Error("deleting a class with enrollments should fail")
+	}
+	if err := repos.User.Delete(bia.ID); err == nil {
+		t.Error("deleting an enrolled user should fail")
+	}
+}
+
+// collect gathers everything a StreamAll method emits.
+func collect[T any](t *testing.T, stream func(func(T) error) error) []T {
+	t.Helper()
+	var out []T
+	must(t, stream(func(v T) error { out = append(out, v); return nil }))
+	return out
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func equal(t *testing.T, got, want any) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func expectNotFound(t *testing.T, call func() error) {
+	t.Helper()
+	if err := call(); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+// dateOnly strips the time part Postgres adds when a DATE column is scanned
+// into a string.
+func dateOnly(s string) string {
+	if len(s) > 10 {
+		return s[:10]
+	}
+	return s
+}
+
+func disciplineIDs(ds []domain.Discipline) []uint {
+	var ids []uint
+	for _, d := range ds {
+		ids = append(ids, d.ID)
+	}
+	return ids
+}
+
+func resourceIDs(rs []domain.Resource) []uint {
+	var ids []uint
+	for _, r := range rs {
+		ids = append(ids, r.ResourceID)
+	}
+	return ids
+}
+
+func testEquivalences(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+	var ids []uint
+	for _, name := range []string{"Cálculo A", "Álgebra", "Matemática Básica"} {
+		d := domain.Discipline{Name: name, Credits: 4}
+		must(t, repos.Discipline.Create(&d))
+		ids = append(ids, d.ID)
+	}
+	calculusA, algebra, basics := ids[0], ids[1], ids[2]
+
+	oneToOne := domain.Equivalence{TargetID: calculusA, SourceIDs: []uint{f.discipline.ID}}
+	must(t, repos.Equivalence.Create(&oneToOne))
+	manyToOne := domain.Equivalence{TargetID: basics, SourceIDs: []uint{algebra, f.discipline.ID}}
+	must(t, repos.Equivalence.Create(&manyToOne))
+	if oneToOne.ID == 0 || manyToOne.ID == oneToOne.ID {
+		t.Fatalf("Create assigned IDs %d and %d", oneToOne.ID, manyToOne.ID)
+	}
+
+	got, err := repos.Equivalence.FindByID(manyToOne.ID)
+	must(t, err)
+	equal(t, got, &domain.Equivalence{ID: manyToOne.ID, TargetID: basics, SourceIDs: []uint{f.discipline.ID, algebra}})
+	expectNotFound(t, func() error { _, err := repos.Equivalence.FindByID(9999); return err })
+
+	all, err := repos.Equivalence.FindAll(domain.Page{})
+	must(t, err)
+	equal(t, len(all), 2)
+	equal(t, all[0].ID, oneToOne.ID)
+	byDiscipline, err := repos.Equivalence.FindByDiscipline(algebra, domain.Page{})
+	must(t, err)
+	equal(t, len(byDiscipline), 1)
+	equal(t, byDiscipline[0].ID, manyToOne.ID)
+	byDiscipline, err = repos.Equivalence.FindByDiscipline(f.discipline.ID, domain.Page{})
+	must(t, err)
+	equal(t, len(byDiscipline), 2)
+	// Pages count equivalences, not their source rows
+	all, err = repos.Equivalence.FindAll(domain.Page{Limit: 1, Offset: 1})
+	must(t, err)
+	equal(t, all, []domain.Equivalence{{ID: manyToOne.ID, TargetID: basics, SourceIDs: []uint{f.discipline.ID, algebra}}})
+	byDiscipline, err = repos.Equivalence.FindByDiscipline(f.discipline.ID, domain.Page{Limit: 1})
+	must(t, err)
+	equal(t, len(byDiscipline), 1)
+	equal(t, byDiscipline[0].ID, oneToOne.ID)
+	byTarget, err := repos.Equivalence.FindByTargets([]uint{calculusA, 9999})
+	must(t, err)
+	equal(t, len(byTarget), 1)
+	equal(t, byTarget[0].ID, oneToOne.ID)
+	byTarget, err = repos.Equivalence.FindByTargets(nil)
+	must(t, err)
+	equal(t, len(byTarget), 0)
+
+	if err := repos.Discipline.Delete(algebra); err == nil {
+		t.Error("deleting a discipline used in an equivalence should fail")
+	}
+	must(t, repos.Equivalence.Delete(manyToOne.ID))
+	expectNotFound(t, func() error { _, err := repos.Equivalence.FindByID(manyToOne.ID); return err })
+	must(t, repos.Discipline.Delete(algebra))
+	must(t, repos.Discipline.Delete(basics))
+}
+
+func testGrades(t *testing.T, repos repositories.Repositories) {
+	f := seed(t, repos)
+	exam := domain.Assessment{ClassID: f.class.ClassID, Name: "Prova 1", Weight: 2}
+	must(t, repos.Assessment.Create(&exam))
+	project := domain.Assessment{ClassID: f.class.ClassID, Name: "Trabalho", Weight: 1}
+	must(t, repos.Assessment.Create(&project))
+	if exam.ID == 0 || project.ID == exam.ID {
+		t.Fatalf("Create assigned IDs %d and %d", exam.ID, project.ID)
+	}
+	if err := repos.Assessment.Create(&domain.Assessment{ClassID: 9999, Name: "X", Weight: 1}); err == nil {
+		t.Error("creating an assessment of an unknown class should fail")
+	}
+	exam.Weight = 3
+	must(t, repos.Assessment.Update(&exam))
+	got, err := repos.Assessment.FindByID(exam.ID)
+	must(t, err)
+	equal(t, got, &exam)
+	expectNotFound(t, func() error { _, err := repos.Assessment.FindByID(9999); return err })
+	assessments, err := repos.Assessment.FindByClass(f.class.ClassID)
+	must(t, err)
+	equal(t, assessments, []domain.Assessment{exam, project})
+
+	must(t, repos.Assessment.SetGrade(&domain.Grade{AssessmentID: project.ID, UserID: f.user.ID, Value: 7}))
+	must(t, repos.Assessment.SetGrade(&domain.Grade{AssessmentID: exam.ID, UserID: f.user.ID, Value: 5}))
+	must(t, repos.Assessment.SetGrade(&domain.Grade{AssessmentID: exam.ID, UserID: f.user.ID, Value: 8.5}))
+	if err := repos.Assessment.SetGrade(&domain.Grade{AssessmentID: exam.ID, UserID: 9999, Value: 1}); err == nil {
+		t.Error("grading an unknown user should fail")
+	}
+	grades, err := repos.Assessment.FindGradesByClass(f.class.ClassID)
+	must(t, err)
+	equal(t, grades, []domain.Grade{
+		{AssessmentID: exam.ID, UserID: f.user.ID, Value: 8.5},
+		{AssessmentID: project.ID, UserID: f.user.ID, Value: 7},
+	})
+	must(t, repos.Assessment.Delete(project.ID))
+	grades, err = repos.Assessment.FindGradesByClass(f.class.ClassID)
+	must(t, err)
+	equal(t, len(grades), 1)
+
+	at := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	record := domain.AcademicRecord{UserID: f.user.ID, ClassID: f.class.ClassID, DisciplineID: f.discipline.ID, FinalGrade: 5, Attendance: 80, Status: domain.ResultFailed, CompletedAt: at}
+	must(t, repos.Record.Save(&record))
+	record.FinalGrade, record.Status = 8.5, domain.ResultApproved
+	must(t, repos.Record.Save(&record))
+	if err := repos.Record.Save(&domain.AcademicRecord{UserID: f.user.ID, ClassID: 9999, DisciplineID: f.discipline.ID, Status: domain.ResultApproved, CompletedAt: at}); err == nil {
+		t.Error("saving a record of an unknown class should fail")
+	}
+	records, err := repos.Record.FindByUser(f.user.ID)
+	must(t, err)
+	if len(records) != 1 || !records[0].CompletedAt.Equal(at) || records[0].Discipline == nil {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+	equal(t, records[0].FinalGrade, 8.5)
+	equal(t, records[0].Status, domain.ResultApproved)
+	equal(t, records[0].Discipline.Name, f.discipline.Name)
+	records, err = repos.Record.FindByUser(9999)
+	must(t, err)
+	equal(t, len(records), 0)
+}
@@ -1,10 +1,25 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"sarc/app/cli"
 	"sarc/app/controllers"
+	"sarc/app/middleware"
+	"sarc/core/domain"
 	"sarc/core/services"
 	_ "sarc/docs" // Importa os docs gerados
-	repoimpl "sarc/infrastructure/repositories/SQLimpl"
+	"sarc/infrastructure/notifications"
+	"sarc/pkg/config"
 	"sarc/pkg/db"
 
 	"github.com/joho/godotenv"
@@ -16,49 +31,123 @@ import (
 
 func main() {
 	godotenv.Load()
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Connect to the database
-	db.Connect()
-
-	// Initialize repositories
-	profileRepo := repoimpl.NewProfileRepository(db.DB)
-	userRepo := repoimpl.NewUserRepository(db.DB)
-	buildingRepo := repoimpl.NewBuildingRepository(db.DB)
-	roomRepo := repoimpl.NewRoomRepository(db.DB)
-	disciplineRepo := repoimpl.NewDisciplineRepository(db.DB)
-	curriculumRepo := repoimpl.NewCurriculumRepository(db.DB)
-	classRepo := repoimpl.NewClassRepository(db.DB)
-	lectureRepo := repoimpl.NewLectureRepository(db.DB)
-	resourceRepo := repoimpl.NewResourceRepository(db.DB)
-	reservationsRepo := repoimpl.NewReservationRepository(db.DB)
+	if err := db.Connect(cfg.DB); err != nil {
+		log.Fatal(err)
+	}
+	defer db.DB.Close()
+
+	// Initialize repositories for the configured storage backend
+	repos := db.Repositories()
+	conflictPolicy := domain.ConflictPolicy{SoftLimit: cfg.Conflicts.SoftLimit}
+	importService := services.NewImportService(db.Transactor(), conflictPolicy)
+	searchService := services.NewSearchService(repos.Search)
+	lectureLocation, err := cfg.Lectures.Location()
+	if err != nil {
+		log.Fatal(err)
+	}
+	checkInPolicy, err := newCheckInPolicy(cfg.CheckIn, lectureLocation)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var notifier notifications.Notifier = notifications.NewLogNotifier(nil)
+	if cfg.Attendance.WebhookURL != "" {
+		notifier = notifications.NewWebhookNotifier(cfg.Attendance.WebhookURL)
+	}
+	attendanceService := services.NewAttendanceService(repos, notifier, domain.AttendancePolicy{
+		MinimumPercentage: cfg.Attendance.MinimumPercentage,
+		WarnAbsencesLeft:  cfg.Attendance.WarnAbsencesLeft,
+	}, lectureLocation)
+
+	// Subcommands such as "import" run against the database and exit
+	if len(cfg.Args) > 0 {
+		if err := cli.Run(cfg.Args, cli.Services{Import: importService, Attendance: attendanceService}, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if cfg.Features.SeedDemoData {
+		if err := db.Seed(repos); err != nil {
+			log.Fatal(err)
+		}
+	}
 
 	// Initialize services with repositories
-	buildingService := services.NewBuildingService(buildingRepo)
-	roomService := services.NewRoomService(roomRepo)
-	classService := services.NewClassService(classRepo)
-	curriculumService := services.NewCurriculumService(curriculumRepo)
-	disciplineService := services.NewDisciplineService(disciplineRepo)
-	lectureService := services.NewLectureService(lectureRepo)
-	profileService := services.NewProfileService(profileRepo)
-	resourceService := services.NewResourceService(resourceRepo)
-	userService := services.NewUserService(userRepo)
-	reservationsService := services.NewReservationsService(reservationsRepo)
+	buildingService := services.NewBuildingService(repos.Building, repos.Room)
+	roomService := services.NewRoomService(repos.Room, repos.Lecture)
+	classService := services.NewClassService(repos.Class, repos.Lecture)
+	curriculumService := services.NewCurriculumService(repos.Curriculum, repos.Discipline, db.Transactor(), domain.CreditLimits{
+		MinSemester: cfg.Curriculum.MinSemesterCredits,
+		MaxSemester: cfg.Curriculum.MaxSemesterCredits,
+		MinTotal:    cfg.Curriculum.MinTotalCredits,
+		MaxTotal:    cfg.Curriculum.MaxTotalCredits,
+	})
+	disciplineService := services.NewDisciplineService(repos.Discipline, repos.Class)
+	equivalenceService := services.NewEquivalenceService(repos.Equivalence, repos.Discipline, repos.Curriculum, db.Transactor())
+	gradeService := services.NewGradeService(repos, attendanceService, db.Transactor(), domain.GradingPolicy{
+		MaxGrade:     cfg.Grading.MaxGrade,
+		PassingGrade: cfg.Grading.PassingGrade,
+	})
+	progressService := services.NewProgressService(repos, equivalenceService, cfg.Curriculum.StudyPlanMaxCredits, lectureLocation)
+	lectureService := services.NewLectureService(repos.Lecture, repos.Reservation, repos.Room, repos.Enrollment, domain.CapacityPolicy{
+		SoftLimit:           cfg.Capacity.SoftLimit,
+		UnderusedPercentage: cfg.Capacity.UnderusedPercentage,
+	}, conflictPolicy, lectureLocation)
+	profileService := services.NewProfileService(repos.Profile)
+	resourceService := services.NewResourceService(repos.Resource)
+	resourceTypeService := services.NewResourceTypeService(repos.ResourceType, repos.Resource)
+	userService := services.NewUserService(repos.User)
+	reservationsService := services.NewReservationsService(repos.Reservation)
+	includeService := services.NewIncludeService(repos)
+	presenceService := services.NewPresenceService(repos.Presence, repos.Lecture, repos.User)
+	checkInService := services.NewCheckInService(repos, db.Transactor(), checkInPolicy)
+	enrollmentService := services.NewEnrollmentService(repos.Enrollment, repos.Class, repos.User, repos.Profile, repos.Lecture, conflictPolicy)
+	timetableService := services.NewTimetableService(repos, includeService, lectureLocation)
+	conflictService := services.NewConflictService(repos.Lecture, repos.Enrollment, lectureLocation)
 
 	// Initialize handlers
-	buildingHandler := controllers.NewBuildingHandler(buildingService)
-	roomHandler := controllers.NewRoomHandler(roomService)
-	classHandler := controllers.NewClassHandler(classService)
+	buildingHandler := controllers.NewBuildingHandler(buildingService, includeService)
+	roomHandler := controllers.NewRoomHandler(roomService, includeService)
+	classHandler := controllers.NewClassHandler(classService, includeService)
 	curriculumHandler := controllers.NewCurriculumHandler(curriculumService)
-	disciplineHandler := controllers.NewDisciplineHandler(disciplineService)
-	lectureHandler := controllers.NewLectureHandler(lectureService)
+	disciplineHandler := controllers.NewDisciplineHandler(disciplineService, includeService)
+	equivalenceHandler := controllers.NewEquivalenceHandler(equivalenceService)
+	gradeHandler := controllers.NewGradeHandler(gradeService)
+	progressHandler := controllers.NewProgressHandler(progressService)
+	lectureHandler := controllers.NewLectureHandler(lectureService, includeService)
 	profileHandler := controllers.NewProfileHandler(profileService)
-	resourceHandler := controllers.NewResourceHandler(resourceService)
+	resourceHandler := controllers.NewResourceHandler(resourceService, includeService)
+	resourceTypeHandler := controllers.NewResourceTypeHandler(resourceTypeService, includeService)
 	userHandler := controllers.NewUserHandler(userService)
-	reservationsHandler := controllers.NewReservationsHandler(reservationsService)
+	reservationsHandler := controllers.NewReservationsHandler(reservationsService, includeService)
+	importHandler := controllers.NewImportHandler(importService)
+	searchHandler := controllers.NewSearchHandler(searchService)
+	presenceHandler := controllers.NewPresenceHandler(presenceService)
+	checkInHandler := controllers.NewCheckInHandler(checkInService)
+	attendanceHandler := controllers.NewAttendanceHandler(attendanceService)
+	enrollmentHandler := controllers.NewEnrollmentHandler(enrollmentService)
+	timetableHandler := controllers.NewTimetableHandler(timetableService)
+	conflictHandler := controllers.NewConflictHandler(conflictService)
+	healthHandler := controllers.NewHealthHandler(db.DB)
+	healthHandler.PingTimeout = cfg.DB.PingTimeout
 
 	// Setup Gin router
 	r := gin.Default()
+	r.Use(middleware.CORS(cfg.CORS.AllowedOrigins))
 
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	if cfg.Features.Swagger {
+		r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	}
+
+	// Health routes
+	r.GET("/healthz", healthHandler.Healthz)
+	r.GET("/readyz", healthHandler.Readyz)
 
 	// Building routes
 	r.POST("/buildings", buildingHandler.CreateBuilding)
@@ -66,6 +155,7 @@ func main() {
 	r.GET("/buildings/:id", buildingHandler.GetBuildingByID)
 	r.PUT("/buildings/:id", buildingHandler.UpdateBuilding)
 	r.DELETE("/buildings/:id", buildingHandler.DeleteBuilding)
+	r.GET("/buildings/:id/rooms", buildingHandler.GetBuildingRooms)
 
 	// Room routes (inside building or standalone)
 	r.POST("/rooms", roomHandler.CreateRoom)
@@ -73,6 +163,7 @@ func main() {
 	r.GET("/rooms/:id", roomHandler.GetRoomByID)
 	r.PUT("/rooms/:id", roomHandler.UpdateRoom)
 	r.DELETE("/rooms/:id", roomHandler.DeleteRoom)
+	r.GET("/rooms/:id/lectures", roomHandler.GetRoomLectures)
 
 	// Class routes
 	r.POST("/classes", classHandler.CreateClass)
@@ -80,6 +171,21 @@ func main() {
 	r.GET("/classes/:id", classHandler.GetClassByID)
 	r.PUT("/classes/:id", classHandler.UpdateClass)
 	r.DELETE("/classes/:id", classHandler.DeleteClass)
+	r.GET("/classes/:id/lectures", classHandler.GetClassLectures)
+	r.GET("/classes/:id/attendance", attendanceHandler.GetClassAttendance)
+	r.POST("/classes/:id/attendance/alerts", attendanceHandler.NotifyClassAlerts)
+	r.POST("/classes/:id/enrollments", enrollmentHandler.Enroll)
+	r.GET("/classes/:id/enrollments", enrollmentHandler.GetRoster)
+	r.DELETE("/classes/:id/enrollments/:userId", enrollmentHandler.Drop)
+	r.POST("/classes/:id/assessments", gradeHandler.CreateAssessment)
+	r.GET("/classes/:id/assessments", gradeHandler.GetAssessments)
+	r.GET("/classes/:id/results", gradeHandler.GetClassResults)
+	r.POST("/classes/:id/close", gradeHandler.CloseClass)
+
+	// Assessment routes
+	r.PUT("/assessments/:id", gradeHandler.UpdateAssessment)
+	r.DELETE("/assessments/:id", gradeHandler.DeleteAssessment)
+	r.PUT("/assessments/:id/grades/:userId", gradeHandler.SetGrade)
 
 	// Curriculum routes
 	r.POST("/curriculums", curriculumHandler.CreateCurriculum)
@@ -88,6 +194,17 @@ func main() {
 	r.PUT("/curriculums/:id", curriculumHandler.UpdateCurriculum)
 	r.DELETE("/curriculums/:id", curriculumHandler.DeleteCurriculum)
 	r.POST("/curriculums/:id/disciplines", curriculumHandler.AddDisciplineToCurriculum)
+	r.PUT("/curriculums/:id/disciplines", curriculumHandler.SetDisciplines)
+	r.PUT("/curriculums/:id/disciplines/:disciplineId", curriculumHandler.PlaceDiscipline)
+	r.DELETE("/curriculums/:id/disciplines/:disciplineId", curriculumHandler.RemoveDisciplineFromCurriculum)
+	r.GET("/curriculums/:id/requisites", curriculumHandler.GetRequisites)
+	r.POST("/curriculums/:id/requisites", curriculumHandler.AddRequisite)
+	r.DELETE("/curriculums/:id/requisites/:disciplineId/:requisiteId", curriculumHandler.RemoveRequisite)
+	r.GET("/curriculums/:id/graph", curriculumHandler.GetGraph)
+	r.POST("/curriculums/:id/clone", curriculumHandler.CloneCurriculum)
+	r.GET("/curriculums/:id/versions", curriculumHandler.GetVersions)
+	r.GET("/curriculums/:id/diff/:otherId", curriculumHandler.DiffCurriculums)
+	r.POST("/curriculums/:id/credit-transfer", equivalenceHandler.TransferCredits)
 
 	// Discipline routes
 	r.POST("/disciplines", disciplineHandler.CreateDiscipline)
@@ -95,13 +212,31 @@ func main() {
 	r.GET("/disciplines/:id", disciplineHandler.GetDisciplineByID)
 	r.PUT("/disciplines/:id", disciplineHandler.UpdateDiscipline)
 	r.DELETE("/disciplines/:id", disciplineHandler.DeleteDiscipline)
+	r.GET("/disciplines/:id/classes", disciplineHandler.GetDisciplineClasses)
+
+	// Equivalence routes
+	r.POST("/equivalences", equivalenceHandler.CreateEquivalence)
+	r.GET("/equivalences", equivalenceHandler.GetEquivalences)
+	r.GET("/equivalences/:id", equivalenceHandler.GetEquivalenceByID)
+	r.DELETE("/equivalences/:id", equivalenceHandler.DeleteEquivalence)
 
 	// Lecture routes
 	r.POST("/lectures", lectureHandler.CreateLecture)
 	r.GET("/lectures", lectureHandler.GetLectures)
+	r.GET("/lectures/capacity", lectureHandler.GetCapacityReport)
 	r.GET("/lectures/:id", lectureHandler.GetLectureByID)
 	r.PUT("/lectures/:id", lectureHandler.UpdateLecture)
 	r.DELETE("/lectures/:id", lectureHandler.DeleteLecture)
+	r.GET("/lectures/:id/reservations", lectureHandler.GetLectureReservations)
+
+	// Presence routes
+	r.GET("/lectures/:id/presence", presenceHandler.GetPresence)
+	r.POST("/lectures/:id/presence", presenceHandler.MarkPresence)
+	r.POST("/lectures/:id/presence/bulk", presenceHandler.MarkPresenceBulk)
+	r.DELETE("/lectures/:id/presence/:userId", presenceHandler.UnmarkPresence)
+	r.GET("/lectures/:id/check-in/token", middleware.RequireUser(), checkInHandler.GetCheckInToken)
+	r.GET("/lectures/:id/check-in/qr", middleware.RequireUser(), checkInHandler.GetCheckInQR)
+	r.POST("/lectures/:id/check-in", middleware.RequireUser(), checkInHandler.CheckIn)
 
 	// Profile routes
 	r.POST("/profiles", profileHandler.CreateProfile)
@@ -117,12 +252,27 @@ func main() {
 	r.PUT("/resources/:id", resourceHandler.UpdateResource)
 	r.DELETE("/resources/:id", resourceHandler.DeleteResource)
 
+	// Resource type routes
+	r.POST("/resource-types", resourceTypeHandler.CreateResourceType)
+	r.GET("/resource-types", resourceTypeHandler.GetResourceTypes)
+	r.GET("/resource-types/:id", resourceTypeHandler.GetResourceTypeByID)
+	r.PUT("/resource-types/:id", resourceTypeHandler.UpdateResourceType)
+	r.DELETE("/resource-types/:id", resourceTypeHandler.DeleteResourceType)
+	r.GET("/resource-types/:id/resources", resourceTypeHandler.GetResourcesByType)
+
 	// User routes
 	r.POST("/users", userHandler.CreateUser)
 	r.GET("/users", userHandler.GetUsers)
 	r.GET("/users/:id", userHandler.GetUserByID)
 	r.PUT("/users/:id", userHandler.UpdateUser)
 	r.DELETE("/users/:id", userHandler.DeleteUser)
+	r.GET("/users/:id/attendance", attendanceHandler.GetStudentAttendance)
+	r.GET("/users/:id/enrollments", enrollmentHandler.GetUserEnrollments)
+	r.GET("/users/:id/history", gradeHandler.GetHistory)
+	r.GET("/users/:id/progress", progressHandler.GetProgress)
+	r.GET("/users/:id/study-plan", progressHandler.GetStudyPlan)
+	r.GET("/users/:id/timetable", timetableHandler.GetUserTimetable)
+	r.GET("/me/timetable", middleware.RequireUser(), timetableHandler.GetMyTimetable)
 
 	// Reservations routes
 	r.POST("/reservations", reservationsHandler.CreateReservation)
@@ -132,6 +282,62 @@ func main() {
 	r.DELETE("/reservations/:id", reservationsHandler.DeleteReservation)
 	r.POST("/reservations/:id/resources", reservationsHandler.AddResourceToReservation)
 
+	// Import routes
+	r.POST("/import/:entity", importHandler.Import)
+
+	// Search routes
+	r.GET("/search", searchHandler.Search)
+
+	// Conflict routes
+	r.GET("/conflicts", conflictHandler.GetConflicts)
+
 	// Start server
-	r.Run(":8080")
+	srv := &http.Server{
+		Addr:         ":" + strconv.Itoa(cfg.HTTP.Port),
+		Handler:      r,
+		ReadTimeout:  cfg.HTTP.ReadTimeout,
+		WriteTimeout: cfg.HTTP.WriteTimeout,
+		IdleTimeout:  cfg.HTTP.IdleTimeout,
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Server failed:", err)
+		}
+	}()
+
+	// Wait for SIGINT/SIGTERM, then stop accepting connections and let
+	// in-flight requests finish.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+
+	// Fail readiness first and give load balancers time to notice before
+	// the listener closes.
+	log.Println("Shutting down, draining in-flight requests...")
+	healthHandler.MarkDraining()
+	time.Sleep(cfg.HTTP.DrainDelay)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Println("Forced shutdown:", err)
+	}
+}
+
+// newCheckInPolicy builds the check-in settings for lectures in loc,
+// generating a secret when none is configured.
+func newCheckInPolicy(cfg config.CheckInConfig, loc *time.Location) (domain.CheckInPolicy, error) {
+	secret := []byte(cfg.Secret)
+	if len(secret) == 0 {
+		log.Println("checkIn.secret is not set; using a random secret, check-in tokens will not survive a restart")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return domain.CheckInPolicy{}, err
+		}
+	}
+	return domain.CheckInPolicy{
+		Secret:     secret,
+		Period:     cfg.TokenPeriod,
+		OpenBefore: cfg.OpenBefore,
+		Location:   loc,
+	}, nil
 }
@@ -0,0 +1,594 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	// Embedded so lectures.timezone resolves in images without zoneinfo.
+	_ "time/tzdata"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the complete application configuration. It is built by Load
+// from defaults, then a YAML file, then environment variables, then
+// command-line flags, each layer overriding the previous one.
+type Config struct {
+	HTTP       HTTPConfig       `yaml:"http"`
+	DB         DBConfig         `yaml:"db"`
+	CORS       CORSConfig       `yaml:"cors"`
+	Features   FeatureConfig    `yaml:"features"`
+	Lectures   LectureConfig    `yaml:"lectures"`
+	CheckIn    CheckInConfig    `yaml:"checkIn"`
+	Attendance AttendanceConfig `yaml:"attendance"`
+	Capacity   CapacityConfig   `yaml:"capacity"`
+	Conflicts  ConflictConfig   `yaml:"conflicts"`
+	Curriculum CurriculumConfig `yaml:"curriculum"`
+	Grading    GradingConfig    `yaml:"grading"`
+
+	// Args are the command-line arguments left after the flags, naming a
+	// subcommand such as "import" to run instead of the server.
+	Args []string `yaml:"-"`
+}
+
+type HTTPConfig struct {
+	Port            int           `yaml:"port"`
+	ReadTimeout     time.Duration `yaml:"readTimeout"`
+	WriteTimeout    time.Duration `yaml:"writeTimeout"`
+	IdleTimeout     time.Duration `yaml:"idleTimeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+	// DrainDelay is how long /readyz reports draining before the server
+	// stops accepting connections, so load balancers see it first.
+	DrainDelay time.Duration `yaml:"drainDelay"`
+}
+
+type DBConfig struct {
+	// Driver selects the storage backend: "postgres" or "sqlite".
+	Driver string `yaml:"driver"`
+	// Path is the database file used by the sqlite driver.
+	Path     string `yaml:"path"`
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	// PasswordFile is read into Password when set, e.g. /run/secrets/db_password.
+	PasswordFile    string        `yaml:"passwordFile"`
+	Name            string        `yaml:"name"`
+	SSLMode         string        `yaml:"sslMode"`
+	MaxOpenConns    int           `yaml:"maxOpenConns"`
+	MaxIdleConns    int           `yaml:"maxIdleConns"`
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime"`
+	ConnectAttempts int           `yaml:"connectAttempts"`
+	PingTimeout     time.Duration `yaml:"pingTimeout"`
+}
+
+type CORSConfig struct {
+	AllowedOrigins []string `yaml:"allowedOrigins"`
+}
+
+type FeatureConfig struct {
+	// Swagger serves the API docs under /swagger.
+	Swagger bool `yaml:"swagger"`
+	// SeedDemoData wipes every table and loads the demo data set on startup.
+	SeedDemoData bool `yaml:"seedDemoData"`
+}
+
+type LectureConfig struct {
+	// Timezone is the IANA zone lecture dates and times are in. Empty uses
+	// the server's local zone.
+	Timezone string `yaml:"timezone"`
+}
+
+// Location returns the zone named by Timezone.
+func (c LectureConfig) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(c.Timezone)
+}
+
+type CheckInConfig struct {
+	// Secret signs the QR check-in tokens. When empty a random secret is
+	// generated on startup, so tokens do not survive a restart and are not
+	// shared between replicas.
+	Secret string `yaml:"secret"`
+	// SecretFile is read into Secret when set.
+	SecretFile string `yaml:"secretFile"`
+	// TokenPeriod is how often the token rotates. A token is accepted during
+	// the period it was issued in and the one after.
+	TokenPeriod time.Duration `yaml:"tokenPeriod"`
+	// OpenBefore is how early before a lecture starts check-in opens.
+	OpenBefore time.Duration `yaml:"openBefore"`
+}
+
+type AttendanceConfig struct {
+	// MinimumPercentage of a class's lectures a student must attend.
+	MinimumPercentage float64 `yaml:"minimumPercentage"`
+	// WarnAbsencesLeft flags students who can miss this many lectures or
+	// fewer before failing.
+	WarnAbsencesLeft int `yaml:"warnAbsencesLeft"`
+	// WebhookURL receives attendance alerts as JSON. When empty alerts are
+	// only logged.
+	WebhookURL string `yaml:"webhookURL"`
+}
+
+type CapacityConfig struct {
+	// SoftLimit accepts lectures in rooms too small for the class, with a
+	// warning, instead of rejecting them.
+	SoftLimit bool `yaml:"softLimit"`
+	// UnderusedPercentage flags upcoming lectures whose class fills less
+	// than this share of the room's seats.
+	UnderusedPercentage float64 `yaml:"underusedPercentage"`
+}
+
+type ConflictConfig struct {
+	// SoftLimit accepts lectures and enrollments that put a user in two
+	// lectures at once, with a warning, instead of rejecting them.
+	SoftLimit bool `yaml:"softLimit"`
+}
+
+// CurriculumConfig bounds the credits of a curriculum. Zero means no limit.
+type CurriculumConfig struct {
+	// MinSemesterCredits and MaxSemesterCredits apply to the mandatory
+	// credits of each semester.
+	MinSemesterCredits int `yaml:"minSemesterCredits"`
+	MaxSemesterCredits int `yaml:"maxSemesterCredits"`
+	// MinTotalCredits and MaxTotalCredits apply to the whole curriculum,
+	// electives included.
+	MinTotalCredits int `yaml:"minTotalCredits"`
+	MaxTotalCredits int `yaml:"maxTotalCredits"`
+	// StudyPlanMaxCredits caps the credits of a student's suggested study
+	// plan when the request does not set one.
+	StudyPlanMaxCredits int `yaml:"studyPlanMaxCredits"`
+}
+
+// GradingConfig sets the grade scale.
+type GradingConfig struct {
+	// MaxGrade is the highest grade an assessment can get.
+	MaxGrade float64 `yaml:"maxGrade"`
+	// PassingGrade is the lowest final grade that approves a student.
+	PassingGrade float64 `yaml:"passingGrade"`
+}
+
+var sslModes = map[string]bool{
+	"disable": true, "allow": true, "prefer": true, "require": true, "verify-ca": true, "verify-full": true,
+}
+
+// Default returns the configuration used when nothing else is set.
+func Default() Config {
+	return Config{
+		HTTP: HTTPConfig{
+			Port:            8080,
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    30 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			ShutdownTimeout: 15 * time.Second,
+			DrainDelay:      5 * time.Second,
+		},
+		DB: DBConfig{
+			Driver:          "postgres",
+			Path:            "sarc.db",
+			Host:            "localhost",
+			Port:            5432,
+			User:            "postgres",
+			Name:            "postgres",
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnectAttempts: 10,
+			PingTimeout:     2 * time.Second,
+		},
+		Features: FeatureConfig{
+			Swagger:      true,
+			SeedDemoData: true,
+		},
+		CheckIn: CheckInConfig{
+			TokenPeriod: 30 * time.Second,
+			OpenBefore:  15 * time.Minute,
+		},
+		Attendance: AttendanceConfig{
+			MinimumPercentage: 75,
+			WarnAbsencesLeft:  2,
+		},
+		Capacity: CapacityConfig{
+			UnderusedPercentage: 40,
+		},
+		Curriculum: CurriculumConfig{
+			MaxSemesterCredits:  30,
+			StudyPlanMaxCredits: 30,
+		},
+		Grading: GradingConfig{
+			MaxGrade:     10,
+			PassingGrade: 6,
+		},
+	}
+}
+
+// Load builds the configuration from args (usually os.Args[1:]) and the
+// process environment and validates it.
+func Load(args []string) (*Config, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("sarc", flag.ContinueOnError)
+	configPath := fs.String("config", os.Getenv("SARC_CONFIG"), "path to a YAML configuration file")
+	port := fs.Int("port", 0, "HTTP port")
+	dbDriver := fs.String("db-driver", "", "storage backend: postgres or sqlite")
+	dbPath := fs.String("db-path", "", "database file for the sqlite driver")
+	dbHost := fs.String("db-host", "", "database host")
+	dbPort := fs.Int("db-port", 0, "database port")
+	dbName := fs.String("db-name", "", "database name")
+	dbUser := fs.String("db-user", "", "database user")
+	dbSSLMode := fs.String("db-sslmode", "", "database TLS mode (disable, require, verify-ca, verify-full, ...)")
+	dbMaxOpen := fs.Int("db-max-open-conns", 0, "maximum open database connections")
+	dbMaxIdle := fs.Int("db-max-idle-conns", 0, "maximum idle database connections")
+	corsOrigins := fs.String("cors-origins", "", "comma-separated list of allowed CORS origins")
+	seed := fs.Bool("seed", false, "wipe and seed the database with demo data on startup")
+	swagger := fs.Bool("swagger", false, "serve the Swagger UI")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *configPath != "" {
+		if err := cfg.loadFile(*configPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+
+	// Only flags given explicitly override the lower layers.
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "port":
+			cfg.HTTP.Port = *port
+		case "db-driver":
+			cfg.DB.Driver = *dbDriver
+		case "db-path":
+			cfg.DB.Path = *dbPath
+		case "db-host":
+			cfg.DB.Host = *dbHost
+		case "db-port":
+			cfg.DB.Port = *dbPort
+		case "db-name":
+			cfg.DB.Name = *dbName
+		case "db-user":
+			cfg.DB.User = *dbUser
+		case "db-sslmode":
+			cfg.DB.SSLMode = *dbSSLMode
+		case "db-max-open-conns":
+			cfg.DB.MaxOpenConns = *dbMaxOpen
+		case "db-max-idle-conns":
+			cfg.DB.MaxIdleConns = *dbMaxIdle
+		case "cors-origins":
+			cfg.CORS.AllowedOrigins = splitList(*corsOrigins)
+		case "seed":
+			cfg.Features.SeedDemoData = *seed
+		case "swagger":
+			cfg.Features.Swagger = *swagger
+		}
+	})
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	cfg.Args = fs.Args()
+	return &cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if c.DB.PasswordFile != "" {
+		password, err := readSecret(c.DB.PasswordFile)
+		if err != nil {
+			return err
+		}
+		c.DB.Password = password
+	}
+	if c.CheckIn.SecretFile != "" {
+		secret, err := readSecret(c.CheckIn.SecretFile)
+		if err != nil {
+			return err
+		}
+		c.CheckIn.Secret = secret
+	}
+	return nil
+}
+
+// loadEnv applies environment variables. Every variable NAME can also be
+// given as NAME_FILE pointing to a file holding the value, which is how
+// Docker and Kubernetes secrets are mounted.
+func (c *Config) loadEnv() error {
+	var errs []error
+	str := func(name string, dst *string) {
+		if v, ok, err := lookupEnv(name); err != nil {
+			errs = append(errs, err)
+		} else if ok {
+			*dst = v
+		}
+	}
+	num := func(name string, dst *int) {
+		if v, ok, err := lookupEnv(name); err != nil {
+			errs = append(errs, err)
+		} else if ok {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				return
+			}
+			*dst = n
+		}
+	}
+	float := func(name string, dst *float64) {
+		if v, ok, err := lookupEnv(name); err != nil {
+			errs = append(errs, err)
+		} else if ok {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				return
+			}
+			*dst = f
+		}
+	}
+	dur := func(name string, dst *time.Duration) {
+		if v, ok, err := lookupEnv(name); err != nil {
+			errs = append(errs, err)
+		} else if ok {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				return
+			}
+			*dst = d
+		}
+	}
+	boolean := func(name string, dst *bool) {
+		if v, ok, err := lookupEnv(name); err != nil {
+			errs = append(errs, err)
+		} else if ok {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				return
+			}
+			*dst = b
+		}
+	}
+
+	num("HTTP_PORT", &c.HTTP.Port)
+	dur("HTTP_READ_TIMEOUT", &c.HTTP.ReadTimeout)
+	dur("HTTP_WRITE_TIMEOUT", &c.HTTP.WriteTimeout)
+	dur("HTTP_IDLE_TIMEOUT", &c.HTTP.IdleTimeout)
+	dur("HTTP_SHUTDOWN_TIMEOUT", &c.HTTP.ShutdownTimeout)
+	dur("HTTP_DRAIN_DELAY", &c.HTTP.DrainDelay)
+
+	str("DB_DRIVER", &c.DB.Driver)
+	str("DB_PATH", &c.DB.Path)
+	str("DB_HOST", &c.DB.Host)
+	num("DB_PORT", &c.DB.Port)
+	str("DB_USER", &c.DB.User)
+	str("DB_PASSWORD", &c.DB.Password)
+	str("DB_NAME", &c.DB.Name)
+	str("DB_SSLMODE", &c.DB.SSLMode)
+	num("DB_MAX_OPEN_CONNS", &c.DB.MaxOpenConns)
+	num("DB_MAX_IDLE_CONNS", &c.DB.MaxIdleConns)
+	dur("DB_CONN_MAX_LIFETIME", &c.DB.ConnMaxLifetime)
+	num("DB_CONNECT_ATTEMPTS", &c.DB.ConnectAttempts)
+	dur("DB_PING_TIMEOUT", &c.DB.PingTimeout)
+
+	var origins string
+	str("CORS_ALLOWED_ORIGINS", &origins)
+	if origins != "" {
+		c.CORS.AllowedOrigins = splitList(origins)
+	}
+
+	boolean("FEATURE_SWAGGER", &c.Features.Swagger)
+	boolean("FEATURE_SEED_DEMO_DATA", &c.Features.SeedDemoData)
+
+	str("LECTURES_TIMEZONE", &c.Lectures.Timezone)
+
+	str("CHECKIN_SECRET", &c.CheckIn.Secret)
+	dur("CHECKIN_TOKEN_PERIOD", &c.CheckIn.TokenPeriod)
+	dur("CHECKIN_OPEN_BEFORE", &c.CheckIn.OpenBefore)
+
+	float("ATTENDANCE_MINIMUM_PERCENTAGE", &c.Attendance.MinimumPercentage)
+	num("ATTENDANCE_WARN_ABSENCES_LEFT", &c.Attendance.WarnAbsencesLeft)
+	str("ATTENDANCE_WEBHOOK_URL", &c.Attendance.WebhookURL)
+
+	boolean("CAPACITY_SOFT_LIMIT", &c.Capacity.SoftLimit)
+	float("CAPACITY_UNDERUSED_PERCENTAGE", &c.Capacity.UnderusedPercentage)
+
+	boolean("CONFLICTS_SOFT_LIMIT", &c.Conflicts.SoftLimit)
+
+	num("CURRICULUM_MIN_SEMESTER_CREDITS", &c.Curriculum.MinSemesterCredits)
+	num("CURRICULUM_MAX_SEMESTER_CREDITS", &c.Curriculum.MaxSemesterCredits)
+	num("CURRICULUM_MIN_TOTAL_CREDITS", &c.Curriculum.MinTotalCredits)
+	num("CURRICULUM_MAX_TOTAL_CREDITS", &c.Curriculum.MaxTotalCredits)
+	num("CURRICULUM_STUDY_PLAN_MAX_CREDITS", &c.Curriculum.StudyPlanMaxCredits)
+
+	float("GRADING_MAX_GRADE", &c.Grading.MaxGrade)
+	float("GRADING_PASSING_GRADE", &c.Grading.PassingGrade)
+
+	return errors.Join(errs...)
+}
+
+// Validate reports every invalid setting at once.
+func (c *Config) Validate() error {
+	var errs []error
+	if c.HTTP.Port < 1 || c.HTTP.Port > 65535 {
+		errs = append(errs, fmt.Errorf("http.port must be between 1 and 65535, got %d", c.HTTP.Port))
+	}
+	if c.HTTP.DrainDelay < 0 {
+		errs = append(errs, errors.New("http.drainDelay cannot be negative"))
+	}
+	for name, d := range map[string]time.Duration{
+		"http.readTimeout":     c.HTTP.ReadTimeout,
+		"http.writeTimeout":    c.HTTP.WriteTimeout,
+		"http.idleTimeout":     c.HTTP.IdleTimeout,
+		"http.shutdownTimeout": c.HTTP.ShutdownTimeout,
+		"db.pingTimeout":       c.DB.PingTimeout,
+		"checkIn.tokenPeriod":  c.CheckIn.TokenPeriod,
+	} {
+		if d <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", name))
+		}
+	}
+
+	switch c.DB.Driver {
+	case "postgres":
+		if c.DB.Host == "" {
+			errs = append(errs, errors.New("db.host is required"))
+		}
+		if c.DB.Port < 1 || c.DB.Port > 65535 {
+			errs = append(errs, fmt.Errorf("db.port must be between 1 and 65535, got %d", c.DB.Port))
+		}
+		if c.DB.User == "" {
+			errs = append(errs, errors.New("db.user is required"))
+		}
+		if c.DB.Name == "" {
+			errs = append(errs, errors.New("db.name is required"))
+		}
+		if !sslModes[c.DB.SSLMode] {
+			errs = append(errs, fmt.Errorf("db.sslMode %q is not a valid Postgres sslmode", c.DB.SSLMode))
+		}
+	case "sqlite":
+		if c.DB.Path == "" {
+			errs = append(errs, errors.New("db.path is required for the sqlite driver"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("db.driver must be postgres or sqlite, got %q", c.DB.Driver))
+	}
+	if c.DB.MaxOpenConns < 0 || c.DB.MaxIdleConns < 0 {
+		errs = append(errs, errors.New("db pool sizes cannot be negative"))
+	}
+	if c.DB.MaxOpenConns > 0 && c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("db.maxIdleConns (%d) cannot exceed db.maxOpenConns (%d)", c.DB.MaxIdleConns, c.DB.MaxOpenConns))
+	}
+	if c.DB.ConnectAttempts < 1 {
+		errs = append(errs, errors.New("db.connectAttempts must be at least 1"))
+	}
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			errs = append(errs, fmt.Errorf("cors origin %q must look like scheme://host[:port]", origin))
+		}
+	}
+
+	if c.CheckIn.Secret != "" && len(c.CheckIn.Secret) < 16 {
+		errs = append(errs, errors.New("checkIn.secret must be at least 16 characters"))
+	}
+	if c.CheckIn.OpenBefore < 0 {
+		errs = append(errs, errors.New("checkIn.openBefore cannot be negative"))
+	}
+	if _, err := c.Lectures.Location(); err != nil {
+		errs = append(errs, fmt.Errorf("lectures.timezone: %w", err))
+	}
+
+	if c.Attendance.MinimumPercentage <= 0 || c.Attendance.MinimumPercentage > 100 {
+		errs = append(errs, fmt.Errorf("attendance.minimumPercentage must be above 0 and at most 100, got %g", c.Attendance.MinimumPercentage))
+	}
+	if c.Attendance.WarnAbsencesLeft < 0 {
+		errs = append(errs, errors.New("attendance.warnAbsencesLeft cannot be negative"))
+	}
+	if c.Attendance.WebhookURL != "" {
+		u, err := url.Parse(c.Attendance.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("attendance.webhookURL %q must be an http or https URL", c.Attendance.WebhookURL))
+		}
+	}
+	if c.Capacity.UnderusedPercentage < 0 || c.Capacity.UnderusedPercentage > 100 {
+		errs = append(errs, fmt.Errorf("capacity.underusedPercentage must be between 0 and 100, got %g", c.Capacity.UnderusedPercentage))
+	}
+	for _, limit := range []struct {
+		name     string
+		min, max int
+	}{
+		{"SemesterCredits", c.Curriculum.MinSemesterCredits, c.Curriculum.MaxSemesterCredits},
+		{"TotalCredits", c.Curriculum.MinTotalCredits, c.Curriculum.MaxTotalCredits},
+	} {
+		if limit.min < 0 || limit.max < 0 {
+			errs = append(errs, fmt.Errorf("curriculum.min%s and curriculum.max%s cannot be negative", limit.name, limit.name))
+		} else if limit.max > 0 && limit.min > limit.max {
+			errs = append(errs, fmt.Errorf("curriculum.min%s (%d) cannot exceed curriculum.max%s (%d)", limit.name, limit.min, limit.name, limit.max))
+		}
+	}
+	if c.Curriculum.StudyPlanMaxCredits < 0 {
+		errs = append(errs, fmt.Errorf("curriculum.studyPlanMaxCredits cannot be negative, got %d", c.Curriculum.StudyPlanMaxCredits))
+	}
+	if c.Grading.MaxGrade <= 0 {
+		errs = append(errs, fmt.Errorf("grading.maxGrade must be above 0, got %g", c.Grading.MaxGrade))
+	} else if c.Grading.PassingGrade <= 0 || c.Grading.PassingGrade > c.Grading.MaxGrade {
+		errs = append(errs, fmt.Errorf("grading.passingGrade must be above 0 and at most grading.maxGrade (%g), got %g", c.Grading.MaxGrade, c.Grading.PassingGrade))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// DSN builds the Postgres connection URL.
+func (c DBConfig) DSN() string {
+	return c.url().String()
+}
+
+// RedactedDSN is DSN with the password masked, safe for logs.
+func (c DBConfig) RedactedDSN() string {
+	return c.url().Redacted()
+}
+
+func (c DBConfig) url() *url.URL {
+	return &url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+		Path:     "/" + c.Name,
+		RawQuery: url.Values{"sslmode": {c.SSLMode}}.Encode(),
+	}
+}
+
+func lookupEnv(name string) (string, bool, error) {
+	if path, ok := os.LookupEnv(name + "_FILE"); ok && path != "" {
+		v, err := readSecret(path)
+		return v, err == nil, err
+	}
+	v, ok := os.LookupEnv(name)
+	return v, ok && v != "", nil
+}
+
+func readSecret(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
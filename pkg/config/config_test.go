@@ -0,0 +1,232 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"sarc/pkg/config"
+)
+
+// load runs Load with a config file holding yaml (none when empty), the
+// given environment and args.
+func load(t *testing.T, yaml string, env map[string]string, args ...string) (*config.Config, error) {
+	t.Helper()
+	t.Setenv("SARC_CONFIG", "")
+	if yaml != "" {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		args = append([]string{"-config", path}, args...)
+	}
+	for name, value := range env {
+		t.Setenv(name, value)
+	}
+	return config.Load(args)
+}
+
+// secret writes value to a file and returns its path.
+func secret(t *testing.T, value string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte(value), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	const file = "http:\n  port: 9000\ndb:\n  driver: sqlite\n  path: file.db\n"
+	tests := []struct {
+		name       string
+		yaml       string
+		env        map[string]string
+		args       []string
+		wantPort   int
+		wantDriver string
+		wantPath   string
+	}{
+		{name: "defaults", wantPort: 8080, wantDriver: "postgres", wantPath: "sarc.db"},
+		{name: "file over defaults", yaml: file, wantPort: 9000, wantDriver: "sqlite", wantPath: "file.db"},
+		{name: "env over file", yaml: file, env: map[string]string{"HTTP_PORT": "9100", "DB_PATH": "env.db"}, wantPort: 9100, wantDriver: "sqlite", wantPath: "env.db"},
+		{name: "flags over env", yaml: file, env: map[string]string{"HTTP_PORT": "9100", "DB_PATH": "env.db"}, args: []string{"-port", "9200", "-db-path", "flag.db"}, wantPort: 9200, wantDriver: "sqlite", wantPath: "flag.db"},
+		{name: "only given flags override", yaml: file, env: map[string]string{"HTTP_PORT": "9100"}, args: []string{"-db-path", "flag.db"}, wantPort: 9100, wantDriver: "sqlite", wantPath: "flag.db"},
+		{name: "empty env is unset", yaml: file, env: map[string]string{"HTTP_PORT": ""}, wantPort: 9000, wantDriver: "sqlite", wantPath: "file.db"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := load(t, tt.yaml, tt.env, tt.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.HTTP.Port != tt.wantPort || cfg.DB.Driver != tt.wantDriver || cfg.DB.Path != tt.wantPath {
+				t.Errorf("got port %d, driver %q, path %q; want %d, %q, %q",
+					cfg.HTTP.Port, cfg.DB.Driver, cfg.DB.Path, tt.wantPort, tt.wantDriver, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestLoadSecretFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    func(t *testing.T) string
+		env     func(t *testing.T) map[string]string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "env file is read and trimmed",
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{"DB_PASSWORD_FILE": secret(t, "s3cret\n")}
+			},
+			want: "s3cret",
+		},
+		{
+			name: "env file wins over the plain variable",
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{"DB_PASSWORD": "plain", "DB_PASSWORD_FILE": secret(t, "from-file")}
+			},
+			want: "from-file",
+		},
+		{
+			name: "passwordFile in the config file",
+			yaml: func(t *testing.T) string {
+				return "db:\n  password: inline\n  passwordFile: " + secret(t, "from-yaml") + "\n"
+			},
+			want: "from-yaml",
+		},
+		{
+			name: "env overrides the config file's secret",
+			yaml: func(t *testing.T) string { return "db:\n  passwordFile: " + secret(t, "from-yaml") + "\n" },
+			env:  func(t *testing.T) map[string]string { return map[string]string{"DB_PASSWORD": "from-env"} },
+			want: "from-env",
+		},
+		{
+			name: "missing env file",
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{"DB_PASSWORD_FILE": filepath.Join(t.TempDir(), "missing")}
+			},
+			wantErr: "failed to read secret file",
+		},
+		{
+			name: "missing file in the config file",
+			yaml: func(t *testing.T) string {
+				return "checkIn:\n  secretFile: " + filepath.Join(t.TempDir(), "missing") + "\n"
+			},
+			wantErr: "failed to read secret file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var yaml string
+			if tt.yaml != nil {
+				yaml = tt.yaml(t)
+			}
+			var env map[string]string
+			if tt.env != nil {
+				env = tt.env(t)
+			}
+			cfg, err := load(t, yaml, env)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.DB.Password != tt.want {
+				t.Errorf("got password %q, want %q", cfg.DB.Password, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadRejectsMalformedEnv(t *testing.T) {
+	_, err := load(t, "", map[string]string{"HTTP_PORT": "eighty", "CHECKIN_TOKEN_PERIOD": "soon"})
+	if err == nil || !strings.Contains(err.Error(), "HTTP_PORT") || !strings.Contains(err.Error(), "CHECKIN_TOKEN_PERIOD") {
+		t.Fatalf("expected both variables reported, got %v", err)
+	}
+}
+
+func TestLoadLectureTimezone(t *testing.T) {
+	cfg, err := load(t, "lectures:\n  timezone: America/Sao_Paulo\n", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Lectures.Timezone != "America/Sao_Paulo" {
+		t.Errorf("from the file: got %q", cfg.Lectures.Timezone)
+	}
+	cfg, err = load(t, "lectures:\n  timezone: America/Sao_Paulo\n", map[string]string{"LECTURES_TIMEZONE": "UTC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Lectures.Timezone != "UTC" {
+		t.Errorf("from the environment: got %q", cfg.Lectures.Timezone)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(c *config.Config)
+		wantErr []string
+	}{
+		{name: "defaults", change: func(c *config.Config) {}},
+		{name: "sqlite needs no Postgres settings", change: func(c *config.Config) {
+			c.DB.Driver, c.DB.Host, c.DB.User, c.DB.SSLMode = "sqlite", "", "", ""
+		}},
+		{name: "sqlite without a path", change: func(c *config.Config) { c.DB.Driver, c.DB.Path = "sqlite", "" },
+			wantErr: []string{"db.path is required"}},
+		{name: "unknown driver", change: func(c *config.Config) { c.DB.Driver = "mysql" },
+			wantErr: []string{"db.driver must be postgres or sqlite"}},
+		{name: "postgres settings", change: func(c *config.Config) { c.DB.Host, c.DB.SSLMode = "", "sometimes" },
+			wantErr: []string{"db.host is required", "db.sslMode"}},
+		{name: "port range", change: func(c *config.Config) { c.HTTP.Port = 70000 },
+			wantErr: []string{"http.port"}},
+		{name: "drain delay", change: func(c *config.Config) { c.HTTP.DrainDelay = -time.Second },
+			wantErr: []string{"http.drainDelay"}},
+		{name: "pool sizes", change: func(c *config.Config) { c.DB.MaxOpenConns, c.DB.MaxIdleConns = 2, 5 },
+			wantErr: []string{"db.maxIdleConns (5) cannot exceed db.maxOpenConns (2)"}},
+		{name: "cors origin", change: func(c *config.Config) { c.CORS.AllowedOrigins = []string{"*", "example.com"} },
+			wantErr: []string{`cors origin "example.com"`}},
+		{name: "short check-in secret", change: func(c *config.Config) { c.CheckIn.Secret = "short" },
+			wantErr: []string{"checkIn.secret"}},
+		{name: "unknown timezone", change: func(c *config.Config) { c.Lectures.Timezone = "Mars/Olympus" },
+			wantErr: []string{"lectures.timezone"}},
+		{name: "webhook URL", change: func(c *config.Config) { c.Attendance.WebhookURL = "ftp://example.com" },
+			wantErr: []string{"attendance.webhookURL"}},
+		{name: "credit limits", change: func(c *config.Config) {
+			c.Curriculum.MinSemesterCredits, c.Curriculum.MaxSemesterCredits = 40, 30
+			c.Curriculum.StudyPlanMaxCredits = -1
+		}, wantErr: []string{"curriculum.minSemesterCredits (40) cannot exceed", "curriculum.studyPlanMaxCredits"}},
+		{name: "grades", change: func(c *config.Config) { c.Grading.PassingGrade = 11 },
+			wantErr: []string{"grading.passingGrade"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default()
+			tt.change(&cfg)
+			err := cfg.Validate()
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected errors %q, got none", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+}
@@ -1,304 +1,114 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
-	"os"
+	"time"
 
-	"sarc/core/domain"
-	"sarc/core/services"
 	repoimpl "sarc/infrastructure/repositories/SQLimpl"
+	sqliteimpl "sarc/infrastructure/repositories/SQLiteimpl"
+	repositories "sarc/infrastructure/repositories/interfaces"
+	"sarc/pkg/config"
 
 	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
 )
 
-var DB *sql.DB
-
-func Connect() {
-	var err error
-	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_NAME"),
-		os.Getenv("DB_PORT"),
-	)
-	fmt.Println("DSN:", dsn)
-	DB, err = sql.Open("postgres", dsn)
-	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
-	}
-
-	// Migrate tables with correct PK/FK names matching domain models
-	_, err = DB.Exec(`
-        CREATE TABLE IF NOT EXISTS profiles (
-            profile_id SERIAL PRIMARY KEY,
-            role TEXT NOT NULL
-        );
-
-        CREATE TABLE IF NOT EXISTS users (
-            user_id SERIAL PRIMARY KEY,
-            email TEXT,
-            nome TEXT,
-            birth_date DATE,
-            sex TEXT,
-            telephone TEXT,
-            profile_id INTEGER REFERENCES profiles(profile_id)
-        );
-
-        CREATE TABLE IF NOT EXISTS buildings (
-            building_id SERIAL PRIMARY KEY,
-            building_name TEXT,
-            address TEXT
-        );
-
-        CREATE TABLE IF NOT EXISTS rooms (
-            room_id SERIAL PRIMARY KEY,
-            room_number TEXT,
-            building_id INTEGER REFERENCES buildings(building_id),
-            room_capacity INTEGER,
-            floor INTEGER
-        );
-
-        CREATE TABLE IF NOT EXISTS disciplines (
-            discipline_id SERIAL PRIMARY KEY,
-            name TEXT,
-            credits INTEGER,
-            program TEXT,
-            bibliography TEXT[]
-        );
-
-        CREATE TABLE IF NOT EXISTS curriculums (
-            curriculum_id SERIAL PRIMARY KEY,
-            course_name TEXT,
-            data_inicio DATE,
-            data_fim DATE
-        );
-
-        CREATE TABLE IF NOT EXISTS curriculum_disciplines (
-            curriculum_id INTEGER REFERENCES curriculums(curriculum_id),
-            discipline_id INTEGER REFERENCES disciplines(discipline_id),
-            PRIMARY KEY (curriculum_id, discipline_id)
-        );
-
-        CREATE TABLE IF NOT EXISTS classes (
-            class_id SERIAL PRIMARY KEY,
-            name TEXT,
-            description TEXT,
-            discipline_id INTEGER REFERENCES disciplines(discipline_id)
-        );
-
-        CREATE TABLE IF NOT EXISTS lectures (
-            lecture_id SERIAL PRIMARY KEY,
-            class_id INTEGER REFERENCES classes(class_id),
-            room_id INTEGER REFERENCES rooms(room_id),
-            date DATE,
-            content TEXT[]
-        );
-
-        CREATE TABLE IF NOT EXISTS resource_types (
-            resource_type_id SERIAL PRIMARY KEY,
-            name TEXT
-        );
-
-        CREATE TABLE IF NOT EXISTS resources (
-            resource_id SERIAL PRIMARY KEY,
-            description TEXT,
-            status TEXT,
-            characteristics TEXT[],
-            resource_type_id INTEGER REFERENCES resource_types(resource_type_id)
-        );
-
-        CREATE TABLE IF NOT EXISTS reservations (
-            reservation_id SERIAL PRIMARY KEY,
-            lecture_id INTEGER REFERENCES lectures(lecture_id),
-            observation TEXT
-        );
-
-        CREATE TABLE IF NOT EXISTS reservation_resources (
-            reservation_id INTEGER REFERENCES reservations(reservation_id),
-            resource_id INTEGER REFERENCES resources(resource_id),
-            PRIMARY KEY (reservation_id, resource_id)
-        );
-    `)
-	if err != nil {
-		log.Fatal("Failed to migrate tables:", err)
-	}
-
-	// --- Clear all tables before seeding ---
-	_, err = DB.Exec(`
-        TRUNCATE TABLE
-            reservation_resources,
-            reservations,
-            resources,
-            resource_types,
-            lectures,
-            classes,
-            curriculum_disciplines,
-            curriculums,
-            disciplines,
-            rooms,
-            buildings,
-            users,
-            profiles
-        RESTART IDENTITY CASCADE;
-    `)
-	if err != nil {
-		log.Fatal("Failed to clear tables:", err)
-	}
-
-	// Instantiate repositories
-	profileRepo := repoimpl.NewProfileRepository(DB)
-	userRepo := repoimpl.NewUserRepository(DB)
-	buildingRepo := repoimpl.NewBuildingRepository(DB)
-	roomRepo := repoimpl.NewRoomRepository(DB)
-	disciplineRepo := repoimpl.NewDisciplineRepository(DB)
-	curriculumRepo := repoimpl.NewCurriculumRepository(DB)
-	classRepo := repoimpl.NewClassRepository(DB)
-	lectureRepo := repoimpl.NewLectureRepository(DB)
-	resourceTypeRepo := repoimpl.NewResourceTypeRepository(DB)
-	resourceRepo := repoimpl.NewResourceRepository(DB)
-	reservationRepo := repoimpl.NewReservationRepository(DB)
-
-	// Instantiate services
-	profileService := services.NewProfileService(profileRepo)
-	userService := services.NewUserService(userRepo)
-	buildingService := services.NewBuildingService(buildingRepo)
-	roomService := services.NewRoomService(roomRepo)
-	disciplineService := services.NewDisciplineService(disciplineRepo)
-	curriculumService := services.NewCurriculumService(curriculumRepo)
-	classService := services.NewClassService(classRepo)
-	lectureService := services.NewLectureService(lectureRepo)
-	resourceService := services.NewResourceService(resourceRepo)
-	reservationService := services.NewReservationsService(reservationRepo)
-
-	// --- Seed data using services ---
-
-	// Profile
-	profile := &domain.Profile{Role: "admin"}
-	_, err = profileService.CreateProfile(profile)
-	if err != nil {
-		log.Fatal("Failed to seed profile:", err)
-	}
+// Supported values of config.DBConfig.Driver.
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
 
-	// User
-	user := &domain.User{
-		Email:     "admin@example.com",
-		Nome:      "Admin",
-		BirthDate: "1990-01-01",
-		Sex:       "M",
-		Telephone: "123456789",
-		ProfileID: 1,
-	}
-	_, err = userService.CreateUser(user)
-	if err != nil {
-		log.Fatal("Failed to seed user:", err)
-	}
+var DB *sql.DB
 
-	// Building
-	building := &domain.Building{
-		BuildingName: "Main Building",
-		Address:      "123 Main St",
-	}
-	_, err = buildingService.CreateBuilding(building)
-	if err != nil {
-		log.Fatal("Failed to seed building:", err)
-	}
+// Driver is the driver DB was opened with.
+var Driver string
 
-	// Room
-	room := &domain.Room{
-		RoomNumber:   "101",
-		BuildingID:   1,
-		RoomCapacity: 30,
-		Floor:        1,
-	}
-	_, err = roomService.CreateRoom(room)
-	if err != nil {
-		log.Fatal("Failed to seed room:", err)
-	}
+const (
+	connectBackoff    = 500 * time.Millisecond
+	connectMaxBackoff = 10 * time.Second
+)
 
-	// Discipline
-	discipline := &domain.Discipline{
-		Name:         "Mathematics",
-		Credits:      4,
-		Program:      "Basic Math Program",
-		Bibliography: []string{"Book 1", "Book 2"},
+// Connect opens the configured database, applies the pool settings, waits
+// for it to accept connections and applies pending migrations. It returns an
+// error instead of exiting so the caller decides how to fail.
+func Connect(cfg config.DBConfig) error {
+	var err error
+	Driver = cfg.Driver
+	switch Driver {
+	case DriverSQLite:
+		log.Printf("SQLite database: %s", cfg.Path)
+		DB, err = sql.Open("sqlite", sqliteDSN(cfg.Path))
+	default:
+		Driver = DriverPostgres
+		log.Printf("DSN: %s", cfg.RedactedDSN())
+		DB, err = sql.Open("postgres", cfg.DSN())
 	}
-	_, err = disciplineService.CreateDiscipline(discipline)
 	if err != nil {
-		log.Fatal("Failed to seed discipline:", err)
+		return fmt.Errorf("failed to open database: %w", err)
 	}
+	DB.SetMaxOpenConns(cfg.MaxOpenConns)
+	DB.SetMaxIdleConns(cfg.MaxIdleConns)
+	DB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
-	// Curriculum
-	curriculum := &domain.Curriculum{
-		CourseName: "Engineering",
-		DataInicio: "2025-01-01",
-		DataFim:    "2029-01-01",
-		Disciplines: []domain.Discipline{
-			{ID: 1}, // Add discipline by ID
-		},
-	}
-	_, err = curriculumService.CreateCurriculum(curriculum)
-	if err != nil {
-		log.Fatal("Failed to seed curriculum:", err)
+	if err = waitForDatabase(DB, cfg.ConnectAttempts, cfg.PingTimeout); err != nil {
+		return err
 	}
 
-	// Class
-	class := &domain.Class{
-		Name:         "Math 101",
-		Description:  "Intro to Math",
-		DisciplineID: 1,
-	}
-	_, err = classService.CreateClass(class)
-	if err != nil {
-		log.Fatal("Failed to seed class:", err)
+	if err = Migrate(DB, Driver); err != nil {
+		return fmt.Errorf("failed to migrate tables: %w", err)
 	}
 
-	// Lecture
-	lecture := &domain.Lecture{
-		ClassID: 1,
-		RoomID:  1,
-		Date:    "2025-09-01",
-		Content: []string{"Introduction", "Numbers"},
-	}
-	_, err = lectureService.CreateLecture(lecture)
-	if err != nil {
-		log.Fatal("Failed to seed lecture:", err)
-	}
+	log.Println("Database connected and migrated")
+	return nil
+}
 
-	// ResourceType
-	resourceType := &domain.ResourceType{
-		Name: "Projector",
-	}
-	err = resourceTypeRepo.Create(resourceType)
-	if err != nil {
-		log.Fatal("Failed to seed resource type:", err)
+// Repositories returns the repository implementations matching Driver.
+func Repositories() repositories.Repositories {
+	if Driver == DriverSQLite {
+		return sqliteimpl.NewRepositories(DB)
 	}
+	return repoimpl.NewRepositories(DB)
+}
 
-	// Resource
-	resource := &domain.Resource{
-		Description:     "Epson Projector",
-		Status:          "available",
-		Characteristics: []string{"HD", "HDMI"},
-		ResourceTypeID:  1,
-	}
-	_, err = resourceService.CreateResource(resource)
-	if err != nil {
-		log.Fatal("Failed to seed resource:", err)
+// Transactor returns the Transactor for the configured storage backend.
+func Transactor() repositories.Transactor {
+	if Driver == DriverSQLite {
+		return sqliteimpl.NewTransactor(DB)
 	}
+	return repoimpl.NewTransactor(DB)
+}
 
-	// Reservation
-	reservation := &domain.Reservation{
-		LectureID:   1,
-		Observation: "First class reservation",
-		Resources:   []domain.Resource{{ResourceID: 1}}, // Add resource by ID
-	}
-	_, err = reservationService.CreateReservation(reservation)
-	if err != nil {
-		log.Fatal("Failed to seed reservation:", err)
-	}
+// sqliteDSN turns on foreign keys, which SQLite leaves off by default, and
+// WAL mode with a busy timeout so readers and the writer don't block each other.
+func sqliteDSN(path string) string {
+	return "file:" + path + "?_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)"
+}
 
-	fmt.Println("Database connected and migrated!")
+// waitForDatabase pings the database with exponential backoff. Postgres is
+// usually still starting when the app container comes up.
+func waitForDatabase(db *sql.DB, attempts int, timeout time.Duration) error {
+	backoff := connectBackoff
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err = db.PingContext(ctx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if attempt == attempts {
+			break
+		}
+		log.Printf("Database not ready (attempt %d/%d): %v; retrying in %s", attempt, attempts, err, backoff)
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > connectMaxBackoff {
+			backoff = connectMaxBackoff
+		}
+	}
+	return fmt.Errorf("failed to connect to database after %d attempts: %w", attempts, err)
 }
@@ -0,0 +1,629 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// migration holds the same schema change written for each supported driver.
+type migration struct {
+	version     int
+	description string
+	postgres    string
+	sqlite      string
+}
+
+// migrations are applied in order and recorded in schema_migrations.
+// Never edit a migration that has been released: append a new one instead.
+// A migration with nothing to change for one driver leaves its SQL empty.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "initial schema",
+		postgres: `
+        CREATE TABLE IF NOT EXISTS profiles (
+            profile_id SERIAL PRIMARY KEY,
+            role TEXT NOT NULL
+        );
+
+        CREATE TABLE IF NOT EXISTS users (
+            user_id SERIAL PRIMARY KEY,
+            email TEXT,
+            nome TEXT,
+            birth_date DATE,
+            sex TEXT,
+            telephone TEXT,
+            profile_id INTEGER REFERENCES profiles(profile_id)
+        );
+
+        CREATE TABLE IF NOT EXISTS buildings (
+            building_id SERIAL PRIMARY KEY,
+            building_name TEXT,
+            address TEXT
+        );
+
+        CREATE TABLE IF NOT EXISTS rooms (
+            room_id SERIAL PRIMARY KEY,
+            room_number TEXT,
+            building_id INTEGER REFERENCES buildings(building_id),
+            room_capacity INTEGER,
+            floor INTEGER
+        );
+
+        CREATE TABLE IF NOT EXISTS disciplines (
+            discipline_id SERIAL PRIMARY KEY,
+            name TEXT,
+            credits INTEGER,
+            program TEXT,
+            bibliography TEXT[]
+        );
+
+        CREATE TABLE IF NOT EXISTS curriculums (
+            curriculum_id SERIAL PRIMARY KEY,
+            course_name TEXT,
+            data_inicio DATE,
+            data_fim DATE
+        );
+
+        CREATE TABLE IF NOT EXISTS curriculum_disciplines (
+            curriculum_id INTEGER REFERENCES curriculums(curriculum_id),
+            discipline_id INTEGER REFERENCES disciplines(discipline_id),
+            PRIMARY KEY (curriculum_id, discipline_id)
+        );
+
+        CREATE TABLE IF NOT EXISTS classes (
+            class_id SERIAL PRIMARY KEY,
+            name TEXT,
+            description TEXT,
+            discipline_id INTEGER REFERENCES disciplines(discipline_id)
+        );
+
+        CREATE TABLE IF NOT EXISTS lectures (
+            lecture_id SERIAL PRIMARY KEY,
+            class_id INTEGER REFERENCES classes(class_id),
+            room_id INTEGER REFERENCES rooms(room_id),
+            date DATE,
+            content TEXT[]
+        );
+
+        CREATE TABLE IF NOT EXISTS resource_types (
+            resource_type_id SERIAL PRIMARY KEY,
+            name TEXT
+        );
+
+        CREATE TABLE IF NOT EXISTS resources (
+            resource_id SERIAL PRIMARY KEY,
+            description TEXT,
+            status TEXT,
+            characteristics TEXT[],
+            resource_type_id INTEGER REFERENCES resource_types(resource_type_id)
+        );
+
+        CREATE TABLE IF NOT EXISTS reservations (
+            reservation_id SERIAL PRIMARY KEY,
+            lecture_id INTEGER REFERENCES lectures(lecture_id),
+            observation TEXT
+        );
+
+        CREATE TABLE IF NOT EXISTS reservation_resources (
+            reservation_id INTEGER REFERENCES reservations(reservation_id),
+            resource_id INTEGER REFERENCES resources(resource_id),
+            PRIMARY KEY (reservation_id, resource_id)
+        );
+    `,
+		sqlite: `
+        CREATE TABLE IF NOT EXISTS profiles (
+            profile_id INTEGER PRIMARY KEY AUTOINCREMENT,
+            role TEXT NOT NULL
+        );
+
+        CREATE TABLE IF NOT EXISTS users (
+            user_id INTEGER PRIMARY KEY AUTOINCREMENT,
+            email TEXT,
+            nome TEXT,
+            birth_date TEXT,
+            sex TEXT,
+            telephone TEXT,
+            profile_id INTEGER REFERENCES profiles(profile_id)
+        );
+
+        CREATE TABLE IF NOT EXISTS buildings (
+            building_id INTEGER PRIMARY KEY AUTOINCREMENT,
+            building_name TEXT,
+            address TEXT
+        );
+
+        CREATE TABLE IF NOT EXISTS rooms (
+            room_id INTEGER PRIMARY KEY AUTOINCREMENT,
+            room_number TEXT,
+            building_id INTEGER REFERENCES buildings(building_id),
+            room_capacity INTEGER,
+            floor INTEGER
+        );
+
+        CREATE TABLE IF NOT EXISTS disciplines (
+            discipline_id INTEGER PRIMARY KEY AUTOINCREMENT,
+            name TEXT,
+            credits INTEGER,
+            program TEXT,
+            bibliography TEXT -- JSON array
+        );
+
+        CREATE TABLE IF NOT EXISTS curriculums (
+            curriculum_id INTEGER PRIMARY KEY AUTOINCREMENT,
+            course_name TEXT,
+            data_inicio TEXT,
+            data_fim TEXT
+        );
+
+        CREATE TABLE IF NOT EXISTS curriculum_disciplines (
+            curriculum_id INTEGER REFERENCES curriculums(curriculum_id),
+            discipline_id INTEGER REFERENCES disciplines(discipline_id),
+            PRIMARY KEY (curriculum_id, discipline_id)
+        );
+
+        CREATE TABLE IF NOT EXISTS classes (
+            class_id INTEGER PRIMARY KEY AUTOINCREMENT,
+            name TEXT,
+            description TEXT,
+            discipline_id INTEGER REFERENCES disciplines(discipline_id)
+        );
+
+        CREATE TABLE IF NOT EXISTS lectures (
+            lecture_id INTEGER PRIMARY KEY AUTOINCREMENT,
+            class_id INTEGER REFERENCES classes(class_id),
+            room_id INTEGER REFERENCES rooms(room_id),
+            date TEXT,
+            content TEXT -- JSON array
+        );
+
+        CREATE TABLE IF NOT EXISTS resource_types (
+            resource_type_id INTEGER PRIMARY KEY AUTOINCREMENT,
+            name TEXT
+        );
+
+        CREATE TABLE IF NOT EXISTS resources (
+            resource_id INTEGER PRIMARY KEY AUTOINCREMENT,
+            description TEXT,
+            status TEXT,
+            characteristics TEXT, -- JSON array
+            resource_type_id INTEGER REFERENCES resource_types(resource_type_id)
+        );
+
+        CREATE TABLE IF NOT EXISTS reservations (
+            reservation_id INTEGER PRIMARY KEY AUTOINCREMENT,
+            lecture_id INTEGER REFERENCES lectures(lecture_id),
+            observation TEXT
+        );
+
+        CREATE TABLE IF NOT EXISTS reservation_resources (
+            reservation_id INTEGER REFERENCES reservations(reservation_id),
+            resource_id INTEGER REFERENCES resources(resource_id),
+            PRIMARY KEY (reservation_id, resource_id)
+        );
+    `,
+	},
+	{
+		version:     2,
+		description: "full-text search",
+		// Each searchable table gets a generated tsvector in both Portuguese and
+		// English, so stemming works whichever language a record is written in.
+		// array_to_string is not IMMUTABLE, which generated columns require,
+		// hence the wrapper.
+		postgres: `
+        CREATE OR REPLACE FUNCTION search_array_text(text[]) RETURNS text
+            LANGUAGE sql IMMUTABLE PARALLEL SAFE
+            AS $$ SELECT coalesce(array_to_string($1, ' '), '') $$;
+
+        ALTER TABLE disciplines ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+            setweight(to_tsvector('portuguese', coalesce(name, '')), 'A') ||
+            setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+            setweight(to_tsvector('portuguese', coalesce(program, '')), 'B') ||
+            setweight(to_tsvector('english', coalesce(program, '')), 'B') ||
+            setweight(to_tsvector('portuguese', search_array_text(bibliography)), 'C') ||
+            setweight(to_tsvector('english', search_array_text(bibliography)), 'C')
+        ) STORED;
+        CREATE INDEX disciplines_search_idx ON disciplines USING GIN (search_vector);
+
+        ALTER TABLE resources ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+            setweight(to_tsvector('portuguese', coalesce(description, '')), 'A') ||
+            setweight(to_tsvector('english', coalesce(description, '')), 'A') ||
+            setweight(to_tsvector('portuguese', search_array_text(characteristics)), 'B') ||
+            setweight(to_tsvector('english', search_array_text(characteristics)), 'B')
+        ) STORED;
+        CREATE INDEX resources_search_idx ON resources USING GIN (search_vector);
+
+        ALTER TABLE buildings ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+            setweight(to_tsvector('portuguese', coalesce(building_name, '')), 'A') ||
+            setweight(to_tsvector('english', coalesce(building_name, '')), 'A') ||
+            setweight(to_tsvector('portuguese', coalesce(address, '')), 'B') ||
+            setweight(to_tsvector('english', coalesce(address, '')), 'B')
+        ) STORED;
+        CREATE INDEX buildings_search_idx ON buildings USING GIN (search_vector);
+
+        ALTER TABLE classes ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+            setweight(to_tsvector('portuguese', coalesce(name, '')), 'A') ||
+            setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+            setweight(to_tsvector('portuguese', coalesce(description, '')), 'B') ||
+            setweight(to_tsvector('english', coalesce(description, '')), 'B')
+        ) STORED;
+        CREATE INDEX classes_search_idx ON classes USING GIN (search_vector);
+    `,
+		// SQLite searches with LIKE, which needs no schema changes.
+		sqlite: "",
+	},
+	{
+		version:     3,
+		description: "foreign key indexes",
+		// Neither database indexes the referencing side of a foreign key, so the
+		// nested listings would scan the child table. The indexes also cover
+		// the order each listing is sorted in.
+		postgres: `
+        CREATE INDEX IF NOT EXISTS rooms_building_id_idx ON rooms (building_id, room_id);
+        CREATE INDEX IF NOT EXISTS classes_discipline_id_idx ON classes (discipline_id, class_id);
+        CREATE INDEX IF NOT EXISTS lectures_class_id_idx ON lectures (class_id, date, lecture_id);
+        CREATE INDEX IF NOT EXISTS lectures_room_id_idx ON lectures (room_id, date, lecture_id);
+        CREATE INDEX IF NOT EXISTS reservations_lecture_id_idx ON reservations (lecture_id, reservation_id);
+    `,
+		sqlite: `
+        CREATE INDEX IF NOT EXISTS rooms_building_id_idx ON rooms (building_id, room_id);
+        CREATE INDEX IF NOT EXISTS classes_discipline_id_idx ON classes (discipline_id, class_id);
+        CREATE INDEX IF NOT EXISTS lectures_class_id_idx ON lectures (class_id, date, lecture_id);
+        CREATE INDEX IF NOT EXISTS lectures_room_id_idx ON lectures (room_id, date, lecture_id);
+        CREATE INDEX IF NOT EXISTS reservations_lecture_id_idx ON reservations (lecture_id, reservation_id);
+    `,
+	},
+	{
+		version:     4,
+		description: "lecture presence",
+		postgres: `
+        CREATE TABLE IF NOT EXISTS lecture_presence (
+            lecture_id INTEGER REFERENCES lectures(lecture_id),
+            user_id INTEGER REFERENCES users(user_id),
+            method TEXT NOT NULL,
+            marked_at TIMESTAMPTZ NOT NULL,
+            PRIMARY KEY (lecture_id, user_id)
+        );
+        CREATE INDEX IF NOT EXISTS lecture_presence_user_id_idx ON lecture_presence (user_id);
+    `,
+		sqlite: `
+        CREATE TABLE IF NOT EXISTS lecture_presence (
+            lecture_id INTEGER REFERENCES lectures(lecture_id),
+            user_id INTEGER REFERENCES users(user_id),
+            method TEXT NOT NULL,
+            marked_at TIMESTAMP NOT NULL,
+            PRIMARY KEY (lecture_id, user_id)
+        );
+        CREATE INDEX IF NOT EXISTS lecture_presence_user_id_idx ON lecture_presence (user_id);
+    `,
+	},
+	{
+		version:     5,
+		description: "lecture times",
+		postgres: `
+        ALTER TABLE lectures ADD COLUMN IF NOT EXISTS start_time TEXT NOT NULL DEFAULT '';
+        ALTER TABLE lectures ADD COLUMN IF NOT EXISTS end_time TEXT NOT NULL DEFAULT '';
+    `,
+		sqlite: `
+        ALTER TABLE lectures ADD COLUMN start_time TEXT NOT NULL DEFAULT '';
+        ALTER TABLE lectures ADD COLUMN end_time TEXT NOT NULL DEFAULT '';
+    `,
+	},
+	{
+		version:     6,
+		description: "class enrollments",
+		postgres: `
+        ALTER TABLE profiles ADD COLUMN IF NOT EXISTS can_teach BOOLEAN NOT NULL DEFAULT FALSE;
+        UPDATE profiles SET can_teach = TRUE WHERE LOWER(role) IN ('teacher', 'professor', 'admin');
+
+        CREATE TABLE IF NOT EXISTS enrollments (
+            enrollment_id SERIAL PRIMARY KEY,
+            class_id INTEGER NOT NULL REFERENCES classes(class_id),
+            user_id INTEGER NOT NULL REFERENCES users(user_id),
+            role TEXT NOT NULL,
+            status TEXT NOT NULL,
+            enrolled_at TIMESTAMPTZ NOT NULL,
+            dropped_at TIMESTAMPTZ,
+            UNIQUE (class_id, user_id)
+        );
+        CREATE INDEX IF NOT EXISTS enrollments_user_id_idx ON enrollments (user_id);
+    `,
+		sqlite: `
+        ALTER TABLE profiles ADD COLUMN can_teach BOOLEAN NOT NULL DEFAULT FALSE;
+        UPDATE profiles SET can_teach = TRUE WHERE LOWER(role) IN ('teacher', 'professor', 'admin');
+
+        CREATE TABLE IF NOT EXISTS enrollments (
+            enrollment_id INTEGER PRIMARY KEY AUTOINCREMENT,
+            class_id INTEGER NOT NULL REFERENCES classes(class_id),
+            user_id INTEGER NOT NULL REFERENCES users(user_id),
+            role TEXT NOT NULL,
+            status TEXT NOT NULL,
+            enrolled_at TIMESTAMP NOT NULL,
+            dropped_at TIMESTAMP,
+            UNIQUE (class_id, user_id)
+        );
+        CREATE INDEX IF NOT EXISTS enrollments_user_id_idx ON enrollments (user_id);
+    `,
+	},
+	{
+		version:     7,
+		description: "curriculum requisites",
+		postgres: `
+        CREATE TABLE IF NOT EXISTS curriculum_requisites (
+            curriculum_id INTEGER NOT NULL,
+            discipline_id INTEGER NOT NULL,
+            requisite_id INTEGER NOT NULL,
+            kind TEXT NOT NULL,
+            PRIMARY KEY (curriculum_id, discipline_id, requisite_id),
+            FOREIGN KEY (curriculum_id, discipline_id) REFERENCES curriculum_disciplines (curriculum_id, discipline_id),
+            FOREIGN KEY (curriculum_id, requisite_id) REFERENCES curriculum_disciplines (curriculum_id, discipline_id)
+        );
+    `,
+		sqlite: `
+        CREATE TABLE IF NOT EXISTS curriculum_requisites (
+            curriculum_id INTEGER NOT NULL,
+            discipline_id INTEGER NOT NULL,
+            requisite_id INTEGER NOT NULL,
+            kind TEXT NOT NULL,
+            PRIMARY KEY (curriculum_id, discipline_id, requisite_id),
+            FOREIGN KEY (curriculum_id, discipline_id) REFERENCES curriculum_disciplines (curriculum_id, discipline_id),
+            FOREIGN KEY (curriculum_id, requisite_id) REFERENCES curriculum_disciplines (curriculum_id, discipline_id)
+        );
+    `,
+	},
+	{
+		version:     8,
+		description: "curriculum semesters",
+		postgres: `
+        ALTER TABLE curriculum_disciplines ADD COLUMN IF NOT EXISTS semester INTEGER NOT NULL DEFAULT 0;
+        ALTER TABLE curriculum_disciplines ADD COLUMN IF NOT EXISTS elective BOOLEAN NOT NULL DEFAULT FALSE;
+    `,
+		sqlite: `
+        ALTER TABLE curriculum_disciplines ADD COLUMN semester INTEGER NOT NULL DEFAULT 0;
+        ALTER TABLE curriculum_disciplines ADD COLUMN elective BOOLEAN NOT NULL DEFAULT FALSE;
+    `,
+	},
+	{
+		version:     9,
+		description: "curriculum versions",
+		postgres: `
+        ALTER TABLE curriculums ADD COLUMN IF NOT EXISTS lineage_id INTEGER REFERENCES curriculums(curriculum_id);
+        ALTER TABLE curriculums ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+        CREATE INDEX IF NOT EXISTS curriculums_lineage_id_idx ON curriculums (lineage_id);
+    `,
+		sqlite: `
+        ALTER TABLE curriculums ADD COLUMN lineage_id INTEGER REFERENCES curriculums(curriculum_id);
+        ALTER TABLE curriculums ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+        CREATE INDEX IF NOT EXISTS curriculums_lineage_id_idx ON curriculums (lineage_id);
+    `,
+	},
+	{
+		version:     10,
+		description: "discipline equivalences",
+		postgres: `
+        CREATE TABLE IF NOT EXISTS discipline_equivalences (
+            equivalence_id SERIAL PRIMARY KEY,
+            target_id INTEGER NOT NULL REFERENCES disciplines(discipline_id)
+        );
+        CREATE INDEX IF NOT EXISTS discipline_equivalences_target_id_idx ON discipline_equivalences (target_id);
+
+        CREATE TABLE IF NOT EXISTS discipline_equivalence_sources (
+            equivalence_id INTEGER NOT NULL REFERENCES discipline_equivalences(equivalence_id),
+            discipline_id INTEGER NOT NULL REFERENCES disciplines(discipline_id),
+            PRIMARY KEY (equivalence_id, discipline_id)
+        );
+        CREATE INDEX IF NOT EXISTS discipline_equivalence_sources_discipline_id_idx ON discipline_equivalence_sources (discipline_id);
+    `,
+		sqlite: `
+        CREATE TABLE IF NOT EXISTS discipline_equivalences (
+            equivalence_id INTEGER PRIMARY KEY AUTOINCREMENT,
+            target_id INTEGER NOT NULL REFERENCES disciplines(discipline_id)
+        );
+        CREATE INDEX IF NOT EXISTS discipline_equivalences_target_id_idx ON discipline_equivalences (target_id);
+
+        CREATE TABLE IF NOT EXISTS discipline_equivalence_sources (
+            equivalence_id INTEGER NOT NULL REFERENCES discipline_equivalences(equivalence_id),
+            discipline_id INTEGER NOT NULL REFERENCES disciplines(discipline_id),
+            PRIMARY KEY (equivalence_id, discipline_id)
+        );
+        CREATE INDEX IF NOT EXISTS discipline_equivalence_sources_discipline_id_idx ON discipline_equivalence_sources (discipline_id);
+    `,
+	},
+	{
+		version:     11,
+		description: "grades and academic records",
+		postgres: `
+        CREATE TABLE IF NOT EXISTS assessments (
+            assessment_id SERIAL PRIMARY KEY,
+            class_id INTEGER NOT NULL REFERENCES classes(class_id),
+            name TEXT NOT NULL,
+            weight DOUBLE PRECISION NOT NULL
+        );
+        CREATE INDEX IF NOT EXISTS assessments_class_id_idx ON assessments (class_id);
+
+        CREATE TABLE IF NOT EXISTS grades (
+            assessment_id INTEGER NOT NULL REFERENCES assessments(assessment_id),
+            user_id INTEGER NOT NULL REFERENCES users(user_id),
+            value DOUBLE PRECISION NOT NULL,
+            PRIMARY KEY (assessment_id, user_id)
+        );
+
+        CREATE TABLE IF NOT EXISTS academic_records (
+            user_id INTEGER NOT NULL REFERENCES users(user_id),
+            class_id INTEGER NOT NULL REFERENCES classes(class_id),
+            discipline_id INTEGER NOT NULL REFERENCES disciplines(discipline_id),
+            final_grade DOUBLE PRECISION NOT NULL,
+            attendance DOUBLE PRECISION NOT NULL,
+            status TEXT NOT NULL,
+            completed_at TIMESTAMPTZ NOT NULL,
+            PRIMARY KEY (user_id, class_id)
+        );
+    `,
+		sqlite: `
+        CREATE TABLE IF NOT EXISTS assessments (
+            assessment_id INTEGER PRIMARY KEY AUTOINCREMENT,
+            class_id INTEGER NOT NULL REFERENCES classes(class_id),
+            name TEXT NOT NULL,
+            weight REAL NOT NULL
+        );
+        CREATE INDEX IF NOT EXISTS assessments_class_id_idx ON assessments (class_id);
+
+        CREATE TABLE IF NOT EXISTS grades (
+            assessment_id INTEGER NOT NULL REFERENCES assessments(assessment_id),
+            user_id INTEGER NOT NULL REFERENCES users(user_id),
+            value REAL NOT NULL,
+            PRIMARY KEY (assessment_id, user_id)
+        );
+
+        CREATE TABLE IF NOT EXISTS academic_records (
+            user_id INTEGER NOT NULL REFERENCES users(user_id),
+            class_id INTEGER NOT NULL REFERENCES classes(class_id),
+            discipline_id INTEGER NOT NULL REFERENCES disciplines(discipline_id),
+            final_grade REAL NOT NULL,
+            attendance REAL NOT NULL,
+            status TEXT NOT NULL,
+            completed_at TIMESTAMP NOT NULL,
+            PRIMARY KEY (user_id, class_id)
+        );
+    `,
+	},
+	{
+		version:     12,
+		description: "check-in token claims",
+		postgres: `
+        CREATE TABLE IF NOT EXISTS check_in_claims (
+            lecture_id INTEGER NOT NULL,
+            user_id INTEGER NOT NULL,
+            token_window BIGINT NOT NULL,
+            expires_at TIMESTAMPTZ NOT NULL,
+            PRIMARY KEY (lecture_id, user_id, token_window)
+        );
+        CREATE INDEX IF NOT EXISTS check_in_claims_expires_at_idx ON check_in_claims (expires_at);
+    `,
+		sqlite: `
+        CREATE TABLE IF NOT EXISTS check_in_claims (
+            lecture_id INTEGER NOT NULL,
+            user_id INTEGER NOT NULL,
+            token_window INTEGER NOT NULL,
+            expires_at TIMESTAMP NOT NULL,
+            PRIMARY KEY (lecture_id, user_id, token_window)
+        );
+        CREATE INDEX IF NOT EXISTS check_in_claims_expires_at_idx ON check_in_claims (expires_at);
+    `,
+	},
+	{
+		version:     13,
+		description: "attendance notifications",
+		postgres: `
+        CREATE TABLE IF NOT EXISTS attendance_notifications (
+            class_id INTEGER NOT NULL,
+            user_id INTEGER NOT NULL,
+            status TEXT NOT NULL,
+            notified_at TIMESTAMPTZ NOT NULL,
+            PRIMARY KEY (class_id, user_id)
+        );
+    `,
+		sqlite: `
+        CREATE TABLE IF NOT EXISTS attendance_notifications (
+            class_id INTEGER NOT NULL,
+            user_id INTEGER NOT NULL,
+            status TEXT NOT NULL,
+            notified_at TIMESTAMP NOT NULL,
+            PRIMARY KEY (class_id, user_id)
+        );
+    `,
+	},
+	{
+		version:     14,
+		description: "unique curriculum versions",
+		// The first version of a lineage has a NULL lineage_id.
+		postgres: `
+        CREATE UNIQUE INDEX IF NOT EXISTS curriculums_lineage_version_idx ON curriculums ((COALESCE(lineage_id, curriculum_id)), version);
+    `,
+		sqlite: `
+        CREATE UNIQUE INDEX IF NOT EXISTS curriculums_lineage_version_idx ON curriculums (COALESCE(lineage_id, curriculum_id), version);
+    `,
+	},
+	{
+		version:     15,
+		description: "lectures by date",
+		postgres: `
+        CREATE INDEX IF NOT EXISTS lectures_date_idx ON lectures (date, lecture_id);
+    `,
+		sqlite: `
+        CREATE INDEX IF NOT EXISTS lectures_date_idx ON lectures (date, lecture_id);
+    `,
+	},
+}
+
+// SchemaVersion is the migration version this build expects the database to be at.
+func SchemaVersion() int {
+	return migrations[len(migrations)-1].version
+}
+
+// CurrentVersion returns the highest migration version applied to the database.
+func CurrentVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var version int
+	err := db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version)
+	return version, err
+}
+
+// Migrate applies every pending migration for driver, each one in its own
+// transaction.
+func Migrate(db *sql.DB, driver string) error {
+	createTable := `
+        CREATE TABLE IF NOT EXISTS schema_migrations (
+            version INTEGER PRIMARY KEY,
+            description TEXT,
+            applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+        );
+    `
+	insert := "INSERT INTO schema_migrations (version, description) VALUES ($1, $2)"
+	if driver == DriverSQLite {
+		createTable = `
+        CREATE TABLE IF NOT EXISTS schema_migrations (
+            version INTEGER PRIMARY KEY,
+            description TEXT,
+            applied_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+        );
+    `
+		insert = "INSERT INTO schema_migrations (version, description) VALUES (?, ?)"
+	}
+	if _, err := db.Exec(createTable); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	current, err := CurrentVersion(context.Background(), db)
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		statements := m.postgres
+		if driver == DriverSQLite {
+			statements = m.sqlite
+		}
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if statements != "" {
+			if _, err := tx.Exec(statements); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("migration %d (%s) failed: %w", m.version, m.description, err)
+			}
+		}
+		if _, err := tx.Exec(insert, m.version, m.description); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to record migration %d: %w", m.version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
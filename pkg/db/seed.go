@@ -0,0 +1,205 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sarc/core/domain"
+	"sarc/core/services"
+	repositories "sarc/infrastructure/repositories/interfaces"
+)
+
+// Seed clears every table and inserts the demo data set through repos.
+func Seed(repos repositories.Repositories) error {
+	var err error
+
+	// --- Clear all tables before seeding ---
+	if err = clearTables(); err != nil {
+		return fmt.Errorf("failed to clear tables: %w", err)
+	}
+
+	// Instantiate services
+	profileService := services.NewProfileService(repos.Profile)
+	userService := services.NewUserService(repos.User)
+	buildingService := services.NewBuildingService(repos.Building, repos.Room)
+	roomService := services.NewRoomService(repos.Room, repos.Lecture)
+	disciplineService := services.NewDisciplineService(repos.Discipline, repos.Class)
+	curriculumService := services.NewCurriculumService(repos.Curriculum, repos.Discipline, Transactor(), domain.CreditLimits{})
+	classService := services.NewClassService(repos.Class, repos.Lecture)
+	lectureService := services.NewLectureService(repos.Lecture, repos.Reservation, repos.Room, repos.Enrollment, domain.CapacityPolicy{}, domain.ConflictPolicy{}, time.UTC)
+	resourceService := services.NewResourceService(repos.Resource)
+	reservationService := services.NewReservationsService(repos.Reservation)
+
+	// --- Seed data using services ---
+
+	// Profile
+	profile := &domain.Profile{Role: "admin", CanTeach: true}
+	_, err = profileService.CreateProfile(profile)
+	if err != nil {
+		return fmt.Errorf("failed to seed profile: %w", err)
+	}
+
+	// User
+	user := &domain.User{
+		Email:     "admin@example.com",
+		Nome:      "Admin",
+		BirthDate: "1990-01-01",
+		Sex:       "M",
+		Telephone: "123456789",
+		ProfileID: 1,
+	}
+	_, err = userService.CreateUser(user)
+	if err != nil {
+		return fmt.Errorf("failed to seed user: %w", err)
+	}
+
+	// Building
+	building := &domain.Building{
+		BuildingName: "Main Building",
+		Address:      "123 Main St",
+	}
+	_, err = buildingService.CreateBuilding(building)
+	if err != nil {
+		return fmt.Errorf("failed to seed building: %w", err)
+	}
+
+	// Room
+	room := &domain.Room{
+		RoomNumber:   "101",
+		BuildingID:   1,
+		RoomCapacity: 30,
+		Floor:        1,
+	}
+	_, err = roomService.CreateRoom(room)
+	if err != nil {
+		return fmt.Errorf("failed to seed room: %w", err)
+	}
+
+	// Discipline
+	discipline := &domain.Discipline{
+		Name:         "Mathematics",
+		Credits:      4,
+		Program:      "Basic Math Program",
+		Bibliography: []string{"Book 1", "Book 2"},
+	}
+	_, err = disciplineService.CreateDiscipline(discipline)
+	if err != nil {
+		return fmt.Errorf("failed to seed discipline: %w", err)
+	}
+
+	// Curriculum
+	curriculum := &domain.Curriculum{
+		CourseName: "Engineering",
+		DataInicio: "2025-01-01",
+		DataFim:    "2029-01-01",
+		Disciplines: []domain.Discipline{
+			{ID: 1, Semester: 1}, // Add discipline by ID
+		},
+	}
+	_, err = curriculumService.CreateCurriculum(curriculum)
+	if err != nil {
+		return fmt.Errorf("failed to seed curriculum: %w", err)
+	}
+
+	// Class
+	class := &domain.Class{
+		Name:         "Math 101",
+		Description:  "Intro to Math",
+		DisciplineID: 1,
+	}
+	_, err = classService.CreateClass(class)
+	if err != nil {
+		return fmt.Errorf("failed to seed class: %w", err)
+	}
+
+	// Lecture
+	lecture := &domain.Lecture{
+		ClassID:   1,
+		RoomID:    1,
+		Date:      "2025-09-01",
+		StartTime: "08:00",
+		EndTime:   "09:40",
+		Content:   []string{"Introduction", "Numbers"},
+	}
+	_, err = lectureService.CreateLecture(lecture)
+	if err != nil {
+		return fmt.Errorf("failed to seed lecture: %w", err)
+	}
+
+	// ResourceType
+	resourceType := &domain.ResourceType{
+		Name: "Projector",
+	}
+	err = repos.ResourceType.Create(resourceType)
+	if err != nil {
+		return fmt.Errorf("failed to seed resource type: %w", err)
+	}
+
+	// Resource
+	resource := &domain.Resource{
+		Description:     "Epson Projector",
+		Status:          "available",
+		Characteristics: []string{"HD", "HDMI"},
+		ResourceTypeID:  1,
+	}
+	_, err = resourceService.CreateResource(resource)
+	if err != nil {
+		return fmt.Errorf("failed to seed resource: %w", err)
+	}
+
+	// Reservation
+	reservation := &domain.Reservation{
+		LectureID:   1,
+		Observation: "First class reservation",
+		Resources:   []domain.Resource{{ResourceID: 1}}, // Add resource by ID
+	}
+	_, err = reservationService.CreateReservation(reservation)
+	if err != nil {
+		return fmt.Errorf("failed to seed reservation: %w", err)
+	}
+
+	return nil
+}
+
+// seededTables lists every table, children before parents.
+var seededTables = []string{
+	"attendance_notifications",
+	"check_in_claims",
+	"academic_records",
+	"grades",
+	"assessments",
+	"enrollments",
+	"lecture_presence",
+	"reservation_resources",
+	"reservations",
+	"resources",
+	"resource_types",
+	"lectures",
+	"classes",
+	"discipline_equivalence_sources",
+	"discipline_equivalences",
+	"curriculum_requisites",
+	"curriculum_disciplines",
+	"curriculums",
+	"disciplines",
+	"rooms",
+	"buildings",
+	"users",
+	"profiles",
+}
+
+// clearTables empties every table and resets the ID sequences.
+func clearTables() error {
+	if Driver == DriverSQLite {
+		for _, table := range seededTables {
+			if _, err := DB.Exec("DELETE FROM " + table); err != nil {
+				return err
+			}
+		}
+		_, err := DB.Exec("DELETE FROM sqlite_sequence")
+		return err
+	}
+	_, err := DB.Exec("TRUNCATE TABLE " + strings.Join(seededTables, ", ") + " RESTART IDENTITY CASCADE")
+	return err
+}
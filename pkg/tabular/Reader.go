@@ -0,0 +1,173 @@
+// Package tabular reads and writes spreadsheets (CSV and XLSX) as plain rows
+// of strings.
+package tabular
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// Table is a spreadsheet read into memory: the header row followed by the
+// data rows. Blank rows are dropped.
+type Table struct {
+	Header     []string
+	HeaderLine int
+	Rows       []Row
+}
+
+// Row is one data row and the line it was read from, so errors can point
+// at the line the user sees in their spreadsheet program.
+type Row struct {
+	Line   int
+	Values []string
+}
+
+// FormatFromFilename picks the format from the file extension.
+func FormatFromFilename(name string) (string, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv", ".txt":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	}
+	return "", fmt.Errorf("unsupported file type %q: use .csv or .xlsx", filepath.Ext(name))
+}
+
+// Read parses r as a CSV or XLSX file. CSV files may use "," or ";" as the
+// delimiter, as exported by spreadsheet programs in different locales. For
+// XLSX only the first sheet is read.
+func Read(r io.Reader, format string) (*Table, error) {
+	var records []Row
+	var err error
+	switch format {
+	case FormatCSV:
+		records, err = readCSV(r)
+	case FormatXLSX:
+		records, err = readXLSX(r)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var table Table
+	for _, record := range records {
+		if isBlank(record.Values) {
+			continue
+		}
+		if table.Header == nil {
+			table.Header = trimAll(record.Values)
+			table.HeaderLine = record.Line
+			continue
+		}
+		table.Rows = append(table.Rows, record)
+	}
+	if table.Header == nil {
+		return nil, errors.New("the file is empty")
+	}
+	return &table, nil
+}
+
+func readCSV(r io.Reader) ([]Row, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.Comma = detectDelimiter(data)
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, Row{Line: line, Values: record})
+	}
+}
+
+// detectDelimiter returns ';' when the first line has more semicolons than
+// commas.
+func detectDelimiter(data []byte) rune {
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+func readXLSX(r io.Reader) ([]Row, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX: %w", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("the workbook has no sheets")
+	}
+	records, err := f.GetRows(sheets[0])
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]Row, len(records))
+	for i, record := range records {
+		rows[i] = Row{Line: i + 1, Values: record}
+	}
+	return rows, nil
+}
+
+func isBlank(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func trimAll(record []string) []string {
+	out := make([]string, len(record))
+	for i, v := range record {
+		out[i] = strings.TrimSpace(v)
+	}
+	return out
+}
+
+// ParseColumnMapping parses "field=Header,field=Header" into a map from
+// field name to the header of the column that holds it.
+func ParseColumnMapping(s string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		field, header, ok := strings.Cut(pair, "=")
+		field, header = strings.TrimSpace(field), strings.TrimSpace(header)
+		if !ok || field == "" || header == "" {
+			return nil, fmt.Errorf("invalid column mapping %q: expected field=Header", pair)
+		}
+		mapping[field] = header
+	}
+	return mapping, nil
+}
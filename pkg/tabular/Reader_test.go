@@ -0,0 +1,65 @@
+package tabular_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"sarc/pkg/tabular"
+)
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		header     []string
+		headerLine int
+		rows       [][]string
+		lines      []int
+	}{
+		{name: "comma", input: "a,b\n1,2\n", header: []string{"a", "b"}, headerLine: 1, rows: [][]string{{"1", "2"}}, lines: []int{2}},
+		{name: "semicolon", input: "a;b\n1,5;2\n", header: []string{"a", "b"}, headerLine: 1, rows: [][]string{{"1,5", "2"}}, lines: []int{2}},
+		{name: "byte order mark and padded header", input: "\ufeff a , b \n1,2\n", header: []string{"a", "b"}, headerLine: 1, rows: [][]string{{"1", "2"}}, lines: []int{2}},
+		{name: "blank rows skipped, lines kept", input: "\n,\na,b\n\n1,2\n", header: []string{"a", "b"}, headerLine: 3, rows: [][]string{{"1", "2"}}, lines: []int{5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := tabular.Read(strings.NewReader(tt.input), tabular.FormatCSV)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(table.Header, tt.header) || table.HeaderLine != tt.headerLine {
+				t.Errorf("got header %q at line %d, want %q at line %d", table.Header, table.HeaderLine, tt.header, tt.headerLine)
+			}
+			var rows [][]string
+			var lines []int
+			for _, row := range table.Rows {
+				rows = append(rows, row.Values)
+				lines = append(lines, row.Line)
+			}
+			if !reflect.DeepEqual(rows, tt.rows) || !reflect.DeepEqual(lines, tt.lines) {
+				t.Errorf("got rows %q at lines %v, want %q at lines %v", rows, lines, tt.rows, tt.lines)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "\n ,\n"} {
+		if _, err := tabular.Read(strings.NewReader(input), tabular.FormatCSV); err == nil {
+			t.Errorf("reading %q should fail", input)
+		}
+	}
+	if _, err := tabular.Read(strings.NewReader("a,b"), "ods"); err == nil {
+		t.Error("reading an unsupported format should fail")
+	}
+}
+
+func TestFormatFromFilename(t *testing.T) {
+	for name, want := range map[string]string{"rooms.csv": tabular.FormatCSV, "ROOMS.XLSX": tabular.FormatXLSX, "rooms.txt": tabular.FormatCSV} {
+		if got, err := tabular.FormatFromFilename(name); err != nil || got != want {
+			t.Errorf("FormatFromFilename(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := tabular.FormatFromFilename("rooms.ods"); err == nil {
+		t.Error("an .ods file should be rejected")
+	}
+}
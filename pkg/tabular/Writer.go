@@ -0,0 +1,113 @@
+package tabular
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ListSeparator joins array fields such as a resource's characteristics into
+// a single cell. SplitList reverses it, so exported files can be re-imported.
+const ListSeparator = "; "
+
+// JoinList flattens a list into one cell.
+func JoinList(items []string) string {
+	return strings.Join(items, ListSeparator)
+}
+
+// SplitList splits a cell written by JoinList, dropping blank items.
+func SplitList(cell string) []string {
+	var out []string
+	for _, item := range strings.Split(cell, ";") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// ContentType returns the MIME type of format.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "application/octet-stream"
+}
+
+// Writer writes a table one row at a time. Close must be called to flush
+// the output.
+type Writer interface {
+	Write(record []string) error
+	Close() error
+}
+
+// NewWriter returns a Writer producing format on w.
+func NewWriter(w io.Writer, format string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		// The byte order mark makes spreadsheet programs read the file as UTF-8.
+		if _, err := io.WriteString(w, "\ufeff"); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		f := excelize.NewFile()
+		sw, err := f.NewStreamWriter("Sheet1")
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return &xlsxWriter{out: w, file: f, stream: sw}, nil
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) Write(record []string) error {
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// xlsxWriter uses excelize's stream writer, which keeps rows in a temporary
+// file rather than in memory. The workbook is only written to out on Close,
+// as the XLSX format needs the complete sheet first.
+type xlsxWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func (x *xlsxWriter) Write(record []string) error {
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	values := make([]any, len(record))
+	for i, v := range record {
+		values[i] = v
+	}
+	return x.stream.SetRow(cell, values)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.out)
+}
@@ -0,0 +1,81 @@
+package tabular_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"sarc/pkg/tabular"
+)
+
+func TestListCells(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []string
+		cell  string
+		split []string
+	}{
+		{name: "empty", items: nil, cell: "", split: nil},
+		{name: "one item", items: []string{"HDMI"}, cell: "HDMI", split: []string{"HDMI"}},
+		{name: "several items", items: []string{"HDMI", "VGA", "4K"}, cell: "HDMI; VGA; 4K", split: []string{"HDMI", "VGA", "4K"}},
+		{name: "commas stay inside an item", items: []string{"Stewart, J.", "Thomas"}, cell: "Stewart, J.; Thomas", split: []string{"Stewart, J.", "Thomas"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tabular.JoinList(tt.items); got != tt.cell {
+				t.Errorf("JoinList = %q, want %q", got, tt.cell)
+			}
+			if got := tabular.SplitList(tt.cell); !reflect.DeepEqual(got, tt.split) {
+				t.Errorf("SplitList = %q, want %q", got, tt.split)
+			}
+		})
+	}
+	// Hand-written cells may be sloppier than JoinList's output
+	if got := tabular.SplitList(" HDMI ;; VGA;"); !reflect.DeepEqual(got, []string{"HDMI", "VGA"}) {
+		t.Errorf("SplitList dropped the wrong items: %q", got)
+	}
+}
+
+func TestWriteThenRead(t *testing.T) {
+	records := [][]string{
+		{"name", "characteristics", "note"},
+		{"Epson", tabular.JoinList([]string{"HDMI", "VGA"}), `says "hi", twice`},
+		{"Sala 101", "", "ç ã é"},
+	}
+	for _, format := range []string{tabular.FormatCSV, tabular.FormatXLSX} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := tabular.NewWriter(&buf, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, record := range records {
+				if err := w.Write(record); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			table, err := tabular.Read(&buf, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(table.Header, records[0]) || table.HeaderLine != 1 || len(table.Rows) != 2 {
+				t.Fatalf("unexpected table: %+v", table)
+			}
+			for i, row := range table.Rows {
+				want := records[i+1]
+				// XLSX drops trailing empty cells
+				got := append(row.Values, make([]string, len(want)-len(row.Values))...)
+				if !reflect.DeepEqual(got, want) || row.Line != i+2 {
+					t.Errorf("row %d: got %q at line %d, want %q at line %d", i, row.Values, row.Line, want, i+2)
+				}
+			}
+			if got := tabular.SplitList(table.Rows[0].Values[1]); !reflect.DeepEqual(got, []string{"HDMI", "VGA"}) {
+				t.Errorf("list cell did not survive the round trip: %q", got)
+			}
+		})
+	}
+}